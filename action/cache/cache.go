@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import "github.com/go-vela/cli/internal/output"
+
+// Config represents the configuration necessary
+// to perform cache related requests with Vela.
+type Config struct {
+	Action string
+	Org    string
+	Repo   string
+	Name   string
+	All    bool
+	Output string
+	Color  output.ColorOptions
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/cli/internal"
+)
+
+// testCaches is the output of inspecting the cache volumes used in tests.
+const testCaches = `[
+  {
+    "CreatedAt": "2024-01-01T00:00:00Z",
+    "Labels": {"io.vela.cache.name": "gomod", "io.vela.cache.org": "github", "io.vela.cache.repo": "octocat"},
+    "Mountpoint": "/var/lib/docker/volumes/vela-cache_github_octocat_gomod/_data",
+    "Name": "vela-cache_github_octocat_gomod"
+  },
+  {
+    "CreatedAt": "2024-01-02T00:00:00Z",
+    "Labels": {"io.vela.cache.name": "npm", "io.vela.cache.org": "github", "io.vela.cache.repo": "octocat"},
+    "Mountpoint": "/var/lib/docker/volumes/vela-cache_github_octocat_npm/_data",
+    "Name": "vela-cache_github_octocat_npm"
+  }
+]`
+
+// testDocker replaces the docker CLI with a fake returning the test
+// caches and recording the volumes removed.
+func testDocker(t *testing.T) *[]string {
+	t.Helper()
+
+	docker := internal.Docker
+
+	t.Cleanup(func() { internal.Docker = docker })
+
+	removed := []string{}
+
+	internal.Docker = func(_ context.Context, args ...string) (string, error) {
+		switch strings.Join(args[:2], " ") {
+		case "volume ls":
+			return "vela-cache_github_octocat_gomod\nvela-cache_github_octocat_npm", nil
+		case "volume inspect":
+			return testCaches, nil
+		case "volume rm":
+			removed = append(removed, args[2])
+
+			return args[2], nil
+		}
+
+		return "", errors.New("unexpected docker command")
+	}
+
+	return &removed
+}
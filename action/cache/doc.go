@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache provides the defined CLI cache actions for Vela.
+//
+// Usage:
+//
+//	import "github.com/go-vela/cli/action/cache"
+package cache
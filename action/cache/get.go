@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// Get captures a list of caches based off the provided configuration.
+func (c *Config) Get(ctx context.Context) error {
+	logrus.Debug("executing get for cache configuration")
+
+	logrus.Tracef("capturing caches for %s/%s", c.Org, c.Repo)
+
+	// send docker call to capture a list of caches
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#ListCaches
+	caches, err := internal.ListCaches(ctx, c.Org, c.Repo)
+	if err != nil {
+		return err
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverDump:
+		// output the caches in dump format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Dump
+		return output.Dump(caches)
+	case output.DriverJSON:
+		// output the caches in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(caches, c.Color)
+	case output.DriverSpew:
+		// output the caches in spew format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Spew
+		return output.Spew(caches)
+	case "wide":
+		// output the caches in wide table format
+		return wideTable(caches)
+	case output.DriverYAML:
+		// output the caches in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(caches, c.Color)
+	default:
+		// output the caches in table format
+		return table(caches)
+	}
+}
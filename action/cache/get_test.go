@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+)
+
+func TestCache_Config_Get(t *testing.T) {
+	// setup types
+	testDocker(t)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Org:    "github",
+				Repo:   "octocat",
+				Output: "",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Output: "dump",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Output: "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Output: "spew",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Output: "wide",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Output: "yaml",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.Get(t.Context())
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Get should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Get returned err: %v", err)
+		}
+	}
+}
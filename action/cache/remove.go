@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// Remove deletes one or all caches based on the provided configuration.
+func (c *Config) Remove(ctx context.Context) error {
+	logrus.Debug("executing remove for cache configuration")
+
+	names := []string{c.Name}
+
+	// check if all caches for the repo should be removed
+	if c.All {
+		// send docker call to capture a list of caches
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#ListCaches
+		caches, err := internal.ListCaches(ctx, c.Org, c.Repo)
+		if err != nil {
+			return err
+		}
+
+		names = []string{}
+
+		for _, cache := range caches {
+			names = append(names, cache.Name)
+		}
+	}
+
+	removed := []string{}
+
+	for _, name := range names {
+		logrus.Tracef("removing cache %s/%s/%s", c.Org, c.Repo, name)
+
+		// send docker call to remove the cache
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#RemoveCache
+		err := internal.RemoveCache(ctx, c.Org, c.Repo, name)
+		if err != nil {
+			return err
+		}
+
+		removed = append(removed, fmt.Sprintf("cache %s removed for repo %s/%s", name, c.Org, c.Repo))
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverDump:
+		// output the msg in dump format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Dump
+		return output.Dump(removed)
+	case output.DriverJSON:
+		// output the msg in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(removed, c.Color)
+	case output.DriverSpew:
+		// output the msg in spew format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Spew
+		return output.Spew(removed)
+	case output.DriverYAML:
+		// output the msg in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(removed, c.Color)
+	default:
+		// check if no caches were found to remove
+		if len(removed) == 0 {
+			return output.Stdout(fmt.Sprintf("no caches found for repo %s/%s", c.Org, c.Repo))
+		}
+
+		// output the msg in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		for _, msg := range removed {
+			err := output.Stdout(msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCache_Config_Remove(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+		want    []string
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+				Output: "",
+			},
+			want: []string{"vela-cache_github_octocat_gomod"},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				All:    true,
+				Output: "",
+			},
+			want: []string{"vela-cache_github_octocat_gomod", "vela-cache_github_octocat_npm"},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+				Output: "dump",
+			},
+			want: []string{"vela-cache_github_octocat_gomod"},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+				Output: "json",
+			},
+			want: []string{"vela-cache_github_octocat_gomod"},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+				Output: "spew",
+			},
+			want: []string{"vela-cache_github_octocat_gomod"},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+				Output: "yaml",
+			},
+			want: []string{"vela-cache_github_octocat_gomod"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		removed := testDocker(t)
+
+		err := test.config.Remove(t.Context())
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Remove should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Remove returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(*removed, test.want) {
+			t.Errorf("Remove removed %v, want %v", *removed, test.want)
+		}
+	}
+}
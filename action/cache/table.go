@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"github.com/gosuri/uitable"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// table is a helper function to output the
+// provided caches in a table format with
+// a specific set of fields displayed.
+func table(caches []*internal.Cache) error {
+	logrus.Debug("creating table for list of caches")
+
+	// create a new table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for table to 50
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 50
+
+	// ensure the table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	logrus.Trace("adding headers to cache table")
+
+	// set of cache fields we display in a table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("NAME", "ORG", "REPO", "CREATED")
+
+	// iterate through all caches in the list
+	for _, c := range caches {
+		logrus.Tracef("adding cache %s to cache table", c.Volume)
+
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(c.Name, c.Org, c.Repo, c.Created)
+	}
+
+	// output the table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
+
+// wideTable is a helper function to output the
+// provided caches in a wide table format with
+// a specific set of fields displayed.
+func wideTable(caches []*internal.Cache) error {
+	logrus.Debug("creating wide table for list of caches")
+
+	// create new wide table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for wide table to 200
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 200
+
+	// ensure the wide table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	logrus.Trace("adding headers to wide cache table")
+
+	// set of cache fields we display in a wide table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("NAME", "ORG", "REPO", "VOLUME", "MOUNTPOINT", "CREATED")
+
+	// iterate through all caches in the list
+	for _, c := range caches {
+		logrus.Tracef("adding cache %s to wide cache table", c.Volume)
+
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(c.Name, c.Org, c.Repo, c.Volume, c.Mountpoint, c.Created)
+	}
+
+	// output the wide table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-vela/cli/internal"
+)
+
+func TestCache_table(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		caches  []*internal.Cache
+	}{
+		{
+			failure: false,
+			caches:  testCacheList(),
+		},
+		{
+			failure: false,
+			caches:  []*internal.Cache{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := table(test.caches)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("table should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("table returned err: %v", err)
+		}
+	}
+}
+
+func TestCache_wideTable(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		caches  []*internal.Cache
+	}{
+		{
+			failure: false,
+			caches:  testCacheList(),
+		},
+		{
+			failure: false,
+			caches:  []*internal.Cache{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := wideTable(test.caches)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("wideTable should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("wideTable returned err: %v", err)
+		}
+	}
+}
+
+// testCacheList is a test helper function to create
+// a list of caches for use in table tests.
+func testCacheList() []*internal.Cache {
+	return []*internal.Cache{
+		{
+			Name:       "gomod",
+			Org:        "github",
+			Repo:       "octocat",
+			Volume:     "vela-cache_github_octocat_gomod",
+			Mountpoint: "/var/lib/docker/volumes/vela-cache_github_octocat_gomod/_data",
+			Created:    "2024-01-01T00:00:00Z",
+		},
+		{
+			Name:       "npm",
+			Org:        "github",
+			Repo:       "octocat",
+			Volume:     "vela-cache_github_octocat_npm",
+			Mountpoint: "/var/lib/docker/volumes/vela-cache_github_octocat_npm/_data",
+			Created:    "2024-01-02T00:00:00Z",
+		},
+	}
+}
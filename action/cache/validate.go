@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+)
+
+// Validate verifies the configuration provided.
+func (c *Config) Validate() error {
+	logrus.Debug("validating cache configuration")
+
+	// check if the repo is set without the org
+	if len(c.Repo) > 0 && len(c.Org) == 0 {
+		return fmt.Errorf("no cache org provided")
+	}
+
+	// check if the action is remove
+	if c.Action == internal.ActionRemove {
+		// check if cache org is set
+		if len(c.Org) == 0 {
+			return fmt.Errorf("no cache org provided")
+		}
+
+		// check if cache repo is set
+		if len(c.Repo) == 0 {
+			return fmt.Errorf("no cache repo provided")
+		}
+
+		// check if cache name or all is set
+		if len(c.Name) == 0 && !c.All {
+			return fmt.Errorf("no cache name provided")
+		}
+
+		// check if both cache name and all are set
+		if len(c.Name) > 0 && c.All {
+			return fmt.Errorf("cache name and all can not both be provided")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+)
+
+func TestCache_Config_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "get",
+				Org:    "github",
+				Repo:   "octocat",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "get",
+				Repo:   "octocat",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				All:    true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "remove",
+				Repo:   "octocat",
+				Name:   "gomod",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Name:   "gomod",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "remove",
+				Org:    "github",
+				Repo:   "octocat",
+				Name:   "gomod",
+				All:    true,
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
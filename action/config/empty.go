@@ -65,6 +65,11 @@ func (c *ConfigFile) Empty() bool {
 		}
 	}
 
+	// check if the caches are set
+	if len(c.Cache) > 0 {
+		return false
+	}
+
 	// check if the output is set
 	if len(c.Output) > 0 {
 		return false
@@ -106,6 +106,12 @@ func TestConfig_ConfigFile_Empty(t *testing.T) {
 				Repo: "octocat",
 			},
 		},
+		{
+			want: false,
+			config: &ConfigFile{
+				Cache: map[string]string{"gomod": "/root/go/pkg/mod"},
+			},
+		},
 	}
 
 	// run tests
@@ -3,6 +3,9 @@
 package config
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -315,6 +318,25 @@ func (c *Config) Load(cmd *cli.Command) error {
 
 			continue
 		}
+
+		// check if the cache flag is available
+		// and if it is set in the context
+		//
+		// the flag names are matched exactly to avoid
+		// setting other flags containing the word cache
+		if slices.Contains(flag.Names(), internal.FlagCache) &&
+			!cmd.IsSet(internal.FlagCache) &&
+			len(config.Cache) > 0 {
+			// set the cache field to values from config
+			for _, name := range slices.Sorted(maps.Keys(config.Cache)) {
+				err = cmd.Set(internal.FlagCache, fmt.Sprintf("%s:%s", name, config.Cache[name]))
+				if err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
 	}
 
 	return nil
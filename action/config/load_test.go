@@ -3,6 +3,8 @@
 package config
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -160,3 +162,79 @@ func TestConfig_Config_Load(t *testing.T) {
 		}
 	}
 }
+
+func TestConfig_Config_Load_Cache(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	data := []byte("cache:\n  npm: /root/.npm\n  gomod: /root/go/pkg/mod\n")
+
+	err := a.WriteFile("config.yml", data, 0600)
+	if err != nil {
+		t.Errorf("unable to write config: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{
+			args: []string{"test"},
+			want: []string{"gomod:/root/go/pkg/mod", "npm:/root/.npm"},
+		},
+		{
+			args: []string{"test", "--cache", "maven:/root/.m2"},
+			want: []string{"maven:/root/.m2"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		var got []string
+
+		cmd := &cli.Command{
+			Name: "test",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name: "cache",
+				},
+				&cli.StringSliceFlag{
+					Name: "template-cache",
+				},
+			},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				config := &Config{
+					Action: "load",
+					File:   "config.yml",
+				}
+
+				err := config.Load(cmd)
+				if err != nil {
+					return err
+				}
+
+				got = cmd.StringSlice("cache")
+
+				if cmd.IsSet("template-cache") {
+					t.Errorf("Load should not have set the template-cache flag")
+				}
+
+				return nil
+			},
+		}
+
+		err := cmd.Run(t.Context(), test.args)
+		if err != nil {
+			t.Errorf("Load returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Load set cache to %v, want %v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+)
+
+// mountCaches creates, or reuses, the Docker volumes for the
+// provided caches scoped to the org and repo and returns the
+// named volumes to mount into every container in the pipeline
+// in the form <volume>:<container-path>.
+func (c *Config) mountCaches(ctx context.Context) ([]string, error) {
+	mounts := []string{}
+
+	for _, cache := range c.Caches {
+		name, target, err := internal.ParseCache(cache)
+		if err != nil {
+			return nil, err
+		}
+
+		// create the volume for the cache
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#CreateCache
+		volume, err := internal.CreateCache(ctx, c.Org, c.Repo, name)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.Debugf("mounting cache %s from volume %s to %s", name, volume.Volume, target)
+
+		mounts = append(mounts, fmt.Sprintf("%s:%s", volume.Volume, target))
+	}
+
+	return mounts, nil
+}
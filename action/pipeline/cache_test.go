@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/cli/internal"
+)
+
+func TestPipeline_Config_mountCaches(t *testing.T) {
+	// setup types
+	docker := internal.Docker
+
+	t.Cleanup(func() { internal.Docker = docker })
+
+	// fake the docker CLI returning a volume for every cache
+	internal.Docker = func(_ context.Context, args ...string) (string, error) {
+		volume := args[len(args)-1]
+
+		if args[1] == "create" {
+			return volume, nil
+		}
+
+		return fmt.Sprintf(`[{"Name": %q, "Mountpoint": "/var/lib/docker/volumes/%s/_data"}]`, volume, volume), nil
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+		want    []string
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Org:    "github",
+				Repo:   "octocat",
+				Caches: []string{"gomod:/root/go/pkg/mod", "npm:/root/.npm"},
+			},
+			want: []string{
+				"vela-cache_github_octocat_gomod:/root/go/pkg/mod",
+				"vela-cache_github_octocat_npm:/root/.npm",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Org:  "github",
+				Repo: "octocat",
+			},
+			want: []string{},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Org:    "github",
+				Repo:   "octocat",
+				Caches: []string{"gomod"},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := test.config.mountCaches(context.Background())
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("mountCaches should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("mountCaches returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("mountCaches is %v, want %v", got, test.want)
+		}
+	}
+}
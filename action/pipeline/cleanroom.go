@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+)
+
+// cleanRoom represents a temporary workspace containing a
+// clean export of the committed tree of a git repository.
+type cleanRoom struct {
+	// repo is the root of the exported git repository.
+	repo string
+	// root is the temporary directory holding the export.
+	root string
+	// workspace is the directory mounted into the pipeline.
+	workspace string
+	// commit is the hash of the exported commit.
+	commit string
+}
+
+// setupCleanRoom exports the committed tree of the git repository
+// containing base into a temporary workspace. The workspace mirrors
+// the location of base within the repository.
+func (c *Config) setupCleanRoom(base string) (*cleanRoom, error) {
+	ref := c.CleanRoomRef
+	if len(ref) == 0 {
+		ref = "HEAD"
+	}
+
+	logrus.Debugf("creating clean room workspace from git revision %s", ref)
+
+	// capture the root of the git repository
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#GetGitRoot
+	repoRoot, err := internal.GetGitRoot(base)
+	if err != nil {
+		return nil, fmt.Errorf("clean room requires a git repository: %w", err)
+	}
+
+	rel, err := filepath.Rel(repoRoot, base)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.MkdirTemp("", "vela-clean-room-")
+	if err != nil {
+		return nil, err
+	}
+
+	// export the committed tree into the temporary directory
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#ExportGitTree
+	commit, err := internal.ExportGitTree(repoRoot, ref, root)
+	if err != nil {
+		os.RemoveAll(root)
+
+		return nil, err
+	}
+
+	workspace := filepath.Join(root, rel)
+
+	// ensure the workspace exists even when base has no committed files
+	err = os.MkdirAll(workspace, 0755)
+	if err != nil {
+		os.RemoveAll(root)
+
+		return nil, err
+	}
+
+	logrus.Infof("running pipeline in clean room at commit %s", commit)
+
+	return &cleanRoom{
+		repo:      repoRoot,
+		root:      root,
+		workspace: workspace,
+		commit:    commit,
+	}, nil
+}
+
+// pipelinePath returns the path of the pipeline file within the
+// clean room when the file exists in the exported commit.
+func (r *cleanRoom) pipelinePath(path string) string {
+	rel, err := filepath.Rel(r.repo, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		logrus.Warnf("pipeline %s is outside of the clean room - using local file", path)
+
+		return path
+	}
+
+	cleanPath := filepath.Join(r.root, rel)
+
+	_, err = os.Stat(cleanPath)
+	if err != nil {
+		logrus.Warnf("pipeline %s is not committed - using local file", rel)
+
+		return path
+	}
+
+	return cleanPath
+}
+
+// teardown copies the artifacts matching the provided
+// patterns back to base and removes the clean room.
+func (r *cleanRoom) teardown(base string, patterns []string) {
+	err := exportArtifacts(r.workspace, base, patterns)
+	if err != nil {
+		logrus.Errorf("unable to export artifacts: %v", err)
+	}
+
+	logrus.Debugf("removing clean room workspace %s", r.root)
+
+	err = os.RemoveAll(r.root)
+	if err != nil {
+		// the containers may create files the user is not allowed to remove
+		if errors.Is(err, fs.ErrPermission) {
+			logrus.Errorf("unable to remove files created by the pipeline in clean room workspace %s - remove it manually, e.g. with `sudo rm -rf %s`: %v", r.root, r.root, err)
+
+			return
+		}
+
+		logrus.Errorf("unable to remove clean room workspace %s: %v", r.root, err)
+	}
+}
+
+// exportArtifacts copies the files and directories within
+// src matching the provided glob patterns, where `**` matches
+// across directories, to the same relative location within dst.
+func exportArtifacts(src, dst string, patterns []string) error {
+	for _, pattern := range patterns {
+		// prevent patterns from escaping the workspace
+		if filepath.IsAbs(pattern) || slices.Contains(strings.Split(filepath.ToSlash(pattern), "/"), "..") {
+			return fmt.Errorf("artifact pattern %s is outside of the workspace", pattern)
+		}
+
+		matcher := globToRegexp(filepath.Clean(pattern))
+		matches := []string{}
+
+		err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(src, path)
+			if err != nil || rel == "." {
+				return err
+			}
+
+			if !matcher.MatchString(filepath.ToSlash(rel)) {
+				return nil
+			}
+
+			matches = append(matches, rel)
+
+			// the matching directory is copied with all of its contents
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to find artifacts matching %s: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			logrus.Warnf("no artifacts found matching %s", pattern)
+
+			continue
+		}
+
+		for _, rel := range matches {
+			logrus.Infof("exporting artifact %s", rel)
+
+			err = copyPath(filepath.Join(src, rel), filepath.Join(dst, rel))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// copyPath recursively copies the file or directory at src to dst.
+func copyPath(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, 0755)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			os.Remove(target)
+
+			return os.Symlink(link, target)
+		}
+
+		err = os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			return err
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, in)
+
+		return err
+	})
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestPipeline_Config_setupCleanRoom(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	hash := testGitCommit(t, r, dir, "app/.vela.yml", "version: 1")
+
+	// create an untracked file which should not be in the clean room
+	err = os.WriteFile(filepath.Join(dir, "app", "local.txt"), []byte("local"), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	c := &Config{CleanRoom: true}
+
+	// run test
+	room, err := c.setupCleanRoom(filepath.Join(dir, "app"))
+	if err != nil {
+		t.Fatalf("setupCleanRoom returned err: %v", err)
+	}
+
+	if room.commit != hash.String() {
+		t.Errorf("clean room commit is %s, want %s", room.commit, hash.String())
+	}
+
+	if room.workspace != filepath.Join(room.root, "app") {
+		t.Errorf("clean room workspace is %s, want %s", room.workspace, filepath.Join(room.root, "app"))
+	}
+
+	_, err = os.Stat(filepath.Join(room.workspace, "local.txt"))
+	if err == nil {
+		t.Errorf("clean room should not contain untracked files")
+	}
+
+	got := room.pipelinePath(filepath.Join(dir, "app", ".vela.yml"))
+	if got != filepath.Join(room.workspace, ".vela.yml") {
+		t.Errorf("pipelinePath is %s, want %s", got, filepath.Join(room.workspace, ".vela.yml"))
+	}
+
+	got = room.pipelinePath(filepath.Join(dir, "app", "local.txt"))
+	if got != filepath.Join(dir, "app", "local.txt") {
+		t.Errorf("pipelinePath is %s for uncommitted file", got)
+	}
+
+	// create an artifact within the clean room
+	err = os.MkdirAll(filepath.Join(room.workspace, "dist"), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(room.workspace, "dist", "app.bin"), []byte("binary"), 0755)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	room.teardown(filepath.Join(dir, "app"), []string{"dist/*", "notfound/*"})
+
+	_, err = os.Stat(filepath.Join(dir, "app", "dist", "app.bin"))
+	if err != nil {
+		t.Errorf("teardown did not export artifact: %v", err)
+	}
+
+	_, err = os.Stat(room.root)
+	if err == nil {
+		t.Errorf("teardown did not remove clean room %s", room.root)
+	}
+
+	_, err = c.setupCleanRoom(t.TempDir())
+	if err == nil {
+		t.Errorf("setupCleanRoom should have returned err outside of a git repository")
+	}
+}
+
+func TestPipeline_exportArtifacts(t *testing.T) {
+	// setup types
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	err := os.MkdirAll(filepath.Join(src, "build", "nested"), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(src, "build", "nested", "out.txt"), []byte("out"), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	// run test
+	err = exportArtifacts(src, dst, []string{"build"})
+	if err != nil {
+		t.Errorf("exportArtifacts returned err: %v", err)
+	}
+
+	_, err = os.Stat(filepath.Join(dst, "build", "nested", "out.txt"))
+	if err != nil {
+		t.Errorf("exportArtifacts did not copy directory: %v", err)
+	}
+
+	err = exportArtifacts(src, dst, []string{"../*"})
+	if err == nil {
+		t.Errorf("exportArtifacts should have returned err for pattern outside of workspace")
+	}
+
+	err = exportArtifacts(src, dst, []string{"/tmp/*"})
+	if err == nil {
+		t.Errorf("exportArtifacts should have returned err for absolute pattern")
+	}
+
+	err = os.WriteFile(filepath.Join(src, "build", "nested", "report.xml"), []byte("report"), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	err = exportArtifacts(src, dst, []string{"**/*.xml"})
+	if err != nil {
+		t.Errorf("exportArtifacts returned err: %v", err)
+	}
+
+	_, err = os.Stat(filepath.Join(dst, "build", "nested", "report.xml"))
+	if err != nil {
+		t.Errorf("exportArtifacts did not copy file matching **: %v", err)
+	}
+}
@@ -5,11 +5,14 @@ package pipeline
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/go-vela/cli/internal/output"
 	"github.com/go-vela/sdk-go/vela"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/constants"
 )
 
 // Compile compiles a pipeline based off the provided configuration.
@@ -62,3 +65,52 @@ func (c *Config) Compile(ctx context.Context, client *vela.Client) error {
 		return output.Stdout(pipeline)
 	}
 }
+
+// CompileLocal compiles a local pipeline, with the same compiler used to
+// execute the pipeline, based off the provided configuration.
+func (c *Config) CompileLocal(ctx context.Context, client compiler.Engine) error {
+	logrus.Debug("executing compile for local pipeline configuration")
+
+	_, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	// compile the pipeline as it would be executed
+	_pipeline, _, err := c.compileLocal(ctx, client, path, filepath.Dir(path), "")
+	if err != nil {
+		return err
+	}
+
+	// sanitize the pipeline as it would be provided to the runtime
+	pipeline := _pipeline.Sanitize(constants.DriverDocker)
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverDump:
+		// output the pipeline in dump format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Dump
+		return output.Dump(pipeline)
+	case output.DriverJSON:
+		// output the pipeline in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(pipeline, c.Color)
+	case output.DriverSpew:
+		// output the pipeline in spew format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Spew
+		return output.Spew(pipeline)
+	case output.DriverYAML:
+		// output the pipeline in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(pipeline, c.Color)
+	default:
+		// output the pipeline in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(pipeline)
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/urfave/cli/v3"
+
 	"github.com/go-vela/sdk-go/vela"
+	"github.com/go-vela/server/compiler/native"
 	"github.com/go-vela/server/mock/server"
 )
 
@@ -104,3 +107,97 @@ func TestPipeline_Config_Compile(t *testing.T) {
 		}
 	}
 }
+
+func TestPipeline_Config_CompileLocal(t *testing.T) {
+	// setup types
+	cmd := new(cli.Command)
+	cmd.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone-image",
+			Value: "target/vela-git:latest",
+		},
+	}
+
+	// create a compiler client
+	client, err := native.FromCLICommand(t.Context(), cmd)
+	if err != nil {
+		t.Errorf("unable to create client: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   "default.yml",
+				Path:   "testdata",
+				Event:  "push",
+				Output: "",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   "stages_default.yml",
+				Path:   "testdata",
+				Event:  "push",
+				Output: "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   "default.yml",
+				Path:   "testdata",
+				Event:  "push",
+				Output: "yaml",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   "notfound.yml",
+				Path:   "testdata",
+				Event:  "push",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   "default.yml",
+				Path:   "testdata",
+				Event:  "delete",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.CompileLocal(t.Context(), client)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CompileLocal should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CompileLocal returned err: %v", err)
+		}
+	}
+}
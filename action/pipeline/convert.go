@@ -0,0 +1,723 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/constants"
+)
+
+// CI systems that pipelines can be converted from.
+const (
+	ConvertDrone         = "drone"
+	ConvertGitHubActions = "github-actions"
+	ConvertGitLab        = "gitlab"
+)
+
+// convertImage is the image used for steps when
+// the image can not be converted from the source.
+const convertImage = "alpine:latest"
+
+// convertBuild represents a pipeline converted from another CI system.
+type convertBuild struct {
+	Environment map[string]string
+	Secrets     []string
+	Services    []*convertStep
+	Stages      []*convertStage
+	Steps       []*convertStep
+	TODO        []string
+}
+
+// convertStage represents a stage converted from another CI system.
+type convertStage struct {
+	Name  string
+	Needs []string
+	Steps []*convertStep
+	TODO  []string
+}
+
+// convertStep represents a step or service converted from another CI system.
+type convertStep struct {
+	Name        string            `yaml:"name"`
+	Image       string            `yaml:"image,omitempty"`
+	Pull        string            `yaml:"pull,omitempty"`
+	Detach      bool              `yaml:"detach,omitempty"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+	Secrets     []any             `yaml:"secrets,omitempty"`
+	Parameters  map[string]any    `yaml:"parameters,omitempty"`
+	Commands    []string          `yaml:"commands,omitempty"`
+	Ruleset     *convertRuleset   `yaml:"ruleset,omitempty"`
+	TODO        []string          `yaml:"-"`
+}
+
+// convertStepSecret represents a secret injected into a
+// step with a different name for the environment variable.
+type convertStepSecret struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+}
+
+// convertSecret represents a secret declared in a converted pipeline.
+type convertSecret struct {
+	Name   string `yaml:"name"`
+	Key    string `yaml:"key"`
+	Engine string `yaml:"engine"`
+	Type   string `yaml:"type"`
+}
+
+// convertRuleset represents a ruleset converted from
+// the conditions of another CI system.
+type convertRuleset struct {
+	If       convertRules `yaml:"if,omitempty"`
+	Unless   convertRules `yaml:"unless,omitempty"`
+	Continue bool         `yaml:"continue,omitempty"`
+}
+
+// convertRules represents the rules of a converted ruleset.
+type convertRules struct {
+	Branch []string `yaml:"branch,omitempty"`
+	Event  []string `yaml:"event,omitempty"`
+	Path   []string `yaml:"path,omitempty"`
+	Status []string `yaml:"status,omitempty"`
+	Tag    []string `yaml:"tag,omitempty"`
+	Target []string `yaml:"target,omitempty"`
+}
+
+// convertList represents a value of another CI system
+// that can be a single value or a list of values.
+type convertList []string
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface
+// and flattens nested lists of values.
+func (l *convertList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*l = append(*l, node.Value)
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			err := l.UnmarshalYAML(yamlResolve(item))
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.AliasNode:
+		return l.UnmarshalYAML(yamlResolve(node))
+	default:
+		return fmt.Errorf("line %d: unable to convert a mapping into a list", node.Line)
+	}
+
+	return nil
+}
+
+// Convert converts a pipeline from another CI system into
+// a Vela pipeline based off the provided configuration.
+func (c *Config) Convert(ctx context.Context, client compiler.Engine) error {
+	logrus.Debugf("executing convert for %s pipeline %s", c.From, c.Source)
+
+	data, err := os.ReadFile(c.Source)
+	if err != nil {
+		return err
+	}
+
+	var build *convertBuild
+
+	switch c.From {
+	case ConvertDrone:
+		build, err = convertFromDrone(data)
+	case ConvertGitHubActions:
+		build, err = convertFromGitHubActions(data)
+	case ConvertGitLab:
+		build, err = convertFromGitLab(data)
+	default:
+		return fmt.Errorf("unable to convert pipelines from %s", c.From)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to convert %s: %w", c.Source, err)
+	}
+
+	out, err := build.marshal(c.Org, c.Repo)
+	if err != nil {
+		return err
+	}
+
+	// use custom filesystem which enables us to test
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#Afero
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// send Filesystem call to capture base directory path
+	base, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	// create full path for pipeline file
+	path := filepath.Join(base, c.File)
+
+	// check if custom path was provided for pipeline file
+	if len(c.Path) > 0 {
+		// create custom full path for pipeline file
+		path = filepath.Join(c.Path, c.File)
+	}
+
+	found, err := a.Exists(path)
+	if err != nil {
+		return err
+	}
+
+	// check if an existing pipeline would be overwritten
+	if found && !c.Force {
+		return fmt.Errorf("pipeline file %s already exists, use --force to overwrite it", path)
+	}
+
+	logrus.Tracef("writing converted pipeline to %s", path)
+
+	// send Filesystem call to create directory path for pipeline file
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#OsFs.MkdirAll
+	err = a.MkdirAll(filepath.Dir(path), 0777)
+	if err != nil {
+		return err
+	}
+
+	// send Filesystem call to create pipeline file
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#Afero.WriteFile
+	err = a.WriteFile(path, out, 0644)
+	if err != nil {
+		return err
+	}
+
+	// output the message in stderr format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+	err = output.Stderr(fmt.Sprintf("converted %s to %s with %d TODO comments", c.Source, path, strings.Count(string(out), "TODO:")))
+	if err != nil {
+		return err
+	}
+
+	// validate the converted pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.ValidateLocal
+	v := &Config{
+		Action:       internal.ActionValidate,
+		File:         c.File,
+		Path:         c.Path,
+		PipelineType: constants.PipelineTypeYAML,
+		Color:        c.Color,
+	}
+
+	err = v.ValidateLocal(ctx, client)
+	if err != nil {
+		return fmt.Errorf("converted pipeline %s is invalid: %w", path, err)
+	}
+
+	return nil
+}
+
+// marshal creates the formatted pipeline, with the TODO comments
+// for the constructs that could not be converted.
+func (b *convertBuild) marshal(org, repo string) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	add := func(key string, value any) error {
+		node, ok := value.(*yaml.Node)
+		if !ok {
+			node = new(yaml.Node)
+
+			err := node.Encode(value)
+			if err != nil {
+				return err
+			}
+		}
+
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, node)
+
+		return nil
+	}
+
+	err := add("version", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "1"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.Environment) > 0 {
+		err = add("environment", b.Environment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.Secrets) > 0 {
+		todo := false
+
+		// create the key of the secrets for the repository
+		key := fmt.Sprintf("%s/%s", org, repo)
+		if len(org) == 0 || len(repo) == 0 {
+			key = "ORG/REPO"
+			todo = true
+		}
+
+		secrets := []*convertSecret{}
+
+		for _, name := range b.Secrets {
+			secrets = append(secrets, &convertSecret{
+				Name:   name,
+				Key:    fmt.Sprintf("%s/%s", key, name),
+				Engine: constants.DriverNative,
+				Type:   constants.SecretRepo,
+			})
+		}
+
+		err = add("secrets", secrets)
+		if err != nil {
+			return nil, err
+		}
+
+		if todo {
+			root.Content[len(root.Content)-2].HeadComment = convertComment([]string{"replace ORG/REPO in the keys with the org and repo of the secrets"})
+		}
+	}
+
+	if len(b.Services) > 0 {
+		services, err := convertSteps(b.Services)
+		if err != nil {
+			return nil, err
+		}
+
+		err = add("services", services)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.Stages) > 0 {
+		stages := &yaml.Node{Kind: yaml.MappingNode}
+
+		for _, stage := range b.Stages {
+			steps, err := convertSteps(stage.Steps)
+			if err != nil {
+				return nil, err
+			}
+
+			value := &yaml.Node{Kind: yaml.MappingNode}
+
+			if len(stage.Needs) > 0 {
+				needs := new(yaml.Node)
+
+				err = needs.Encode(stage.Needs)
+				if err != nil {
+					return nil, err
+				}
+
+				value.Content = append(value.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "needs"}, needs)
+			}
+
+			value.Content = append(value.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "steps"}, steps)
+
+			stages.Content = append(stages.Content, &yaml.Node{
+				Kind:        yaml.ScalarNode,
+				Value:       stage.Name,
+				HeadComment: convertComment(stage.TODO),
+			}, value)
+		}
+
+		err = add("stages", stages)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.Steps) > 0 {
+		steps, err := convertSteps(b.Steps)
+		if err != nil {
+			return nil, err
+		}
+
+		err = add("steps", steps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	root.Content[0].HeadComment = convertComment(b.TODO)
+
+	out, err := yaml.Marshal(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+	if err != nil {
+		return nil, err
+	}
+
+	return format(out)
+}
+
+// convertSteps creates the sequence node for the steps with
+// the TODO comments of each step above the step.
+func convertSteps(steps []*convertStep) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.SequenceNode}
+
+	for _, step := range steps {
+		value := new(yaml.Node)
+
+		err := value.Encode(step)
+		if err != nil {
+			return nil, err
+		}
+
+		value.HeadComment = convertComment(step.TODO)
+
+		node.Content = append(node.Content, value)
+	}
+
+	return node, nil
+}
+
+// convertComment creates the comment for the TODO items.
+func convertComment(todo []string) string {
+	lines := []string{}
+
+	for _, item := range todo {
+		lines = append(lines, "TODO: "+item)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// addSecret adds the secret to the pipeline when it is not declared.
+func (b *convertBuild) addSecret(name string) {
+	if !slices.Contains(b.Secrets, name) {
+		b.Secrets = append(b.Secrets, name)
+	}
+}
+
+// addService adds the service to the pipeline when
+// a service with the same name is not declared.
+func (b *convertBuild) addService(service *convertStep) {
+	for _, s := range b.Services {
+		if s.Name == service.Name {
+			return
+		}
+	}
+
+	b.Services = append(b.Services, service)
+}
+
+// addSecret injects the secret into the step with the provided
+// name for the environment variable of the secret.
+func (s *convertStep) addSecret(source, target string) {
+	var secret any = source
+
+	if !strings.EqualFold(source, target) {
+		secret = &convertStepSecret{Source: source, Target: target}
+	}
+
+	for _, existing := range s.Secrets {
+		if fmt.Sprint(existing) == fmt.Sprint(secret) {
+			return
+		}
+	}
+
+	s.Secrets = append(s.Secrets, secret)
+}
+
+// addRules adds the rules, and the rules that skip the step,
+// to the ruleset of the step.
+func (s *convertStep) addRules(when, unless convertRules) {
+	if when.empty() && unless.empty() {
+		return
+	}
+
+	if s.Ruleset == nil {
+		s.Ruleset = new(convertRuleset)
+	}
+
+	s.Ruleset.If = s.Ruleset.If.mergeIf(when, &s.TODO)
+	s.Ruleset.Unless = s.Ruleset.Unless.mergeUnless(unless, &s.TODO)
+}
+
+// convertRule represents a single rule of converted rules.
+type convertRule struct {
+	Name   string
+	Values *[]string
+}
+
+// rules returns each of the rules by name.
+func (r *convertRules) rules() []convertRule {
+	return []convertRule{
+		{Name: "branch", Values: &r.Branch},
+		{Name: "event", Values: &r.Event},
+		{Name: "path", Values: &r.Path},
+		{Name: "status", Values: &r.Status},
+		{Name: "tag", Values: &r.Tag},
+		{Name: "target", Values: &r.Target},
+	}
+}
+
+// empty returns true when none of the rules are set.
+func (r convertRules) empty() bool {
+	return r.fields() == 0
+}
+
+// String returns the rules that are set in the form of a ruleset.
+func (r convertRules) String() string {
+	parts := []string{}
+
+	for _, rule := range r.rules() {
+		if len(*rule.Values) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: [%s]", rule.Name, strings.Join(*rule.Values, ", ")))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// mergeIf returns the rules combined with the provided rules, where
+// both rules need to match, adding a TODO for the rules that can't be
+// combined, in which case the rule that is set on the rules is kept.
+func (r convertRules) mergeIf(other convertRules, todo *[]string) convertRules {
+	merged := r
+	others := other.rules()
+
+	for i, rule := range merged.rules() {
+		values, theirs := *rule.Values, *others[i].Values
+
+		switch {
+		case len(theirs) == 0 || convertEqual(values, theirs):
+			continue
+		case len(values) == 0:
+			*rule.Values = theirs
+
+			continue
+		}
+
+		// the build has a single value for these rules, so matching both is
+		// matching the values in both, unless the values contain patterns
+		common := []string{}
+
+		for _, value := range values {
+			if slices.Contains(theirs, value) {
+				common = append(common, value)
+			}
+		}
+
+		if rule.Name != "path" && len(common) > 0 && !convertPattern(values) && !convertPattern(theirs) {
+			*rule.Values = common
+
+			continue
+		}
+
+		*todo = append(*todo, fmt.Sprintf("%s must also match [%s], which can not be combined with [%s]", rule.Name, strings.Join(theirs, ", "), strings.Join(values, ", ")))
+	}
+
+	return merged
+}
+
+// mergeUnless returns the rules combined with the provided rules, where
+// either rules skip the step, adding a TODO when the rules can't be
+// combined, in which case the rules are kept.
+func (r convertRules) mergeUnless(other convertRules, todo *[]string) convertRules {
+	switch {
+	case other.empty() || reflect.DeepEqual(r, other):
+		return r
+	case r.empty():
+		return other
+	}
+
+	// rules set on a single, and the same, field skip the step
+	// when the values of either of the rules match
+	if r.fields() == 1 && other.fields() == 1 {
+		others := other.rules()
+
+		for i, rule := range r.rules() {
+			if len(*rule.Values) > 0 && len(*others[i].Values) > 0 {
+				return r.add(other)
+			}
+		}
+	}
+
+	*todo = append(*todo, fmt.Sprintf("also skip the step for %s, which can not be combined with skipping the step for %s", other, r))
+
+	return r
+}
+
+// add appends the values of the provided rules to the rules,
+// used when either of the rules can match.
+func (r convertRules) add(other convertRules) convertRules {
+	return convertRules{
+		Branch: convertAppend(r.Branch, other.Branch...),
+		Event:  convertAppend(r.Event, other.Event...),
+		Path:   convertAppend(r.Path, other.Path...),
+		Status: convertAppend(r.Status, other.Status...),
+		Tag:    convertAppend(r.Tag, other.Tag...),
+		Target: convertAppend(r.Target, other.Target...),
+	}
+}
+
+// fields returns the number of rules that are set.
+func (r convertRules) fields() int {
+	count := 0
+
+	for _, rule := range r.rules() {
+		if len(*rule.Values) > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// convertEqual returns true when the lists contain the same values.
+func convertEqual(a, b []string) bool {
+	return len(a) == len(b) && !slices.ContainsFunc(a, func(value string) bool {
+		return !slices.Contains(b, value)
+	})
+}
+
+// convertPattern returns true when any of the values is a pattern.
+func convertPattern(values []string) bool {
+	return slices.ContainsFunc(values, func(value string) bool {
+		return strings.ContainsAny(value, "*?[{")
+	})
+}
+
+// convertNegate returns the rules to run the step for
+// the == operator and the rules to skip the step for !=.
+func convertNegate(operator string, when, unless *convertRules) *convertRules {
+	if operator == "!=" {
+		return unless
+	}
+
+	return when
+}
+
+// convertAppend appends the values that are not in the list.
+func convertAppend(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
+
+// convertCommands splits the provided script into commands.
+func convertCommands(script string) []string {
+	commands := []string{}
+
+	for line := range strings.SplitSeq(script, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if len(strings.TrimSpace(line)) > 0 {
+			commands = append(commands, line)
+		}
+	}
+
+	return commands
+}
+
+// convertVariable is the pattern for an environment variable in a script.
+var convertVariable = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)\}?`)
+
+// convertReplace replaces the names of the environment variables in
+// the value that are not escaped with the names returned by replace.
+func convertReplace(value string, replace func(name string) (string, bool)) string {
+	var sb strings.Builder
+
+	last := 0
+
+	for _, match := range convertVariable.FindAllStringSubmatchIndex(value, -1) {
+		sb.WriteString(value[last:match[0]])
+
+		last = match[1]
+
+		// skip escaped variables
+		if match[0] > 0 && value[match[0]-1] == '$' {
+			sb.WriteString(value[match[0]:match[1]])
+
+			continue
+		}
+
+		name, ok := replace(value[match[2]:match[3]])
+		if !ok {
+			sb.WriteString(value[match[0]:match[1]])
+
+			continue
+		}
+
+		sb.WriteString(value[match[0]:match[2]] + name + value[match[3]:match[1]])
+	}
+
+	sb.WriteString(value[last:])
+
+	return sb.String()
+}
+
+// convertName is the pattern for the characters
+// that are replaced in the names of stages.
+var convertName = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// convertStageName returns the name of a stage for the provided values.
+func convertStageName(values ...string) string {
+	return strings.Trim(convertName.ReplaceAllString(strings.Join(values, "-"), "-"), "-")
+}
+
+// convertImageName returns the name of a service for the image.
+func convertImageName(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	name, _, _ = strings.Cut(name, "@")
+	name, _, _ = strings.Cut(name, ":")
+
+	return name
+}
+
+// convertMatrix returns the combinations of the values for the
+// keys of the matrix, in the order the keys are declared.
+func convertMatrix(keys []string, values map[string][]string) []map[string]string {
+	combinations := []map[string]string{{}}
+
+	for _, key := range keys {
+		next := []map[string]string{}
+
+		for _, combination := range combinations {
+			for _, value := range values[key] {
+				c := map[string]string{key: value}
+
+				for k, v := range combination {
+					c[k] = v
+				}
+
+				next = append(next, c)
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
+}
+
+// convertEscape escapes the environment variables in the
+// provided value to prevent the compiler from substituting
+// them, which leaves them to be evaluated by the shell.
+func convertEscape(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
@@ -0,0 +1,411 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/server/constants"
+)
+
+// droneVariables maps the environment variables of
+// Drone to the environment variables of Vela.
+var droneVariables = map[string]string{
+	"DRONE_BRANCH":         "VELA_BUILD_BRANCH",
+	"DRONE_BUILD_EVENT":    "VELA_BUILD_EVENT",
+	"DRONE_BUILD_NUMBER":   "VELA_BUILD_NUMBER",
+	"DRONE_COMMIT":         "VELA_BUILD_COMMIT",
+	"DRONE_COMMIT_AUTHOR":  "VELA_BUILD_AUTHOR",
+	"DRONE_COMMIT_BRANCH":  "VELA_BUILD_BRANCH",
+	"DRONE_COMMIT_MESSAGE": "VELA_BUILD_MESSAGE",
+	"DRONE_COMMIT_REF":     "VELA_BUILD_REF",
+	"DRONE_COMMIT_SHA":     "VELA_BUILD_COMMIT",
+	"DRONE_REPO":           "VELA_REPO_FULL_NAME",
+	"DRONE_REPO_BRANCH":    "VELA_REPO_BRANCH",
+	"DRONE_REPO_NAME":      "VELA_REPO_NAME",
+	"DRONE_REPO_NAMESPACE": "VELA_REPO_ORG",
+	"DRONE_REPO_OWNER":     "VELA_REPO_ORG",
+	"DRONE_SOURCE_BRANCH":  "VELA_PULL_REQUEST_SOURCE",
+	"DRONE_TAG":            "VELA_BUILD_TAG",
+	"DRONE_TARGET_BRANCH":  "VELA_PULL_REQUEST_TARGET",
+	"DRONE_PULL_REQUEST":   "VELA_PULL_REQUEST",
+	"DRONE_DEPLOY_TO":      "VELA_DEPLOYMENT",
+}
+
+// droneEvents maps the events of Drone to the events of Vela.
+var droneEvents = map[string]string{
+	"cron":         constants.EventSchedule,
+	"custom":       "",
+	"promote":      constants.EventDeploy,
+	"pull_request": constants.EventPull,
+	"push":         constants.EventPush,
+	"rollback":     constants.EventDeploy,
+	"tag":          constants.EventTag,
+}
+
+// dronePipeline represents a document of a Drone configuration.
+type dronePipeline struct {
+	Kind      string          `yaml:"kind"`
+	Type      string          `yaml:"type"`
+	Name      string          `yaml:"name"`
+	Steps     []*droneStep    `yaml:"steps"`
+	Services  []*droneStep    `yaml:"services"`
+	Trigger   droneConditions `yaml:"trigger"`
+	DependsOn convertList     `yaml:"depends_on"`
+	Extra     map[string]any  `yaml:",inline"`
+}
+
+// droneStep represents a step or service of a Drone pipeline.
+type droneStep struct {
+	Name        string                 `yaml:"name"`
+	Image       string                 `yaml:"image"`
+	Pull        string                 `yaml:"pull"`
+	Commands    convertList            `yaml:"commands"`
+	Environment map[string]*droneValue `yaml:"environment"`
+	Settings    map[string]*droneValue `yaml:"settings"`
+	When        droneConditions        `yaml:"when"`
+	DependsOn   convertList            `yaml:"depends_on"`
+	Detach      bool                   `yaml:"detach"`
+	Failure     string                 `yaml:"failure"`
+	Extra       map[string]any         `yaml:",inline"`
+}
+
+// droneValue represents a value of a Drone step
+// that can reference a secret with from_secret.
+type droneValue struct {
+	Value      any
+	FromSecret string
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (v *droneValue) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.MappingNode {
+		secret := new(struct {
+			FromSecret string `yaml:"from_secret"`
+		})
+
+		err := node.Decode(secret)
+		if err != nil {
+			return err
+		}
+
+		if len(secret.FromSecret) > 0 {
+			v.FromSecret = secret.FromSecret
+
+			return nil
+		}
+	}
+
+	return node.Decode(&v.Value)
+}
+
+// droneConditions represents the conditions of a Drone step or pipeline.
+type droneConditions struct {
+	Branch   droneCondition `yaml:"branch"`
+	Event    droneCondition `yaml:"event"`
+	Paths    droneCondition `yaml:"paths"`
+	Ref      droneCondition `yaml:"ref"`
+	Status   droneCondition `yaml:"status"`
+	Target   droneCondition `yaml:"target"`
+	Instance droneCondition `yaml:"instance"`
+	Extra    map[string]any `yaml:",inline"`
+}
+
+// droneCondition represents a condition of Drone which
+// can be a value, a list of values or include and exclude.
+type droneCondition struct {
+	Include convertList `yaml:"include"`
+	Exclude convertList `yaml:"exclude"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *droneCondition) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.MappingNode {
+		type condition droneCondition
+
+		return node.Decode((*condition)(c))
+	}
+
+	return node.Decode(&c.Include)
+}
+
+// convertFromDrone converts the Drone configuration into a pipeline.
+func convertFromDrone(data []byte) (*convertBuild, error) {
+	build := &convertBuild{
+		TODO: []string{"review the pipeline converted from Drone"},
+	}
+
+	pipelines := []*dronePipeline{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		p := new(dronePipeline)
+
+		err := decoder.Decode(p)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch p.Kind {
+		case "pipeline":
+			pipelines = append(pipelines, p)
+		case "secret":
+			build.TODO = append(build.TODO, fmt.Sprintf("secret %s is read from an external secret store, add it to Vela", p.Name))
+		case "":
+			// skip empty documents
+		default:
+			build.TODO = append(build.TODO, fmt.Sprintf("%s %s can not be converted", p.Kind, p.Name))
+		}
+	}
+
+	if len(pipelines) == 0 {
+		return nil, errors.New("no pipelines found")
+	}
+
+	for _, p := range pipelines {
+		todo := []string{}
+
+		if len(p.Type) > 0 && p.Type != "docker" {
+			todo = append(todo, fmt.Sprintf("pipeline %s uses the %s runner, the steps are converted to run in containers", p.Name, p.Type))
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(p.Extra)) {
+			todo = append(todo, fmt.Sprintf("%s of pipeline %s can not be converted", key, p.Name))
+		}
+
+		when, unless := droneRules(p.Trigger, &todo)
+
+		steps := []*convertStep{}
+
+		for i, s := range p.Steps {
+			step := droneConvertStep(build, s)
+
+			// a dependency on the previous step matches the order of the steps
+			if len(s.DependsOn) > 0 && (i == 0 || len(s.DependsOn) > 1 || s.DependsOn[0] != p.Steps[i-1].Name) {
+				step.TODO = append(step.TODO, fmt.Sprintf("step depends on %s, the steps of a stage run in order", strings.Join(s.DependsOn, ", ")))
+			}
+
+			stepWhen, stepUnless := droneRules(s.When, &step.TODO)
+
+			step.addRules(stepWhen.mergeIf(when, &step.TODO), stepUnless.mergeUnless(unless, &step.TODO))
+
+			if s.Failure == "ignore" {
+				if step.Ruleset == nil {
+					step.Ruleset = new(convertRuleset)
+				}
+
+				step.Ruleset.Continue = true
+			}
+
+			steps = append(steps, step)
+		}
+
+		for _, s := range p.Services {
+			service := droneConvertStep(build, s)
+
+			if len(service.Commands) > 0 {
+				service.TODO = append(service.TODO, fmt.Sprintf("service runs the commands %s, services run the entrypoint of the image", strings.Join(service.Commands, "; ")))
+				service.Commands = nil
+			}
+
+			build.addService(service)
+		}
+
+		if len(pipelines) == 1 {
+			build.Steps = steps
+			build.TODO = append(build.TODO, todo...)
+
+			break
+		}
+
+		build.Stages = append(build.Stages, &convertStage{
+			Name:  convertStageName(p.Name),
+			Needs: droneNeeds(p.DependsOn),
+			Steps: steps,
+			TODO:  todo,
+		})
+	}
+
+	return build, nil
+}
+
+// droneNeeds returns the names of the stages for the pipelines.
+func droneNeeds(names []string) []string {
+	needs := []string{}
+
+	for _, name := range names {
+		needs = append(needs, convertStageName(name))
+	}
+
+	return needs
+}
+
+// droneConvertStep converts the Drone step into a step.
+func droneConvertStep(build *convertBuild, s *droneStep) *convertStep {
+	step := &convertStep{
+		Name:   s.Name,
+		Image:  s.Image,
+		Detach: s.Detach,
+	}
+
+	switch s.Pull {
+	case "always":
+		step.Pull = constants.PullAlways
+	case "if-not-exists":
+		step.Pull = constants.PullNotPresent
+	case "never":
+		step.Pull = constants.PullNever
+	}
+
+	for _, command := range s.Commands {
+		step.Commands = append(step.Commands, droneReplace(command, &step.TODO))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(s.Environment)) {
+		value := s.Environment[key]
+
+		if len(value.FromSecret) > 0 {
+			build.addSecret(value.FromSecret)
+			step.addSecret(value.FromSecret, key)
+
+			continue
+		}
+
+		if step.Environment == nil {
+			step.Environment = map[string]string{}
+		}
+
+		step.Environment[key] = droneReplace(fmt.Sprint(value.Value), &step.TODO)
+	}
+
+	if len(s.Settings) > 0 {
+		step.TODO = append(step.TODO, fmt.Sprintf("step uses the Drone plugin %s, replace it with a Vela plugin", s.Image))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(s.Settings)) {
+		value := s.Settings[key]
+
+		// plugins read the parameters from PARAMETER_ environment variables
+		if len(value.FromSecret) > 0 {
+			build.addSecret(value.FromSecret)
+			step.addSecret(value.FromSecret, "parameter_"+key)
+
+			continue
+		}
+
+		if step.Parameters == nil {
+			step.Parameters = map[string]any{}
+		}
+
+		step.Parameters[key] = value.Value
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(s.Extra)) {
+		step.TODO = append(step.TODO, fmt.Sprintf("%s can not be converted", key))
+	}
+
+	if len(step.Image) == 0 {
+		step.Image = convertImage
+		step.TODO = append(step.TODO, fmt.Sprintf("step has no image, %s is used", convertImage))
+	}
+
+	return step
+}
+
+// droneRules converts the Drone conditions into the rules
+// to run the step and the rules to skip the step.
+func droneRules(c droneConditions, todo *[]string) (convertRules, convertRules) {
+	when := convertRules{
+		Branch: c.Branch.Include,
+		Path:   c.Paths.Include,
+		Status: c.Status.Include,
+		Target: c.Target.Include,
+	}
+
+	unless := convertRules{
+		Branch: c.Branch.Exclude,
+		Path:   c.Paths.Exclude,
+		Status: c.Status.Exclude,
+		Target: c.Target.Exclude,
+	}
+
+	events := func(values []string) []string {
+		result := []string{}
+
+		for _, value := range values {
+			event, ok := droneEvents[value]
+			if !ok || len(event) == 0 {
+				*todo = append(*todo, fmt.Sprintf("event %s can not be converted", value))
+
+				continue
+			}
+
+			result = convertAppend(result, event)
+		}
+
+		return result
+	}
+
+	when.Event = events(c.Event.Include)
+	unless.Event = events(c.Event.Exclude)
+
+	for _, ref := range c.Ref.Include {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			when.Branch = convertAppend(when.Branch, strings.TrimPrefix(ref, "refs/heads/"))
+		case strings.HasPrefix(ref, "refs/tags/"):
+			when.Tag = convertAppend(when.Tag, strings.TrimPrefix(ref, "refs/tags/"))
+			when.Event = convertAppend(when.Event, constants.EventTag)
+		default:
+			*todo = append(*todo, fmt.Sprintf("ref %s can not be converted", ref))
+		}
+	}
+
+	if len(c.Ref.Exclude) > 0 {
+		*todo = append(*todo, fmt.Sprintf("excluded refs %s can not be converted", strings.Join(c.Ref.Exclude, ", ")))
+	}
+
+	if len(c.Instance.Include) > 0 || len(c.Instance.Exclude) > 0 {
+		*todo = append(*todo, "instance conditions can not be converted")
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(c.Extra)) {
+		*todo = append(*todo, fmt.Sprintf("%s conditions can not be converted", key))
+	}
+
+	return when, unless
+}
+
+// droneReplace replaces the Drone environment variables in
+// the value with the environment variables of Vela.
+func droneReplace(value string, todo *[]string) string {
+	return convertReplace(value, func(name string) (string, bool) {
+		vela, ok := droneVariables[name]
+		if ok {
+			return vela, true
+		}
+
+		if strings.HasPrefix(name, "DRONE_") || strings.HasPrefix(name, "CI_") {
+			*todo = append(*todo, fmt.Sprintf("variable %s can not be converted", name))
+		}
+
+		return name, false
+	})
+}
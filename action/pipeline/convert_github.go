@@ -0,0 +1,779 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/server/constants"
+)
+
+// githubImage is the image used for jobs that run on a
+// runner of GitHub Actions instead of a container.
+const githubImage = "ubuntu:latest"
+
+// githubExpression is the pattern for an expression of GitHub Actions.
+var githubExpression = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+
+// githubContexts maps the contexts of GitHub Actions
+// to the environment variables of Vela.
+var githubContexts = map[string]string{
+	"github.actor":            "VELA_BUILD_SENDER",
+	"github.base_ref":         "VELA_PULL_REQUEST_TARGET",
+	"github.event_name":       "VELA_BUILD_EVENT",
+	"github.head_ref":         "VELA_PULL_REQUEST_SOURCE",
+	"github.ref":              "VELA_BUILD_REF",
+	"github.ref_name":         "VELA_BUILD_BRANCH",
+	"github.repository":       "VELA_REPO_FULL_NAME",
+	"github.repository_owner": "VELA_REPO_ORG",
+	"github.run_number":       "VELA_BUILD_NUMBER",
+	"github.sha":              "VELA_BUILD_COMMIT",
+}
+
+// githubEvents maps the events of GitHub Actions to the events of Vela.
+var githubEvents = map[string]string{
+	"deployment":          constants.EventDeploy,
+	"issue_comment":       constants.EventComment,
+	"pull_request":        constants.EventPull,
+	"pull_request_target": constants.EventPull,
+	"push":                constants.EventPush,
+	"schedule":            constants.EventSchedule,
+}
+
+// githubConditions maps the patterns of the conditions of GitHub
+// Actions to the functions that add the rules for the condition.
+var githubConditions = []struct {
+	pattern *regexp.Regexp
+	rules   func(match []string, when, unless *convertRules) bool
+}{
+	{
+		pattern: regexp.MustCompile(`^github\.ref\s*(==|!=)\s*['"]refs/heads/(.+)['"]$`),
+		rules: func(match []string, when, unless *convertRules) bool {
+			rules := convertNegate(match[1], when, unless)
+			rules.Branch = convertAppend(rules.Branch, match[2])
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^github\.(?:ref_name|base_ref)\s*(==|!=)\s*['"](.+)['"]$`),
+		rules: func(match []string, when, unless *convertRules) bool {
+			rules := convertNegate(match[1], when, unless)
+			rules.Branch = convertAppend(rules.Branch, match[2])
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^github\.event_name\s*(==|!=)\s*['"](.+)['"]$`),
+		rules: func(match []string, when, unless *convertRules) bool {
+			event, ok := githubEvents[match[2]]
+			if !ok {
+				return false
+			}
+
+			rules := convertNegate(match[1], when, unless)
+			rules.Event = convertAppend(rules.Event, event)
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^startsWith\(\s*github\.ref\s*,\s*['"]refs/tags/(.*)['"]\s*\)$`),
+		rules: func(match []string, when, _ *convertRules) bool {
+			when.Event = convertAppend(when.Event, constants.EventTag)
+
+			if len(match[1]) > 0 {
+				when.Tag = convertAppend(when.Tag, match[1]+"*")
+			}
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^(success|failure|always|!\s*cancelled)\(\)$`),
+		rules: func(match []string, when, _ *convertRules) bool {
+			switch match[1] {
+			case "success":
+				when.Status = convertAppend(when.Status, constants.StatusSuccess)
+			case "failure":
+				when.Status = convertAppend(when.Status, constants.StatusFailure)
+			default:
+				when.Status = convertAppend(when.Status, constants.StatusSuccess, constants.StatusFailure)
+			}
+
+			return true
+		},
+	},
+}
+
+// githubWorkflow represents a workflow of GitHub Actions.
+type githubWorkflow struct {
+	Name  string            `yaml:"name"`
+	On    yaml.Node         `yaml:"on"`
+	Env   map[string]string `yaml:"env"`
+	Jobs  yaml.Node         `yaml:"jobs"`
+	Extra map[string]any    `yaml:",inline"`
+}
+
+// githubJob represents a job of a GitHub Actions workflow.
+type githubJob struct {
+	Name            string                      `yaml:"name"`
+	RunsOn          any                         `yaml:"runs-on"`
+	Container       *githubContainer            `yaml:"container"`
+	Services        map[string]*githubContainer `yaml:"services"`
+	Needs           convertList                 `yaml:"needs"`
+	If              string                      `yaml:"if"`
+	Env             map[string]string           `yaml:"env"`
+	Strategy        githubStrategy              `yaml:"strategy"`
+	Steps           []*githubStep               `yaml:"steps"`
+	ContinueOnError string                      `yaml:"continue-on-error"`
+	Extra           map[string]any              `yaml:",inline"`
+}
+
+// githubStrategy represents the strategy of a GitHub Actions job.
+type githubStrategy struct {
+	Matrix yaml.Node      `yaml:"matrix"`
+	Extra  map[string]any `yaml:",inline"`
+}
+
+// githubContainer represents a container or service of a GitHub Actions job.
+type githubContainer struct {
+	Image string            `yaml:"image"`
+	Env   map[string]string `yaml:"env"`
+	Ports convertList       `yaml:"ports"`
+	Extra map[string]any    `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *githubContainer) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.ScalarNode {
+		c.Image = node.Value
+
+		return nil
+	}
+
+	type container githubContainer
+
+	return node.Decode((*container)(c))
+}
+
+// githubStep represents a step of a GitHub Actions job.
+type githubStep struct {
+	ID               string            `yaml:"id"`
+	Name             string            `yaml:"name"`
+	If               string            `yaml:"if"`
+	Uses             string            `yaml:"uses"`
+	Run              string            `yaml:"run"`
+	Shell            string            `yaml:"shell"`
+	WorkingDirectory string            `yaml:"working-directory"`
+	Env              map[string]string `yaml:"env"`
+	With             map[string]any    `yaml:"with"`
+	ContinueOnError  string            `yaml:"continue-on-error"`
+	Extra            map[string]any    `yaml:",inline"`
+}
+
+// githubStage represents a stage for a job, or a combination
+// of the matrix of a job, of a GitHub Actions workflow.
+type githubStage struct {
+	id     string
+	job    *githubJob
+	matrix map[string]string
+	todo   []string
+	stage  *convertStage
+}
+
+// githubScope represents the values used to convert
+// the expressions of a step of a GitHub Actions job.
+type githubScope struct {
+	build  *convertBuild
+	step   *convertStep
+	matrix map[string]string
+	todo   *[]string
+}
+
+// convertFromGitHubActions converts the GitHub Actions workflow into a pipeline.
+func convertFromGitHubActions(data []byte) (*convertBuild, error) {
+	workflow := new(githubWorkflow)
+
+	err := yaml.Unmarshal(data, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := yamlResolve(&workflow.Jobs)
+	if jobs.Kind != yaml.MappingNode || len(jobs.Content) == 0 {
+		return nil, errors.New("no jobs found")
+	}
+
+	build := &convertBuild{
+		TODO: []string{fmt.Sprintf("review the pipeline converted from the GitHub Actions workflow %s", workflow.Name)},
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(workflow.Extra)) {
+		build.TODO = append(build.TODO, fmt.Sprintf("%s of the workflow can not be converted", key))
+	}
+
+	when, unless := githubTriggers(&workflow.On, &build.TODO)
+
+	scope := &githubScope{build: build, step: new(convertStep), todo: &build.TODO}
+
+	for _, key := range slices.Sorted(maps.Keys(workflow.Env)) {
+		if build.Environment == nil {
+			build.Environment = map[string]string{}
+		}
+
+		build.Environment[key] = scope.replace(workflow.Env[key], false)
+	}
+
+	stages := []*githubStage{}
+	names := map[string][]string{}
+
+	// capture the stages of the jobs before converting
+	// them as jobs can need the jobs declared after them
+	for i := 0; i < len(jobs.Content)-1; i += 2 {
+		id := jobs.Content[i].Value
+
+		job := new(githubJob)
+
+		err = yamlResolve(jobs.Content[i+1]).Decode(job)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert job %s: %w", id, err)
+		}
+
+		todo := []string{}
+
+		keys, combinations := githubMatrix(&job.Strategy.Matrix, &todo)
+
+		for _, key := range slices.Sorted(maps.Keys(job.Strategy.Extra)) {
+			todo = append(todo, fmt.Sprintf("%s of the strategy can not be converted", key))
+		}
+
+		for _, combination := range combinations {
+			values := []string{id}
+
+			for _, key := range keys {
+				values = append(values, combination[key])
+			}
+
+			name := convertStageName(values...)
+
+			names[id] = append(names[id], name)
+
+			stages = append(stages, &githubStage{
+				id:     id,
+				job:    job,
+				matrix: combination,
+				todo:   slices.Clone(todo),
+				stage:  &convertStage{Name: name},
+			})
+		}
+	}
+
+	for _, s := range stages {
+		s.convert(build, when, unless)
+
+		for _, need := range s.job.Needs {
+			stage, ok := names[need]
+			if !ok {
+				s.stage.TODO = append(s.stage.TODO, fmt.Sprintf("job needs the job %s which does not exist", need))
+
+				continue
+			}
+
+			s.stage.Needs = append(s.stage.Needs, stage...)
+		}
+
+		build.Stages = append(build.Stages, s.stage)
+	}
+
+	if len(build.Stages) == 1 {
+		build.Steps = build.Stages[0].Steps
+		build.TODO = append(build.TODO, build.Stages[0].TODO...)
+		build.Stages = nil
+	}
+
+	return build, nil
+}
+
+// convert converts the job of the GitHub Actions workflow into the stage.
+func (s *githubStage) convert(build *convertBuild, when, unless convertRules) {
+	job := s.job
+	stage := s.stage
+	stage.TODO = s.todo
+
+	scope := &githubScope{build: build, step: new(convertStep), matrix: s.matrix, todo: &stage.TODO}
+
+	for _, key := range slices.Sorted(maps.Keys(job.Extra)) {
+		stage.TODO = append(stage.TODO, fmt.Sprintf("%s of the job can not be converted", key))
+	}
+
+	// capture the image for the steps of the job
+	image := githubImage
+	env := map[string]string{}
+
+	if job.Container != nil && len(job.Container.Image) > 0 {
+		image = scope.replace(job.Container.Image, false)
+
+		maps.Copy(env, job.Container.Env)
+
+		for _, key := range slices.Sorted(maps.Keys(job.Container.Extra)) {
+			stage.TODO = append(stage.TODO, fmt.Sprintf("%s of the container can not be converted", key))
+		}
+	} else {
+		runsOn := scope.replace(fmt.Sprint(job.RunsOn), false)
+
+		stage.TODO = append(stage.TODO, fmt.Sprintf("job runs on %s, use an image with the tools the steps need instead of %s", runsOn, image))
+	}
+
+	maps.Copy(env, job.Env)
+
+	for _, name := range slices.Sorted(maps.Keys(job.Services)) {
+		service := job.Services[name]
+
+		converted := &convertStep{
+			Name:  name,
+			Image: scope.replace(service.Image, false),
+			Ports: service.Ports,
+		}
+
+		serviceScope := &githubScope{build: build, step: converted, matrix: s.matrix, todo: &converted.TODO}
+
+		for _, key := range slices.Sorted(maps.Keys(service.Env)) {
+			serviceScope.env(key, service.Env[key])
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(service.Extra)) {
+			converted.TODO = append(converted.TODO, fmt.Sprintf("%s of the service can not be converted", key))
+		}
+
+		build.addService(converted)
+	}
+
+	// capture the rules of the job for the steps of the job
+	jobWhen, jobUnless := githubCondition(job.If, &stage.TODO)
+	jobWhen = jobWhen.mergeIf(when, &stage.TODO)
+	jobUnless = jobUnless.mergeUnless(unless, &stage.TODO)
+
+	names := map[string]int{}
+	pending := []string{}
+
+	for i, item := range job.Steps {
+		name := item.Name
+		if len(name) == 0 {
+			name = item.ID
+		}
+
+		if len(name) == 0 {
+			name = fmt.Sprintf("step-%d", i+1)
+		}
+
+		name = scope.replace(name, false)
+
+		// step names must be unique within a stage
+		names[name]++
+		if names[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, names[name])
+		}
+
+		if len(item.Uses) > 0 {
+			// the repository is cloned by Vela before the steps
+			if strings.HasPrefix(item.Uses, "actions/checkout@") {
+				continue
+			}
+
+			pending = append(pending, fmt.Sprintf("replace the action %s with commands or a Vela plugin", item.Uses))
+
+			continue
+		}
+
+		step := &convertStep{
+			Name:  name,
+			Image: image,
+			TODO:  pending,
+		}
+
+		pending = []string{}
+
+		stepScope := &githubScope{build: build, step: step, matrix: scope.matrix, todo: &step.TODO}
+
+		stepEnv := maps.Clone(env)
+		maps.Copy(stepEnv, item.Env)
+
+		for _, key := range slices.Sorted(maps.Keys(stepEnv)) {
+			stepScope.env(key, stepEnv[key])
+		}
+
+		if len(item.WorkingDirectory) > 0 {
+			step.Commands = append(step.Commands, "cd "+stepScope.replace(item.WorkingDirectory, true))
+		}
+
+		for _, command := range convertCommands(item.Run) {
+			step.Commands = append(step.Commands, stepScope.replace(command, true))
+		}
+
+		if len(item.Shell) > 0 && item.Shell != "bash" && item.Shell != "sh" {
+			step.TODO = append(step.TODO, fmt.Sprintf("step runs the commands with %s, the commands run with the shell of the image", item.Shell))
+		}
+
+		stepWhen, stepUnless := githubCondition(item.If, &step.TODO)
+
+		step.addRules(stepWhen.mergeIf(jobWhen, &step.TODO), stepUnless.mergeUnless(jobUnless, &step.TODO))
+
+		if stepScope.replace(item.ContinueOnError, false) == "true" || scope.replace(job.ContinueOnError, false) == "true" {
+			if step.Ruleset == nil {
+				step.Ruleset = new(convertRuleset)
+			}
+
+			step.Ruleset.Continue = true
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(item.Extra)) {
+			step.TODO = append(step.TODO, fmt.Sprintf("%s of the step can not be converted", key))
+		}
+
+		stage.Steps = append(stage.Steps, step)
+	}
+
+	// a stage requires a step for the actions that can not be converted
+	if len(stage.Steps) == 0 {
+		stage.Steps = append(stage.Steps, &convertStep{
+			Name:     s.id,
+			Image:    image,
+			Commands: []string{"echo no steps were converted"},
+			TODO:     pending,
+		})
+
+		return
+	}
+
+	stage.TODO = append(stage.TODO, pending...)
+}
+
+// env adds the environment variable to the step, or the secret
+// when the value of the environment variable is a secret.
+func (s *githubScope) env(key, value string) {
+	match := githubExpression.FindStringSubmatch(value)
+
+	if match != nil && match[0] == value && strings.HasPrefix(match[1], "secrets.") {
+		name := strings.ToLower(strings.TrimPrefix(match[1], "secrets."))
+
+		s.build.addSecret(name)
+		s.step.addSecret(name, key)
+
+		return
+	}
+
+	if s.step.Environment == nil {
+		s.step.Environment = map[string]string{}
+	}
+
+	s.step.Environment[key] = s.replace(value, false)
+}
+
+// replace replaces the expressions in the value with the environment
+// variables of Vela, the value is escaped for the shell for commands.
+func (s *githubScope) replace(value string, commands bool) string {
+	var sb strings.Builder
+
+	last := 0
+
+	for _, match := range githubExpression.FindAllStringSubmatchIndex(value, -1) {
+		sb.WriteString(s.literal(value[last:match[0]], commands))
+		sb.WriteString(s.expression(value[match[2]:match[3]], value[match[0]:match[1]], commands))
+
+		last = match[1]
+	}
+
+	sb.WriteString(s.literal(value[last:], commands))
+
+	return sb.String()
+}
+
+// literal replaces the default environment variables of GitHub Actions in
+// the value, outside of expressions, with the environment variables of Vela.
+func (s *githubScope) literal(value string, commands bool) string {
+	if !commands {
+		return value
+	}
+
+	value = convertReplace(value, func(name string) (string, bool) {
+		vela, ok := githubContexts["github."+strings.ToLower(strings.TrimPrefix(name, "GITHUB_"))]
+		if ok && strings.HasPrefix(name, "GITHUB_") {
+			return vela, true
+		}
+
+		if strings.HasPrefix(name, "GITHUB_") || strings.HasPrefix(name, "RUNNER_") {
+			*s.todo = append(*s.todo, fmt.Sprintf("variable %s can not be converted", name))
+		}
+
+		return name, false
+	})
+
+	return convertEscape(value)
+}
+
+// expression converts the expression into a reference to an environment
+// variable of Vela, which is escaped for the shell for commands.
+func (s *githubScope) expression(expression, raw string, commands bool) string {
+	variable := func(name string) string {
+		if commands {
+			return fmt.Sprintf("$${%s}", name)
+		}
+
+		return fmt.Sprintf("${%s}", name)
+	}
+
+	switch {
+	case strings.HasPrefix(expression, "matrix."):
+		value, ok := s.matrix[strings.TrimPrefix(expression, "matrix.")]
+		if ok {
+			return value
+		}
+	case strings.HasPrefix(expression, "env."):
+		return variable(strings.TrimPrefix(expression, "env."))
+	case strings.HasPrefix(expression, "secrets.") && commands:
+		name := strings.TrimPrefix(expression, "secrets.")
+
+		s.build.addSecret(strings.ToLower(name))
+		s.step.addSecret(strings.ToLower(name), name)
+
+		return variable(strings.ToUpper(name))
+	default:
+		name, ok := githubContexts[expression]
+		if ok {
+			return variable(name)
+		}
+	}
+
+	*s.todo = append(*s.todo, fmt.Sprintf("expression %s can not be converted", raw))
+
+	return raw
+}
+
+// githubTriggers converts the events that trigger the GitHub Actions workflow
+// into the rules to run the steps and the rules to skip the steps.
+func githubTriggers(node *yaml.Node, todo *[]string) (convertRules, convertRules) {
+	when := convertRules{}
+	unless := convertRules{}
+
+	node = yamlResolve(node)
+
+	events := []string{}
+	filters := map[string]*yaml.Node{}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		events = append(events, node.Value)
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			events = append(events, yamlResolve(item).Value)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			event := node.Content[i].Value
+
+			events = append(events, event)
+			filters[event] = yamlResolve(node.Content[i+1])
+		}
+	}
+
+	branches := map[string][]string{}
+
+	for _, event := range events {
+		filter := new(struct {
+			Branches       convertList `yaml:"branches"`
+			BranchesIgnore convertList `yaml:"branches-ignore"`
+			Tags           convertList `yaml:"tags"`
+			TagsIgnore     convertList `yaml:"tags-ignore"`
+			Paths          convertList `yaml:"paths"`
+			PathsIgnore    convertList `yaml:"paths-ignore"`
+			Types          convertList `yaml:"types"`
+		})
+
+		if node, ok := filters[event]; ok && node.Kind == yaml.MappingNode {
+			err := node.Decode(filter)
+			if err != nil {
+				*todo = append(*todo, fmt.Sprintf("filters of the %s event can not be converted", event))
+			}
+		}
+
+		switch event {
+		case "push":
+			if len(filter.Branches) > 0 || len(filter.Tags) == 0 {
+				when.Event = convertAppend(when.Event, constants.EventPush)
+			}
+
+			if len(filter.Tags) > 0 {
+				when.Event = convertAppend(when.Event, constants.EventTag)
+				when.Tag = convertAppend(when.Tag, filter.Tags...)
+			}
+
+			unless.Tag = convertAppend(unless.Tag, filter.TagsIgnore...)
+		case "pull_request", "pull_request_target":
+			if len(filter.Types) == 0 {
+				when.Event = convertAppend(when.Event, constants.EventPull)
+			}
+
+			for _, action := range filter.Types {
+				switch action {
+				case constants.ActionOpened, constants.ActionSynchronize, constants.ActionReopened,
+					constants.ActionEdited, constants.ActionLabeled, constants.ActionUnlabeled:
+					when.Event = convertAppend(when.Event, fmt.Sprintf("%s:%s", constants.EventPull, action))
+				default:
+					*todo = append(*todo, fmt.Sprintf("pull_request type %s can not be converted", action))
+				}
+			}
+		case "schedule":
+			when.Event = convertAppend(when.Event, constants.EventSchedule)
+
+			if node, ok := filters[event]; ok && node.Kind == yaml.SequenceNode {
+				for _, item := range node.Content {
+					cron := new(struct {
+						Cron string `yaml:"cron"`
+					})
+
+					if yamlResolve(item).Decode(cron) == nil {
+						*todo = append(*todo, fmt.Sprintf("add the schedule %q to the repository in Vela", cron.Cron))
+					}
+				}
+			}
+		default:
+			converted, ok := githubEvents[event]
+			if !ok {
+				*todo = append(*todo, fmt.Sprintf("event %s can not be converted", event))
+
+				continue
+			}
+
+			when.Event = convertAppend(when.Event, converted)
+		}
+
+		branches[event] = filter.Branches
+
+		when.Path = convertAppend(when.Path, filter.Paths...)
+		unless.Branch = convertAppend(unless.Branch, filter.BranchesIgnore...)
+		unless.Path = convertAppend(unless.Path, filter.PathsIgnore...)
+	}
+
+	// the branch rule applies to every event of the steps
+	for _, event := range events {
+		if !slices.Equal(branches[event], branches[events[0]]) {
+			*todo = append(*todo, "the events filter different branches, the branches of every event are used")
+
+			break
+		}
+	}
+
+	for _, event := range events {
+		when.Branch = convertAppend(when.Branch, branches[event]...)
+	}
+
+	return when, unless
+}
+
+// githubCondition converts the condition of the GitHub Actions job or step
+// into the rules to run the step and the rules to skip the step.
+func githubCondition(condition string, todo *[]string) (convertRules, convertRules) {
+	when := convertRules{}
+	unless := convertRules{}
+
+	condition = strings.TrimSpace(condition)
+	if len(condition) == 0 {
+		return when, unless
+	}
+
+	expression := condition
+
+	match := githubExpression.FindStringSubmatch(condition)
+	if match != nil && match[0] == condition {
+		expression = match[1]
+	}
+
+	if strings.Contains(expression, "||") {
+		*todo = append(*todo, fmt.Sprintf("condition %s can not be converted", condition))
+
+		return when, unless
+	}
+
+	for term := range strings.SplitSeq(expression, "&&") {
+		term = strings.TrimSpace(term)
+
+		converted := false
+
+		for _, c := range githubConditions {
+			match := c.pattern.FindStringSubmatch(term)
+			if match != nil && c.rules(match, &when, &unless) {
+				converted = true
+
+				break
+			}
+		}
+
+		if !converted {
+			*todo = append(*todo, fmt.Sprintf("condition %s can not be converted", term))
+		}
+	}
+
+	return when, unless
+}
+
+// githubMatrix returns the keys and the combinations of the values
+// of the matrix of the strategy for the GitHub Actions job.
+func githubMatrix(node *yaml.Node, todo *[]string) ([]string, []map[string]string) {
+	node = yamlResolve(node)
+
+	keys := []string{}
+	values := map[string][]string{}
+
+	switch node.Kind {
+	case 0:
+		// the job does not have a matrix
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			key := node.Content[i].Value
+			value := yamlResolve(node.Content[i+1])
+
+			if value.Kind != yaml.SequenceNode {
+				*todo = append(*todo, fmt.Sprintf("%s of the matrix can not be converted", key))
+
+				continue
+			}
+
+			list := []string{}
+
+			for _, item := range value.Content {
+				item = yamlResolve(item)
+				if item.Kind != yaml.ScalarNode {
+					list = nil
+
+					break
+				}
+
+				list = append(list, item.Value)
+			}
+
+			if len(list) == 0 {
+				*todo = append(*todo, fmt.Sprintf("%s of the matrix can not be converted", key))
+
+				continue
+			}
+
+			keys = append(keys, key)
+			values[key] = list
+		}
+	default:
+		*todo = append(*todo, "matrix can not be converted")
+	}
+
+	return keys, convertMatrix(keys, values)
+}
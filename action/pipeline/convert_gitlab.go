@@ -0,0 +1,856 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/server/constants"
+)
+
+// gitlabDepth is the maximum depth of the jobs that
+// a job of GitLab CI can extend, matching GitLab.
+const gitlabDepth = 11
+
+// gitlabStages are the stages of GitLab CI when the stages are not declared.
+var gitlabStages = []string{"build", "test", "deploy"}
+
+// gitlabVariables maps the predefined variables of
+// GitLab CI to the environment variables of Vela.
+var gitlabVariables = map[string]string{
+	"CI_COMMIT_AUTHOR":                    "VELA_BUILD_AUTHOR",
+	"CI_COMMIT_BRANCH":                    "VELA_BUILD_BRANCH",
+	"CI_COMMIT_MESSAGE":                   "VELA_BUILD_MESSAGE",
+	"CI_COMMIT_REF_NAME":                  "VELA_BUILD_BRANCH",
+	"CI_COMMIT_SHA":                       "VELA_BUILD_COMMIT",
+	"CI_COMMIT_TAG":                       "VELA_BUILD_TAG",
+	"CI_DEFAULT_BRANCH":                   "VELA_REPO_BRANCH",
+	"CI_MERGE_REQUEST_IID":                "VELA_PULL_REQUEST",
+	"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "VELA_PULL_REQUEST_SOURCE",
+	"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "VELA_PULL_REQUEST_TARGET",
+	"CI_PIPELINE_IID":                     "VELA_BUILD_NUMBER",
+	"CI_PIPELINE_SOURCE":                  "VELA_BUILD_EVENT",
+	"CI_PROJECT_NAME":                     "VELA_REPO_NAME",
+	"CI_PROJECT_NAMESPACE":                "VELA_REPO_ORG",
+	"CI_PROJECT_PATH":                     "VELA_REPO_FULL_NAME",
+	"GITLAB_USER_LOGIN":                   "VELA_BUILD_SENDER",
+}
+
+// gitlabSources maps the sources of the pipelines of GitLab CI to the events of Vela.
+var gitlabSources = map[string]string{
+	"merge_request_event": constants.EventPull,
+	"merge_requests":      constants.EventPull,
+	"push":                constants.EventPush,
+	"pushes":              constants.EventPush,
+	"schedule":            constants.EventSchedule,
+	"schedules":           constants.EventSchedule,
+	"branches":            constants.EventPush,
+	"tags":                constants.EventTag,
+}
+
+// gitlabShell are the environment variables of the
+// shell that are not converted into secrets.
+var gitlabShell = []string{"HOME", "HOSTNAME", "PATH", "PWD", "SHELL", "USER"}
+
+// gitlabSecret is the pattern for the name of a variable that can be a secret.
+var gitlabSecret = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// gitlabConditions maps the patterns of the conditions of GitLab
+// CI to the functions that add the rules for the condition.
+var gitlabConditions = []struct {
+	pattern *regexp.Regexp
+	rules   func(match []string, when, unless *convertRules) bool
+}{
+	{
+		pattern: regexp.MustCompile(`^\$(?:CI_COMMIT_BRANCH|CI_COMMIT_REF_NAME|CI_MERGE_REQUEST_TARGET_BRANCH_NAME)\s*(==|!=)\s*["'](.+)["']$`),
+		rules: func(match []string, when, unless *convertRules) bool {
+			rules := convertNegate(match[1], when, unless)
+			rules.Branch = convertAppend(rules.Branch, match[2])
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^\$CI_PIPELINE_SOURCE\s*(==|!=)\s*["'](.+)["']$`),
+		rules: func(match []string, when, unless *convertRules) bool {
+			event, ok := gitlabSources[match[2]]
+			if !ok {
+				return false
+			}
+
+			rules := convertNegate(match[1], when, unless)
+			rules.Event = convertAppend(rules.Event, event)
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^\$CI_COMMIT_TAG\s*(==|!=)\s*["'](.+)["']$`),
+		rules: func(match []string, when, unless *convertRules) bool {
+			rules := convertNegate(match[1], when, unless)
+			rules.Tag = convertAppend(rules.Tag, match[2])
+
+			when.Event = convertAppend(when.Event, constants.EventTag)
+
+			return true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^\$(CI_COMMIT_TAG|CI_COMMIT_BRANCH|CI_MERGE_REQUEST_IID)$`),
+		rules: func(match []string, when, _ *convertRules) bool {
+			switch match[1] {
+			case "CI_COMMIT_TAG":
+				when.Event = convertAppend(when.Event, constants.EventTag)
+			case "CI_COMMIT_BRANCH":
+				when.Event = convertAppend(when.Event, constants.EventPush)
+			default:
+				when.Event = convertAppend(when.Event, constants.EventPull)
+			}
+
+			return true
+		},
+	},
+}
+
+// gitlabJob represents a job, or the default values of the jobs, of GitLab CI.
+type gitlabJob struct {
+	Stage        string                     `yaml:"stage"`
+	Image        *gitlabImage               `yaml:"image"`
+	Services     []*gitlabImage             `yaml:"services"`
+	Variables    map[string]*gitlabVariable `yaml:"variables"`
+	BeforeScript *convertList               `yaml:"before_script"`
+	Script       convertList                `yaml:"script"`
+	AfterScript  *convertList               `yaml:"after_script"`
+	Rules        []*gitlabRule              `yaml:"rules"`
+	Only         *gitlabRefs                `yaml:"only"`
+	Except       *gitlabRefs                `yaml:"except"`
+	Needs        yaml.Node                  `yaml:"needs"`
+	Parallel     yaml.Node                  `yaml:"parallel"`
+	AllowFailure yaml.Node                  `yaml:"allow_failure"`
+	When         string                     `yaml:"when"`
+	Extends      convertList                `yaml:"extends"`
+	Extra        map[string]any             `yaml:",inline"`
+}
+
+// gitlabImage represents an image or service of GitLab CI.
+type gitlabImage struct {
+	Name  string         `yaml:"name"`
+	Alias string         `yaml:"alias"`
+	Extra map[string]any `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *gitlabImage) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.ScalarNode {
+		i.Name = node.Value
+
+		return nil
+	}
+
+	type image gitlabImage
+
+	return node.Decode((*image)(i))
+}
+
+// gitlabVariable represents a variable of GitLab CI.
+type gitlabVariable struct {
+	Value string `yaml:"value"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (v *gitlabVariable) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.ScalarNode {
+		v.Value = node.Value
+
+		return nil
+	}
+
+	type variable gitlabVariable
+
+	return node.Decode((*variable)(v))
+}
+
+// gitlabRule represents a rule of a GitLab CI job.
+type gitlabRule struct {
+	If           string         `yaml:"if"`
+	Changes      gitlabChanges  `yaml:"changes"`
+	When         string         `yaml:"when"`
+	AllowFailure bool           `yaml:"allow_failure"`
+	Extra        map[string]any `yaml:",inline"`
+}
+
+// gitlabChanges represents the paths of the changes of a GitLab CI rule.
+type gitlabChanges []string
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *gitlabChanges) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.MappingNode {
+		changes := new(struct {
+			Paths convertList `yaml:"paths"`
+		})
+
+		err := node.Decode(changes)
+		if err != nil {
+			return err
+		}
+
+		*c = gitlabChanges(changes.Paths)
+
+		return nil
+	}
+
+	return node.Decode((*convertList)(c))
+}
+
+// gitlabRefs represents the only and except keywords of a GitLab CI job.
+type gitlabRefs struct {
+	Refs    convertList    `yaml:"refs"`
+	Changes convertList    `yaml:"changes"`
+	Extra   map[string]any `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *gitlabRefs) UnmarshalYAML(node *yaml.Node) error {
+	node = yamlResolve(node)
+
+	if node.Kind == yaml.MappingNode {
+		type refs gitlabRefs
+
+		return node.Decode((*refs)(r))
+	}
+
+	return node.Decode(&r.Refs)
+}
+
+// convertFromGitLab converts the GitLab CI configuration into a pipeline.
+func convertFromGitLab(data []byte) (*convertBuild, error) {
+	document := new(yaml.Node)
+
+	err := yaml.Unmarshal(data, document)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("no jobs found")
+	}
+
+	root := document.Content[0]
+
+	build := &convertBuild{
+		TODO: []string{"review the pipeline converted from GitLab CI"},
+	}
+
+	global := new(gitlabJob)
+	stages := gitlabStages
+	variables := map[string]*gitlabVariable{}
+	jobs := []string{}
+	nodes := map[string]*yaml.Node{}
+
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		key := root.Content[i].Value
+		value := yamlResolve(root.Content[i+1])
+
+		switch key {
+		case "default":
+			err = value.Decode(global)
+		case "image", "services", "before_script", "after_script":
+			err = (&yaml.Node{Kind: yaml.MappingNode, Content: root.Content[i : i+2]}).Decode(global)
+		case "stages":
+			stages = []string{}
+			err = value.Decode(&stages)
+		case "variables":
+			err = value.Decode(&variables)
+		case "cache", "include", "workflow":
+			build.TODO = append(build.TODO, fmt.Sprintf("%s can not be converted", key))
+		default:
+			nodes[key] = value
+
+			// hidden jobs are only used to be extended by jobs
+			if !strings.HasPrefix(key, ".") {
+				jobs = append(jobs, key)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert %s: %w", key, err)
+		}
+	}
+
+	if len(jobs) == 0 {
+		return nil, errors.New("no jobs found")
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(global.Extra)) {
+		build.TODO = append(build.TODO, fmt.Sprintf("%s of the default can not be converted", key))
+	}
+
+	declared := map[string]bool{}
+
+	for _, key := range slices.Sorted(maps.Keys(variables)) {
+		if build.Environment == nil {
+			build.Environment = map[string]string{}
+		}
+
+		build.Environment[key] = gitlabReplace(variables[key].Value, false, &build.TODO)
+		declared[key] = true
+	}
+
+	// the .pre and .post stages always run first and last
+	stages = append(append([]string{".pre"}, stages...), ".post")
+
+	steps := map[string][]*convertStep{}
+
+	for _, name := range jobs {
+		node, err := gitlabExtend(name, nodes, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		job := new(gitlabJob)
+
+		err = node.Decode(job)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert job %s: %w", name, err)
+		}
+
+		stage := job.Stage
+		if len(stage) == 0 {
+			stage = "test"
+		}
+
+		if !slices.Contains(stages, stage) {
+			build.TODO = append(build.TODO, fmt.Sprintf("stage %s of job %s is not declared in the stages", stage, name))
+
+			stages = slices.Insert(stages, len(stages)-1, stage)
+		}
+
+		steps[stage] = append(steps[stage], gitlabConvertJob(build, name, job, global, declared)...)
+	}
+
+	previous := ""
+
+	for _, stage := range stages {
+		if len(steps[stage]) == 0 {
+			continue
+		}
+
+		converted := &convertStage{
+			Name:  convertStageName(strings.TrimPrefix(stage, ".")),
+			Steps: steps[stage],
+		}
+
+		// the stages of GitLab CI run in order
+		if len(previous) > 0 {
+			converted.Needs = []string{previous}
+		}
+
+		previous = converted.Name
+
+		build.Stages = append(build.Stages, converted)
+	}
+
+	if len(build.Stages) == 1 {
+		build.Steps = build.Stages[0].Steps
+		build.Stages = nil
+	}
+
+	if len(build.Secrets) > 0 {
+		build.TODO = append(build.TODO, "the variables that are not declared were converted to secrets, remove the ones that are not CI/CD variables")
+	}
+
+	return build, nil
+}
+
+// gitlabExtend returns the job merged into the jobs
+// it extends, in the order the jobs are extended.
+func gitlabExtend(name string, nodes map[string]*yaml.Node, depth int) (*yaml.Node, error) {
+	if depth > gitlabDepth {
+		return nil, fmt.Errorf("job %s extends more than %d jobs", name, gitlabDepth)
+	}
+
+	node, ok := nodes[name]
+	if !ok {
+		return nil, fmt.Errorf("job %s does not exist", name)
+	}
+
+	job := new(struct {
+		Extends convertList `yaml:"extends"`
+	})
+
+	if node.Kind == yaml.MappingNode {
+		err := node.Decode(job)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, parent := range job.Extends {
+		base, err := gitlabExtend(parent, nodes, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = gitlabMerge(merged, base)
+	}
+
+	return gitlabMerge(merged, node), nil
+}
+
+// gitlabMerge returns the mapping with the values of the
+// overlay, where mappings in both are merged like GitLab.
+func gitlabMerge(base, overlay *yaml.Node) *yaml.Node {
+	if len(base.Content) == 0 {
+		return overlay
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Content: slices.Clone(base.Content)}
+
+	for i := 0; i < len(overlay.Content)-1; i += 2 {
+		key := overlay.Content[i]
+		value := yamlResolve(overlay.Content[i+1])
+
+		// skip the keyword of the job being merged
+		if key.Value == "extends" {
+			continue
+		}
+
+		replaced := false
+
+		for j := 0; j < len(merged.Content)-1; j += 2 {
+			if merged.Content[j].Value != key.Value {
+				continue
+			}
+
+			existing := yamlResolve(merged.Content[j+1])
+			if existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+				value = gitlabMerge(existing, value)
+			}
+
+			merged.Content[j+1] = value
+			replaced = true
+
+			break
+		}
+
+		if !replaced {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+
+	return merged
+}
+
+// gitlabConvertJob converts the GitLab CI job into the steps
+// for the job, with a step for each combination of the matrix.
+func gitlabConvertJob(build *convertBuild, name string, job, global *gitlabJob, declared map[string]bool) []*convertStep {
+	step := &convertStep{Name: name}
+
+	image := job.Image
+	if image == nil {
+		image = global.Image
+	}
+
+	if image == nil || len(image.Name) == 0 {
+		step.Image = convertImage
+		step.TODO = append(step.TODO, fmt.Sprintf("job has no image, %s is used", convertImage))
+	} else {
+		step.Image = gitlabReplace(image.Name, false, &step.TODO)
+
+		for _, key := range slices.Sorted(maps.Keys(image.Extra)) {
+			step.TODO = append(step.TODO, fmt.Sprintf("%s of the image can not be converted", key))
+		}
+	}
+
+	services := job.Services
+	if services == nil {
+		services = global.Services
+	}
+
+	for _, service := range services {
+		serviceName := service.Alias
+		if len(serviceName) == 0 {
+			serviceName = convertImageName(service.Name)
+		}
+
+		converted := &convertStep{
+			Name:  serviceName,
+			Image: service.Name,
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(service.Extra)) {
+			converted.TODO = append(converted.TODO, fmt.Sprintf("%s of the service can not be converted", key))
+		}
+
+		build.addService(converted)
+	}
+
+	jobDeclared := maps.Clone(declared)
+
+	for _, key := range slices.Sorted(maps.Keys(job.Variables)) {
+		if step.Environment == nil {
+			step.Environment = map[string]string{}
+		}
+
+		step.Environment[key] = gitlabReplace(job.Variables[key].Value, false, &step.TODO)
+		jobDeclared[key] = true
+	}
+
+	before := job.BeforeScript
+	if before == nil {
+		before = global.BeforeScript
+	}
+
+	after := job.AfterScript
+	if after == nil {
+		after = global.AfterScript
+	}
+
+	script := slices.Clone(job.Script)
+
+	if before != nil {
+		script = slices.Concat(*before, script)
+	}
+
+	if after != nil && len(*after) > 0 {
+		step.TODO = append(step.TODO, "the after_script commands only run when the script succeeds")
+
+		script = slices.Concat(script, *after)
+	}
+
+	for _, command := range script {
+		for _, line := range convertCommands(command) {
+			step.Commands = append(step.Commands, gitlabReplace(line, true, &step.TODO))
+		}
+	}
+
+	// matrix variables are declared for the steps of the job
+	keys, combinations := gitlabMatrix(&job.Parallel, &step.TODO)
+	for _, key := range keys {
+		jobDeclared[key] = true
+	}
+
+	gitlabSecrets(build, step, script, jobDeclared)
+
+	gitlabJobRules(step, job)
+
+	for _, key := range slices.Sorted(maps.Keys(job.Extra)) {
+		step.TODO = append(step.TODO, fmt.Sprintf("%s can not be converted", key))
+	}
+
+	if len(keys) == 0 {
+		return []*convertStep{step}
+	}
+
+	steps := []*convertStep{}
+
+	for _, combination := range combinations {
+		values := []string{name}
+
+		converted := *step
+		converted.Environment = maps.Clone(step.Environment)
+
+		if converted.Environment == nil {
+			converted.Environment = map[string]string{}
+		}
+
+		for _, key := range keys {
+			values = append(values, combination[key])
+			converted.Environment[key] = combination[key]
+		}
+
+		converted.Name = strings.Join(values, "-")
+
+		steps = append(steps, &converted)
+	}
+
+	return steps
+}
+
+// gitlabJobRules converts the rules, only and except, when,
+// allow_failure and needs of the GitLab CI job into the ruleset.
+func gitlabJobRules(step *convertStep, job *gitlabJob) {
+	when, unless, allowFailure := gitlabRules(job.Rules, &step.TODO)
+
+	refsWhen := gitlabOnly(job.Only, &step.TODO)
+	refsUnless := gitlabOnly(job.Except, &step.TODO)
+
+	when = when.mergeIf(refsWhen, &step.TODO)
+	unless = unless.mergeUnless(refsUnless, &step.TODO)
+
+	switch job.When {
+	case "", "on_success":
+	case "on_failure":
+		when.Status = []string{constants.StatusFailure}
+	case "always":
+		when.Status = []string{constants.StatusSuccess, constants.StatusFailure}
+	default:
+		step.TODO = append(step.TODO, fmt.Sprintf("when %s can not be converted", job.When))
+	}
+
+	step.addRules(when, unless)
+
+	allowed := yamlResolve(&job.AllowFailure)
+
+	switch {
+	case allowed.Kind == yaml.ScalarNode && allowed.Value == "true":
+		allowFailure = true
+	case allowed.Kind == yaml.MappingNode:
+		step.TODO = append(step.TODO, "allow_failure for exit codes can not be converted")
+	}
+
+	if allowFailure {
+		if step.Ruleset == nil {
+			step.Ruleset = new(convertRuleset)
+		}
+
+		step.Ruleset.Continue = true
+	}
+
+	if yamlResolve(&job.Needs).Kind != 0 {
+		step.TODO = append(step.TODO, "needs can not be converted, the stages run in the order of the stages")
+	}
+}
+
+// gitlabRules converts the rules of the GitLab CI job into the rules to
+// run the step, the rules to skip the step and if failures are allowed.
+func gitlabRules(rules []*gitlabRule, todo *[]string) (convertRules, convertRules, bool) {
+	when := convertRules{}
+	unless := convertRules{}
+	allowFailure := false
+
+	runs := []convertRules{}
+
+	for _, rule := range rules {
+		ruleWhen, ruleUnless, ok := gitlabCondition(rule.If, todo)
+		if !ok {
+			continue
+		}
+
+		ruleWhen.Path = convertAppend(ruleWhen.Path, rule.Changes...)
+
+		for _, key := range slices.Sorted(maps.Keys(rule.Extra)) {
+			*todo = append(*todo, fmt.Sprintf("%s of the rules can not be converted", key))
+		}
+
+		if rule.AllowFailure {
+			allowFailure = true
+		}
+
+		switch rule.When {
+		case "never":
+			// only a single rule can be negated into the rules to skip the step
+			if ruleWhen.fields() > 1 || !ruleUnless.empty() {
+				*todo = append(*todo, fmt.Sprintf("rule %s with when never can not be converted", rule.If))
+
+				continue
+			}
+
+			unless = unless.add(ruleWhen)
+		case "", "on_success", "always":
+			if ruleWhen.empty() {
+				// the rule matches every pipeline that was not skipped
+				unless = unless.mergeUnless(ruleUnless, todo)
+
+				return convertRules{}, unless, allowFailure
+			}
+
+			runs = append(runs, ruleWhen)
+			unless = unless.mergeUnless(ruleUnless, todo)
+		default:
+			*todo = append(*todo, fmt.Sprintf("rule %s with when %s can not be converted", rule.If, rule.When))
+		}
+	}
+
+	for _, run := range runs {
+		when = when.add(run)
+	}
+
+	// either of the rules runs the step, which only
+	// converts when the rules match the same value
+	if len(runs) > 1 && when.fields() > 1 {
+		*todo = append(*todo, "the rules match different values, the step runs when every value matches")
+	}
+
+	return when, unless, allowFailure
+}
+
+// gitlabCondition converts the condition of the rule of a GitLab CI job
+// into the rules to run the step and the rules to skip the step.
+func gitlabCondition(condition string, todo *[]string) (convertRules, convertRules, bool) {
+	when := convertRules{}
+	unless := convertRules{}
+
+	condition = strings.TrimSpace(condition)
+	if len(condition) == 0 {
+		return when, unless, true
+	}
+
+	if strings.Contains(condition, "||") {
+		*todo = append(*todo, fmt.Sprintf("condition %s can not be converted", condition))
+
+		return when, unless, false
+	}
+
+	for term := range strings.SplitSeq(condition, "&&") {
+		term = strings.TrimSpace(term)
+
+		converted := false
+
+		for _, c := range gitlabConditions {
+			match := c.pattern.FindStringSubmatch(term)
+			if match != nil && c.rules(match, &when, &unless) {
+				converted = true
+
+				break
+			}
+		}
+
+		if !converted {
+			*todo = append(*todo, fmt.Sprintf("condition %s can not be converted", term))
+
+			return when, unless, false
+		}
+	}
+
+	return when, unless, true
+}
+
+// gitlabOnly converts the only or except keyword of the GitLab CI job into rules.
+func gitlabOnly(refs *gitlabRefs, todo *[]string) convertRules {
+	rules := convertRules{}
+
+	if refs == nil {
+		return rules
+	}
+
+	for _, ref := range refs.Refs {
+		event, ok := gitlabSources[ref]
+
+		switch {
+		case ok:
+			rules.Event = convertAppend(rules.Event, event)
+		case strings.HasPrefix(ref, "/"), slices.Contains([]string{"api", "chat", "external", "pipelines", "triggers", "web"}, ref):
+			*todo = append(*todo, fmt.Sprintf("ref %s can not be converted", ref))
+		default:
+			rules.Branch = convertAppend(rules.Branch, ref)
+		}
+	}
+
+	// the refs of GitLab CI match either of the refs
+	if rules.fields() > 1 {
+		*todo = append(*todo, "the refs match different values, the step runs when every value matches")
+	}
+
+	rules.Path = convertAppend(rules.Path, refs.Changes...)
+
+	for _, key := range slices.Sorted(maps.Keys(refs.Extra)) {
+		*todo = append(*todo, fmt.Sprintf("%s of only or except can not be converted", key))
+	}
+
+	return rules
+}
+
+// gitlabMatrix returns the keys and the combinations of the
+// values of the parallel matrix of the GitLab CI job.
+func gitlabMatrix(node *yaml.Node, todo *[]string) ([]string, []map[string]string) {
+	node = yamlResolve(node)
+
+	if node.Kind == 0 {
+		return nil, nil
+	}
+
+	parallel := new(struct {
+		Matrix []map[string]convertList `yaml:"matrix"`
+	})
+
+	if node.Kind != yaml.MappingNode || node.Decode(parallel) != nil || len(parallel.Matrix) == 0 {
+		*todo = append(*todo, "parallel can not be converted")
+
+		return nil, nil
+	}
+
+	keys := []string{}
+	combinations := []map[string]string{}
+
+	for _, entry := range parallel.Matrix {
+		entryKeys := slices.Sorted(maps.Keys(entry))
+
+		for _, key := range entryKeys {
+			keys = convertAppend(keys, key)
+		}
+
+		values := map[string][]string{}
+
+		for key, value := range entry {
+			values[key] = value
+		}
+
+		combinations = append(combinations, convertMatrix(entryKeys, values)...)
+	}
+
+	return keys, combinations
+}
+
+// gitlabSecrets adds the variables used in the script which are
+// not declared, or predefined by GitLab CI, as secrets for the step.
+func gitlabSecrets(build *convertBuild, step *convertStep, script []string, declared map[string]bool) {
+	for _, command := range script {
+		for _, match := range convertVariable.FindAllStringSubmatch(command, -1) {
+			name := match[1]
+
+			switch {
+			case declared[name],
+				!gitlabSecret.MatchString(name),
+				slices.Contains(gitlabShell, name),
+				strings.HasPrefix(name, "CI_"),
+				strings.HasPrefix(name, "GITLAB_"),
+				strings.HasPrefix(name, "VELA_"),
+				strings.Contains(strings.Join(script, "\n"), name+"="):
+				continue
+			}
+
+			build.addSecret(strings.ToLower(name))
+			step.addSecret(strings.ToLower(name), name)
+		}
+	}
+}
+
+// gitlabReplace replaces the predefined variables of GitLab CI in the
+// value with the environment variables of Vela, the value is escaped
+// for the shell for commands.
+func gitlabReplace(value string, commands bool, todo *[]string) string {
+	value = convertReplace(value, func(name string) (string, bool) {
+		vela, ok := gitlabVariables[name]
+		if ok {
+			return vela, true
+		}
+
+		if strings.HasPrefix(name, "CI_") || strings.HasPrefix(name, "GITLAB_") {
+			*todo = append(*todo, fmt.Sprintf("variable %s can not be converted", name))
+		}
+
+		return name, false
+	})
+
+	if commands {
+		return convertEscape(value)
+	}
+
+	return value
+}
@@ -0,0 +1,458 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/server/compiler/native"
+)
+
+func TestPipeline_Config_Convert(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewOsFs()
+
+	// setup types
+	cmd := new(cli.Command)
+	cmd.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone-image",
+			Value: "target/vela-git:latest",
+		},
+	}
+
+	client, err := native.FromCLICommand(t.Context(), cmd)
+	if err != nil {
+		t.Errorf("unable to create client: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		failure  bool
+		existing bool
+		config   *Config
+	}{
+		{
+			name:    "drone",
+			failure: false,
+			config:  &Config{Action: "convert", From: "drone", Source: "testdata/convert/drone.yml", File: ".vela.yml"},
+		},
+		{
+			name:    "github actions",
+			failure: false,
+			config:  &Config{Action: "convert", From: "github-actions", Source: "testdata/convert/github-actions.yml", File: ".vela.yml"},
+		},
+		{
+			name:    "gitlab",
+			failure: false,
+			config:  &Config{Action: "convert", From: "gitlab", Source: "testdata/convert/gitlab.yml", File: ".vela.yml"},
+		},
+		{
+			name:    "missing source",
+			failure: true,
+			config:  &Config{Action: "convert", From: "drone", Source: "testdata/convert/missing.yml", File: ".vela.yml"},
+		},
+		{
+			name:    "invalid source",
+			failure: true,
+			config:  &Config{Action: "convert", From: "github-actions", Source: "testdata/convert/drone.yml", File: ".vela.yml"},
+		},
+		{
+			name:    "invalid from",
+			failure: true,
+			config:  &Config{Action: "convert", From: "jenkins", Source: "testdata/convert/drone.yml", File: ".vela.yml"},
+		},
+		{
+			name:     "existing pipeline",
+			failure:  true,
+			existing: true,
+			config:   &Config{Action: "convert", From: "drone", Source: "testdata/convert/drone.yml", File: ".vela.yml"},
+		},
+		{
+			name:     "existing pipeline with force",
+			failure:  false,
+			existing: true,
+			config:   &Config{Action: "convert", From: "drone", Source: "testdata/convert/drone.yml", File: ".vela.yml", Force: true},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Path = t.TempDir()
+
+			if test.existing {
+				err := os.WriteFile(filepath.Join(test.config.Path, test.config.File), []byte("version: \"1\"\n"), 0o600)
+				if err != nil {
+					t.Fatalf("unable to write pipeline: %v", err)
+				}
+			}
+
+			err := test.config.Convert(t.Context(), client)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Convert should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Convert returned err: %v", err)
+			}
+
+			_, err = os.Stat(filepath.Join(test.config.Path, test.config.File))
+			if err != nil {
+				t.Errorf("Convert did not write the pipeline: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_convertFrom(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		convert func([]byte) (*convertBuild, error)
+	}{
+		{name: "drone", convert: convertFromDrone},
+		{name: "github-actions", convert: convertFromGitHubActions},
+		{name: "gitlab", convert: convertFromGitLab},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "convert", test.name+".yml"))
+			if err != nil {
+				t.Fatalf("unable to read pipeline: %v", err)
+			}
+
+			want, err := os.ReadFile(filepath.Join("testdata", "convert", test.name+".vela.yml"))
+			if err != nil {
+				t.Fatalf("unable to read converted pipeline: %v", err)
+			}
+
+			build, err := test.convert(data)
+			if err != nil {
+				t.Fatalf("convert returned err: %v", err)
+			}
+
+			got, err := build.marshal("octocat", "hello-world")
+			if err != nil {
+				t.Fatalf("marshal returned err: %v", err)
+			}
+
+			if string(got) != string(want) {
+				t.Errorf("convert is %s, want %s", got, want)
+			}
+
+			// the converted pipeline is already formatted
+			formatted, err := format(got)
+			if err != nil {
+				t.Fatalf("format returned err: %v", err)
+			}
+
+			if string(formatted) != string(got) {
+				t.Errorf("convert is not formatted, got %s", got)
+			}
+		})
+	}
+}
+
+func TestPipeline_convertBuild_marshal(t *testing.T) {
+	// setup types
+	build := &convertBuild{
+		Secrets: []string{"token"},
+		Steps: []*convertStep{
+			{
+				Name:     "test",
+				Image:    "alpine:latest",
+				Commands: []string{"echo $${TOKEN}"},
+				TODO:     []string{"review the step"},
+			},
+		},
+	}
+
+	want := `version: "1"
+
+# TODO: replace ORG/REPO in the keys with the org and repo of the secrets
+secrets:
+  - name: token
+    key: ORG/REPO/token
+    engine: native
+    type: repo
+
+steps:
+  # TODO: review the step
+  - name: test
+    image: alpine:latest
+    commands:
+      - echo $${TOKEN}
+`
+
+	got, err := build.marshal("", "")
+	if err != nil {
+		t.Fatalf("marshal returned err: %v", err)
+	}
+
+	if string(got) != want {
+		t.Errorf("marshal is %s, want %s", got, want)
+	}
+}
+
+func TestPipeline_convertReplace(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "echo $DRONE_TAG", want: "echo $VELA_BUILD_TAG"},
+		{value: "echo ${DRONE_TAG}", want: "echo ${VELA_BUILD_TAG}"},
+		{value: "echo $${DRONE_TAG}", want: "echo $${DRONE_TAG}"},
+		{value: "echo $HOME $DRONE_COMMIT_SHA", want: "echo $HOME $VELA_BUILD_COMMIT"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := convertReplace(test.value, func(name string) (string, bool) {
+			vela, ok := droneVariables[name]
+
+			return vela, ok
+		})
+
+		if got != test.want {
+			t.Errorf("convertReplace for %s is %s, want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestPipeline_convertMatrix(t *testing.T) {
+	// setup types
+	want := []map[string]string{
+		{"go": "1.23", "os": "linux"},
+		{"go": "1.23", "os": "darwin"},
+		{"go": "1.24", "os": "linux"},
+		{"go": "1.24", "os": "darwin"},
+	}
+
+	got := convertMatrix([]string{"go", "os"}, map[string][]string{
+		"go": {"1.23", "1.24"},
+		"os": {"linux", "darwin"},
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("convertMatrix is %v, want %v", got, want)
+	}
+}
+
+func TestPipeline_convertRules_mergeIf(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name  string
+		rules convertRules
+		other convertRules
+		want  convertRules
+		todo  []string
+	}{
+		{
+			name:  "different rules",
+			rules: convertRules{Branch: []string{"main"}},
+			other: convertRules{Event: []string{"push"}},
+			want:  convertRules{Branch: []string{"main"}, Event: []string{"push"}},
+			todo:  []string{},
+		},
+		{
+			name:  "overlapping values",
+			rules: convertRules{Event: []string{"push", "tag"}},
+			other: convertRules{Event: []string{"push", "pull_request"}},
+			want:  convertRules{Event: []string{"push"}},
+			todo:  []string{},
+		},
+		{
+			name:  "conflicting values",
+			rules: convertRules{Branch: []string{"main"}},
+			other: convertRules{Branch: []string{"release/*"}},
+			want:  convertRules{Branch: []string{"main"}},
+			todo:  []string{"branch must also match [release/*], which can not be combined with [main]"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			todo := []string{}
+
+			got := test.rules.mergeIf(test.other, &todo)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("mergeIf is %v, want %v", got, test.want)
+			}
+
+			if !reflect.DeepEqual(todo, test.todo) {
+				t.Errorf("mergeIf TODO is %v, want %v", todo, test.todo)
+			}
+		})
+	}
+}
+
+func TestPipeline_convertRules_mergeUnless(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name  string
+		rules convertRules
+		other convertRules
+		want  convertRules
+		todo  []string
+	}{
+		{
+			name:  "same rule",
+			rules: convertRules{Branch: []string{"main"}},
+			other: convertRules{Branch: []string{"dev"}},
+			want:  convertRules{Branch: []string{"main", "dev"}},
+			todo:  []string{},
+		},
+		{
+			name:  "different rules",
+			rules: convertRules{Branch: []string{"main"}},
+			other: convertRules{Event: []string{"tag"}},
+			want:  convertRules{Branch: []string{"main"}},
+			todo:  []string{"also skip the step for event: [tag], which can not be combined with skipping the step for branch: [main]"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			todo := []string{}
+
+			got := test.rules.mergeUnless(test.other, &todo)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("mergeUnless is %v, want %v", got, test.want)
+			}
+
+			if !reflect.DeepEqual(todo, test.todo) {
+				t.Errorf("mergeUnless TODO is %v, want %v", todo, test.todo)
+			}
+		})
+	}
+}
+
+func TestPipeline_githubCondition(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		condition string
+		when      convertRules
+		unless    convertRules
+		todo      int
+	}{
+		{
+			condition: "${{ github.ref == 'refs/heads/main' }}",
+			when:      convertRules{Branch: []string{"main"}},
+		},
+		{
+			condition: "github.event_name != 'pull_request' && always()",
+			when:      convertRules{Status: []string{"success", "failure"}},
+			unless:    convertRules{Event: []string{"pull_request"}},
+		},
+		{
+			condition: "startsWith(github.ref, 'refs/tags/v')",
+			when:      convertRules{Event: []string{"tag"}, Tag: []string{"v*"}},
+		},
+		{
+			condition: "github.ref_name == 'main' || github.ref_name == 'dev'",
+			todo:      1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		todo := []string{}
+
+		when, unless := githubCondition(test.condition, &todo)
+
+		if !reflect.DeepEqual(when, test.when) {
+			t.Errorf("githubCondition for %s is %v, want %v", test.condition, when, test.when)
+		}
+
+		if !reflect.DeepEqual(unless, test.unless) {
+			t.Errorf("githubCondition unless for %s is %v, want %v", test.condition, unless, test.unless)
+		}
+
+		if len(todo) != test.todo {
+			t.Errorf("githubCondition TODO for %s is %v, want %d items", test.condition, todo, test.todo)
+		}
+	}
+}
+
+func TestPipeline_gitlabRules(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		rules  []*gitlabRule
+		when   convertRules
+		unless convertRules
+		todo   int
+	}{
+		{
+			name: "either branch",
+			rules: []*gitlabRule{
+				{If: `$CI_COMMIT_BRANCH == "main"`},
+				{If: `$CI_COMMIT_BRANCH == "dev"`},
+			},
+			when: convertRules{Branch: []string{"main", "dev"}},
+		},
+		{
+			name: "never",
+			rules: []*gitlabRule{
+				{If: `$CI_PIPELINE_SOURCE == "schedule"`, When: "never"},
+				{When: "on_success"},
+			},
+			unless: convertRules{Event: []string{"schedule"}},
+		},
+		{
+			name: "changes",
+			rules: []*gitlabRule{
+				{If: `$CI_PIPELINE_SOURCE == "merge_request_event"`, Changes: gitlabChanges{"src/**"}},
+			},
+			when: convertRules{Event: []string{"pull_request"}, Path: []string{"src/**"}},
+		},
+		{
+			name: "regex",
+			rules: []*gitlabRule{
+				{If: `$CI_COMMIT_TAG =~ /^v/`},
+			},
+			todo: 1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			todo := []string{}
+
+			when, unless, _ := gitlabRules(test.rules, &todo)
+
+			if !reflect.DeepEqual(when, test.when) {
+				t.Errorf("gitlabRules is %v, want %v", when, test.when)
+			}
+
+			if !reflect.DeepEqual(unless, test.unless) {
+				t.Errorf("gitlabRules unless is %v, want %v", unless, test.unless)
+			}
+
+			if len(todo) != test.todo {
+				t.Errorf("gitlabRules TODO is %v, want %d items", todo, test.todo)
+			}
+		})
+	}
+}
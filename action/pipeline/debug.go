@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/constants"
+	"github.com/go-vela/worker/runtime"
+)
+
+// debugCommand keeps the debug container running
+// until it is removed after the shell exits.
+const debugCommand = "tail -f /dev/null"
+
+// debugFailure starts an interactive shell in a container created
+// from the first failed step of the pipeline. The container uses the
+// same image, environment, workspace, volumes and network as the
+// failed step and is removed once the shell exits.
+func (c *Config) debugFailure(ctx context.Context, r runtime.Engine, s internal.Shell, p *pipeline.Build, stdin io.Reader, stdout, stderr io.Writer) error {
+	// check if the build was canceled
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	ctn := failedStep(p)
+	if ctn == nil {
+		logrus.Debug("no failed steps found to debug")
+
+		return nil
+	}
+
+	logrus.Infof("step %s failed with exit code %d - starting debug shell", ctn.Name, ctn.ExitCode)
+
+	debug := debugContainer(ctn)
+
+	// setup the debug container with the runtime
+	err := r.SetupContainer(ctx, debug)
+	if err != nil {
+		return fmt.Errorf("unable to setup debug container: %w", err)
+	}
+
+	// run the debug container with the runtime
+	err = r.RunContainer(ctx, debug, p)
+	if err != nil {
+		return fmt.Errorf("unable to run debug container: %w", err)
+	}
+
+	defer func() {
+		logrus.Debugf("removing debug container %s", debug.ID)
+
+		// remove the debug container with the runtime
+		err := r.RemoveContainer(context.Background(), debug)
+		if err != nil {
+			logrus.Errorf("unable to remove debug container: %v", err)
+		}
+	}()
+
+	shell := c.DebugShell
+	if len(shell) == 0 {
+		shell = "/bin/sh"
+	}
+
+	logrus.Infof("exit the shell to resume cleaning up the build")
+
+	// attach an interactive shell to the debug container
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#Shell
+	err = s.Exec(ctx, debug.ID, []string{shell}, stdin, stdout, stderr)
+	if err != nil {
+		// the exit code of the last command in the shell is expected
+		logrus.Debugf("debug shell exited: %v", err)
+	}
+
+	return nil
+}
+
+// failedStep returns the first step in the pipeline
+// that exited with a non-zero exit code.
+func failedStep(p *pipeline.Build) *pipeline.Container {
+	if p == nil {
+		return nil
+	}
+
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			if step.ExitCode != 0 {
+				return step
+			}
+		}
+	}
+
+	for _, step := range p.Steps {
+		if step.ExitCode != 0 {
+			return step
+		}
+	}
+
+	return nil
+}
+
+// debugContainer creates a long running copy of the provided
+// container that an interactive shell can be attached to.
+func debugContainer(ctn *pipeline.Container) *pipeline.Container {
+	debug := *ctn
+
+	debug.ID = fmt.Sprintf("%s_debug", ctn.ID)
+	debug.Detach = true
+	debug.ExitCode = 0
+	debug.Entrypoint = []string{"/bin/sh", "-c"}
+	debug.Commands = []string{debugCommand}
+	debug.Pull = constants.PullNotPresent
+	debug.Environment = maps.Clone(ctn.Environment)
+
+	if debug.Environment == nil {
+		debug.Environment = make(map[string]string)
+	}
+
+	// provide the secrets from the local environment
+	for _, secret := range ctn.Secrets {
+		val, exists := os.LookupEnv(secret.Target)
+		if exists {
+			debug.Environment[secret.Target] = val
+		}
+	}
+
+	return &debug
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/worker/runtime/docker"
+)
+
+// testShell is a fake internal.Shell capturing
+// the container and command of the last exec.
+type testShell struct {
+	got []string
+}
+
+// Exec captures the container ID and command.
+func (s *testShell) Exec(_ context.Context, id string, cmd []string, _ io.Reader, _, _ io.Writer) error {
+	s.got = append([]string{id}, cmd...)
+
+	return nil
+}
+
+func TestPipeline_Config_debugFailure(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Fatalf("unable to create runtime engine: %v", err)
+	}
+
+	shell := new(testShell)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// setup tests
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		config   *Config
+		pipeline *pipeline.Build
+		want     []string
+	}{
+		{
+			name:   "failed step",
+			ctx:    context.Background(),
+			config: &Config{DebugOnFailure: true},
+			pipeline: &pipeline.Build{
+				ID: "github_octocat_1",
+				Steps: pipeline.ContainerSlice{
+					{ID: "step_github_octocat_1_init", Name: "init", Image: "#init"},
+					{ID: "step_github_octocat_1_test", Name: "test", Image: "alpine:latest", ExitCode: 1},
+				},
+			},
+			want: []string{"step_github_octocat_1_test_debug", "/bin/sh"},
+		},
+		{
+			name:   "failed stage step with custom shell",
+			ctx:    context.Background(),
+			config: &Config{DebugOnFailure: true, DebugShell: "/bin/bash"},
+			pipeline: &pipeline.Build{
+				ID: "github_octocat_1",
+				Stages: pipeline.StageSlice{
+					{
+						Name: "test",
+						Steps: pipeline.ContainerSlice{
+							{ID: "github_octocat_1_test_test", Name: "test", Image: "alpine:latest", ExitCode: 2},
+						},
+					},
+				},
+			},
+			want: []string{"github_octocat_1_test_test_debug", "/bin/bash"},
+		},
+		{
+			name:   "no failed steps",
+			ctx:    context.Background(),
+			config: &Config{DebugOnFailure: true},
+			pipeline: &pipeline.Build{
+				ID: "github_octocat_1",
+				Steps: pipeline.ContainerSlice{
+					{ID: "step_github_octocat_1_test", Name: "test", Image: "alpine:latest"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name:   "canceled build",
+			ctx:    canceled,
+			config: &Config{DebugOnFailure: true},
+			pipeline: &pipeline.Build{
+				ID: "github_octocat_1",
+				Steps: pipeline.ContainerSlice{
+					{ID: "step_github_octocat_1_test", Name: "test", Image: "alpine:latest", ExitCode: 1},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shell.got = nil
+
+			err := test.config.debugFailure(test.ctx, _runtime, shell, test.pipeline, strings.NewReader(""), new(bytes.Buffer), new(bytes.Buffer))
+			if err != nil {
+				t.Errorf("debugFailure returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(shell.got, test.want) {
+				t.Errorf("debugFailure ran shell %v, want %v", shell.got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_debugContainer(t *testing.T) {
+	// setup types
+	t.Setenv("SECRET_TOKEN", "secret")
+
+	ctn := &pipeline.Container{
+		ID:          "step_github_octocat_1_test",
+		Name:        "test",
+		Image:       "golang:latest",
+		Directory:   "/vela/src/github.com/github/octocat",
+		Commands:    []string{"go test ./..."},
+		Environment: map[string]string{"FOO": "bar"},
+		ExitCode:    1,
+		Pull:        "always",
+		Secrets: pipeline.StepSecretSlice{
+			{Source: "token", Target: "SECRET_TOKEN"},
+		},
+	}
+
+	// run test
+	got := debugContainer(ctn)
+
+	if got.ID != "step_github_octocat_1_test_debug" {
+		t.Errorf("debugContainer ID is %s", got.ID)
+	}
+
+	if got.Image != ctn.Image || got.Directory != ctn.Directory {
+		t.Errorf("debugContainer should use the image and directory of the failed step")
+	}
+
+	if !got.Detach || got.ExitCode != 0 || got.Pull != "not_present" {
+		t.Errorf("debugContainer is %+v", got)
+	}
+
+	if !reflect.DeepEqual(got.Commands, []string{debugCommand}) {
+		t.Errorf("debugContainer commands are %v", got.Commands)
+	}
+
+	want := map[string]string{"FOO": "bar", "SECRET_TOKEN": "secret"}
+
+	if !reflect.DeepEqual(got.Environment, want) {
+		t.Errorf("debugContainer environment is %v, want %v", got.Environment, want)
+	}
+
+	// the failed step must not be modified
+	if _, ok := ctn.Environment["SECRET_TOKEN"]; ok || ctn.ExitCode != 1 {
+		t.Errorf("debugContainer modified the failed step")
+	}
+}
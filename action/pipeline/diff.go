@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/sdk-go/vela"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/types/yaml"
+)
+
+const (
+	// diffText outputs the diff of the pipelines as text.
+	diffText = "text"
+
+	// diffAdded is the change for a resource only in the new pipeline.
+	diffAdded = "added"
+
+	// diffRemoved is the change for a resource only in the old pipeline.
+	diffRemoved = "removed"
+
+	// diffChanged is the change for a resource in both pipelines with different fields.
+	diffChanged = "changed"
+)
+
+// pipelineDiff represents the structural differences between two pipelines.
+type pipelineDiff struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Changes []*diffResource `json:"changes"`
+}
+
+// diffResource represents a stage, step, service or
+// secret added, removed or changed between two pipelines.
+type diffResource struct {
+	Kind   string       `json:"kind"`
+	Name   string       `json:"name"`
+	Change string       `json:"change"`
+	Fields []*diffField `json:"fields,omitempty"`
+}
+
+// diffField represents a field of a resource changed between two pipelines.
+type diffField struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// Diff compares the expanded pipelines of the
+// repository for the two provided refs.
+func (c *Config) Diff(ctx context.Context, client *vela.Client) error {
+	logrus.Debug("executing diff for pipeline configuration")
+
+	from, err := c.expandRemote(ctx, client, c.Refs[0])
+	if err != nil {
+		return err
+	}
+
+	to, err := c.expandRemote(ctx, client, c.Refs[1])
+	if err != nil {
+		return err
+	}
+
+	return c.diff(newPipelineDiff(c.diffName(c.Refs[0]), from, c.diffName(c.Refs[1]), to))
+}
+
+// DiffLocal compares the expanded pipeline of the repository
+// for the provided ref with the local pipeline.
+func (c *Config) DiffLocal(ctx context.Context, client *vela.Client, engine compiler.Engine) error {
+	logrus.Debug("executing diff for local pipeline configuration")
+
+	from, err := c.expandRemote(ctx, client, c.Refs[0])
+	if err != nil {
+		return err
+	}
+
+	to, _, err := c.expandLocal(ctx, engine)
+	if err != nil {
+		return err
+	}
+
+	return c.diff(newPipelineDiff(c.diffName(c.Refs[0]), from, filepath.Join(c.Path, c.File), to))
+}
+
+// diffName returns the name of the pipeline for the provided ref.
+func (c *Config) diffName(ref string) string {
+	return fmt.Sprintf("%s/%s@%s", c.Org, c.Repo, ref)
+}
+
+// diff outputs the differences between the
+// pipelines based off the provided configuration.
+func (c *Config) diff(d *pipelineDiff) error {
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverJSON:
+		// output the diff in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(d, c.Color)
+	default:
+		// output the diff in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(d.text())
+	}
+}
+
+// newPipelineDiff returns the structural differences for the stages,
+// steps, services and secrets between the provided pipelines.
+func newPipelineDiff(fromName string, from *yaml.Build, toName string, to *yaml.Build) *pipelineDiff {
+	d := &pipelineDiff{From: fromName, To: toName, Changes: []*diffResource{}}
+
+	fromStages, fromStageOrder := diffStages(from)
+	toStages, toStageOrder := diffStages(to)
+
+	d.compare("stage", fromStageOrder, toStageOrder, func(name string) []*diffField {
+		return diffStage(fromStages[name], toStages[name])
+	})
+
+	fromSteps, fromOrder := diffSteps(from)
+	toSteps, toOrder := diffSteps(to)
+
+	d.compare("step", fromOrder, toOrder, func(name string) []*diffField {
+		return diffStep(fromSteps[name], toSteps[name])
+	})
+
+	fromServices := map[string]*yaml.Service{}
+	for _, service := range from.Services {
+		fromServices[service.Name] = service
+	}
+
+	toServices := map[string]*yaml.Service{}
+	for _, service := range to.Services {
+		toServices[service.Name] = service
+	}
+
+	d.compare("service", slices.Sorted(maps.Keys(fromServices)), slices.Sorted(maps.Keys(toServices)), func(name string) []*diffField {
+		return diffService(fromServices[name], toServices[name])
+	})
+
+	fromSecrets := map[string]*yaml.Secret{}
+	for _, secret := range from.Secrets {
+		fromSecrets[secret.Name] = secret
+	}
+
+	toSecrets := map[string]*yaml.Secret{}
+	for _, secret := range to.Secrets {
+		toSecrets[secret.Name] = secret
+	}
+
+	d.compare("secret", slices.Sorted(maps.Keys(fromSecrets)), slices.Sorted(maps.Keys(toSecrets)), func(name string) []*diffField {
+		return diffSecret(fromSecrets[name], toSecrets[name])
+	})
+
+	return d
+}
+
+// compare adds the resources of the provided kind that were removed,
+// changed or added, in that order, between the provided names.
+func (d *pipelineDiff) compare(kind string, from, to []string, fields func(name string) []*diffField) {
+	for _, name := range from {
+		if !slices.Contains(to, name) {
+			d.Changes = append(d.Changes, &diffResource{Kind: kind, Name: name, Change: diffRemoved})
+
+			continue
+		}
+
+		changes := fields(name)
+		if len(changes) > 0 {
+			d.Changes = append(d.Changes, &diffResource{Kind: kind, Name: name, Change: diffChanged, Fields: changes})
+		}
+	}
+
+	for _, name := range to {
+		if !slices.Contains(from, name) {
+			d.Changes = append(d.Changes, &diffResource{Kind: kind, Name: name, Change: diffAdded})
+		}
+	}
+}
+
+// diffStages returns the stages of the pipeline
+// and the names in the pipeline order.
+func diffStages(p *yaml.Build) (map[string]*yaml.Stage, []string) {
+	stages := map[string]*yaml.Stage{}
+	order := []string{}
+
+	for _, stage := range p.Stages {
+		stages[stage.Name] = stage
+		order = append(order, stage.Name)
+	}
+
+	return stages, order
+}
+
+// diffStage returns the fields changed between the provided
+// stages, which includes the order of the steps in the stage.
+func diffStage(from, to *yaml.Stage) []*diffField {
+	fields := []*diffField{}
+
+	stepNames := func(stage *yaml.Stage) string {
+		names := []string{}
+
+		for _, step := range stage.Steps {
+			names = append(names, step.Name)
+		}
+
+		return strings.Join(names, ", ")
+	}
+
+	fields = diffValue(fields, "needs", strings.Join(slices.Sorted(slices.Values(from.Needs)), ", "), strings.Join(slices.Sorted(slices.Values(to.Needs)), ", "))
+	fields = diffValue(fields, "independent", strconv.FormatBool(from.Independent), strconv.FormatBool(to.Independent))
+	fields = diffMap(fields, "environment", from.Environment, to.Environment)
+
+	return diffValue(fields, "steps", stepNames(from), stepNames(to))
+}
+
+// diffSteps returns the steps of the pipeline, named with the stage
+// for a pipeline with stages, and the names in the pipeline order.
+func diffSteps(p *yaml.Build) (map[string]*yaml.Step, []string) {
+	steps := map[string]*yaml.Step{}
+	order := []string{}
+
+	add := func(name string, step *yaml.Step) {
+		steps[name] = step
+		order = append(order, name)
+	}
+
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			add(fmt.Sprintf("%s:%s", stage.Name, step.Name), step)
+		}
+	}
+
+	for _, step := range p.Steps {
+		add(step.Name, step)
+	}
+
+	return steps, order
+}
+
+// diffStep returns the fields changed between the provided steps.
+func diffStep(from, to *yaml.Step) []*diffField {
+	fields := []*diffField{}
+
+	fields = diffValue(fields, "image", from.Image, to.Image)
+	fields = diffValue(fields, "commands", strings.Join(from.Commands, "\n"), strings.Join(to.Commands, "\n"))
+	fields = diffMap(fields, "environment", from.Environment, to.Environment)
+	fields = diffMap(fields, "parameters", diffParameters(from.Parameters), diffParameters(to.Parameters))
+	fields = diffValue(fields, "ruleset", strings.Join(rulesetNotes(from.Ruleset), "; "), strings.Join(rulesetNotes(to.Ruleset), "; "))
+
+	fromSecrets := map[string]string{}
+	for _, secret := range from.Secrets {
+		fromSecrets[secret.Target] = secret.Source
+	}
+
+	toSecrets := map[string]string{}
+	for _, secret := range to.Secrets {
+		toSecrets[secret.Target] = secret.Source
+	}
+
+	return diffMap(fields, "secrets", fromSecrets, toSecrets)
+}
+
+// diffService returns the fields changed between the provided services.
+func diffService(from, to *yaml.Service) []*diffField {
+	fields := []*diffField{}
+
+	fields = diffValue(fields, "image", from.Image, to.Image)
+	fields = diffMap(fields, "environment", from.Environment, to.Environment)
+
+	return diffValue(fields, "ports", strings.Join(from.Ports, ", "), strings.Join(to.Ports, ", "))
+}
+
+// diffSecret returns the fields changed between the provided secrets.
+func diffSecret(from, to *yaml.Secret) []*diffField {
+	fields := []*diffField{}
+
+	fields = diffValue(fields, "key", from.Key, to.Key)
+	fields = diffValue(fields, "engine", from.Engine, to.Engine)
+	fields = diffValue(fields, "type", from.Type, to.Type)
+
+	return diffValue(fields, "origin", from.Origin.Image, to.Origin.Image)
+}
+
+// diffValue appends the field when the provided values are different.
+func diffValue(fields []*diffField, field, from, to string) []*diffField {
+	if from == to {
+		return fields
+	}
+
+	return append(fields, &diffField{Field: field, From: from, To: to})
+}
+
+// diffMap appends a field for each key with a different value in the provided maps.
+func diffMap(fields []*diffField, field string, from, to map[string]string) []*diffField {
+	keys := map[string]bool{}
+
+	for key := range from {
+		keys[key] = true
+	}
+
+	for key := range to {
+		keys[key] = true
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		fields = diffValue(fields, fmt.Sprintf("%s.%s", field, key), from[key], to[key])
+	}
+
+	return fields
+}
+
+// diffParameters returns the values of the provided
+// parameters, with the values that are not a string
+// in JSON format, to compare them for a step.
+func diffParameters(parameters map[string]any) map[string]string {
+	values := map[string]string{}
+
+	for key, value := range parameters {
+		if s, ok := value.(string); ok {
+			values[key] = s
+
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = fmt.Appendf(nil, "%v", value)
+		}
+
+		values[key] = string(data)
+	}
+
+	return values
+}
+
+// text returns the differences between the pipelines in plain text.
+func (d *pipelineDiff) text() string {
+	if len(d.Changes) == 0 {
+		return fmt.Sprintf("no differences between %s and %s", d.From, d.To)
+	}
+
+	b := new(strings.Builder)
+
+	fmt.Fprintf(b, "--- %s\n+++ %s\n", d.From, d.To)
+
+	for _, change := range d.Changes {
+		switch change.Change {
+		case diffAdded:
+			fmt.Fprintf(b, "\n+ %s %s\n", change.Kind, change.Name)
+		case diffRemoved:
+			fmt.Fprintf(b, "\n- %s %s\n", change.Kind, change.Name)
+		default:
+			fmt.Fprintf(b, "\n~ %s %s\n", change.Kind, change.Name)
+		}
+
+		for _, field := range change.Fields {
+			switch {
+			case field.Field == "commands":
+				b.WriteString("    commands:\n")
+
+				// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#Diff
+				diff := internal.Diff(field.From+"\n", field.To+"\n", d.From, d.To)
+
+				for line := range strings.SplitSeq(strings.TrimSuffix(diff, "\n"), "\n") {
+					fmt.Fprintf(b, "      %s\n", line)
+				}
+			case len(field.From) == 0:
+				fmt.Fprintf(b, "    %s: added %s\n", field.Field, field.To)
+			case len(field.To) == 0:
+				fmt.Fprintf(b, "    %s: removed %s\n", field.Field, field.From)
+			default:
+				fmt.Fprintf(b, "    %s: %s -> %s\n", field.Field, field.From, field.To)
+			}
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/sdk-go/vela"
+	"github.com/go-vela/server/compiler/types/yaml"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_Config_Diff(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// create a vela client
+	client, err := vela.NewClient(s.URL, "vela", nil)
+	if err != nil {
+		t.Errorf("unable to create client: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main", "48afb5bdc41ad69bf22588491333f7cf71135163"},
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main", "48afb5bdc41ad69bf22588491333f7cf71135163"},
+				Output: "json",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main", "0"},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.Diff(t.Context(), client)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Diff should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Diff returned err: %v", err)
+		}
+	}
+}
+
+func TestPipeline_newPipelineDiff(t *testing.T) {
+	// setup types
+	from := &yaml.Build{
+		Version: "1",
+		Secrets: yaml.SecretSlice{
+			{Name: "docker_username", Key: "octocat/docker_username", Engine: "native", Type: "repo"},
+			{Name: "docker_password", Key: "octocat/docker_password", Engine: "native", Type: "repo"},
+		},
+		Steps: yaml.StepSlice{
+			{
+				Name:     "test",
+				Image:    "golang:1.23",
+				Commands: []string{"go test ./..."},
+				Environment: map[string]string{
+					"CGO_ENABLED": "0",
+					"GOFLAGS":     "-mod=mod",
+				},
+			},
+			{
+				Name:  "publish",
+				Image: "target/vela-docker:latest",
+				Ruleset: yaml.Ruleset{
+					If: yaml.Rules{Event: []string{"push"}},
+				},
+				Secrets: yaml.StepSecretSlice{
+					{Source: "docker_username", Target: "docker_username"},
+					{Source: "docker_password", Target: "docker_password"},
+				},
+			},
+			{Name: "notify", Image: "target/vela-slack:latest"},
+		},
+	}
+
+	to := &yaml.Build{
+		Version: "1",
+		Services: yaml.ServiceSlice{
+			{Name: "redis", Image: "redis:7"},
+		},
+		Secrets: yaml.SecretSlice{
+			{Name: "docker_username", Key: "octocat/docker_username", Engine: "native", Type: "repo"},
+			{Name: "docker_password", Key: "octocat/docker/password", Engine: "native", Type: "org"},
+		},
+		Steps: yaml.StepSlice{
+			{
+				Name:     "test",
+				Image:    "golang:1.24",
+				Commands: []string{"go vet ./...", "go test ./..."},
+				Environment: map[string]string{
+					"GOFLAGS": "-mod=vendor",
+				},
+			},
+			{Name: "lint", Image: "golangci/golangci-lint:latest"},
+			{
+				Name:  "publish",
+				Image: "target/vela-docker:latest",
+				Ruleset: yaml.Ruleset{
+					If: yaml.Rules{Event: []string{"push", "tag"}},
+				},
+				Secrets: yaml.StepSecretSlice{
+					{Source: "docker_username", Target: "docker_username"},
+					{Source: "docker_password", Target: "docker_password"},
+				},
+			},
+		},
+	}
+
+	want := []*diffResource{
+		{
+			Kind:   "step",
+			Name:   "test",
+			Change: "changed",
+			Fields: []*diffField{
+				{Field: "image", From: "golang:1.23", To: "golang:1.24"},
+				{Field: "commands", From: "go test ./...", To: "go vet ./...\ngo test ./..."},
+				{Field: "environment.CGO_ENABLED", From: "0"},
+				{Field: "environment.GOFLAGS", From: "-mod=mod", To: "-mod=vendor"},
+			},
+		},
+		{
+			Kind:   "step",
+			Name:   "publish",
+			Change: "changed",
+			Fields: []*diffField{
+				{Field: "ruleset", From: "if event: push", To: "if event: push, tag"},
+			},
+		},
+		{Kind: "step", Name: "notify", Change: "removed"},
+		{Kind: "step", Name: "lint", Change: "added"},
+		{Kind: "service", Name: "redis", Change: "added"},
+		{
+			Kind:   "secret",
+			Name:   "docker_password",
+			Change: "changed",
+			Fields: []*diffField{
+				{Field: "key", From: "octocat/docker_password", To: "octocat/docker/password"},
+				{Field: "type", From: "repo", To: "org"},
+			},
+		},
+	}
+
+	got := newPipelineDiff("octocat/hello-world@main", from, ".vela.yml", to)
+
+	if !reflect.DeepEqual(got.Changes, want) {
+		t.Errorf("newPipelineDiff is %v, want %v", got.Changes, want)
+	}
+
+	wantText := `--- octocat/hello-world@main
++++ .vela.yml
+
+~ step test
+    image: golang:1.23 -> golang:1.24
+    commands:
+      --- octocat/hello-world@main
+      +++ .vela.yml
+      @@ -1,1 +1,2 @@
+      +go vet ./...
+       go test ./...
+    environment.CGO_ENABLED: removed 0
+    environment.GOFLAGS: -mod=mod -> -mod=vendor
+
+~ step publish
+    ruleset: if event: push -> if event: push, tag
+
+- step notify
+
++ step lint
+
++ service redis
+
+~ secret docker_password
+    key: octocat/docker_password -> octocat/docker/password
+    type: repo -> org`
+
+	if got.text() != wantText {
+		t.Errorf("text is %s, want %s", got.text(), wantText)
+	}
+
+	// compare the pipeline with itself
+	got = newPipelineDiff("octocat/hello-world@main", from, "octocat/hello-world@main", from)
+
+	if len(got.Changes) != 0 {
+		t.Errorf("newPipelineDiff is %v, want no changes", got.Changes)
+	}
+}
+
+func TestPipeline_newPipelineDiff_Stages(t *testing.T) {
+	// setup types
+	from := &yaml.Build{
+		Version: "1",
+		Stages: yaml.StageSlice{
+			{
+				Name:  "build",
+				Needs: []string{"clone"},
+				Steps: yaml.StepSlice{
+					{Name: "compile", Image: "golang:1.24"},
+					{Name: "test", Image: "golang:1.24"},
+				},
+			},
+			{
+				Name:  "deploy",
+				Needs: []string{"build"},
+				Steps: yaml.StepSlice{
+					{Name: "publish", Image: "target/vela-docker:latest"},
+				},
+			},
+		},
+	}
+
+	to := &yaml.Build{
+		Version: "1",
+		Stages: yaml.StageSlice{
+			{
+				Name:  "build",
+				Needs: []string{"clone"},
+				Steps: yaml.StepSlice{
+					{Name: "test", Image: "golang:1.24"},
+					{Name: "compile", Image: "golang:1.24"},
+				},
+			},
+			{
+				Name:  "lint",
+				Needs: []string{"clone"},
+				Steps: yaml.StepSlice{
+					{Name: "lint", Image: "golangci/golangci-lint:latest"},
+				},
+			},
+			{
+				Name:        "deploy",
+				Needs:       []string{"lint", "build"},
+				Independent: true,
+				Steps: yaml.StepSlice{
+					{Name: "publish", Image: "target/vela-docker:latest"},
+				},
+			},
+		},
+	}
+
+	want := []*diffResource{
+		{
+			Kind:   "stage",
+			Name:   "build",
+			Change: "changed",
+			Fields: []*diffField{
+				{Field: "steps", From: "compile, test", To: "test, compile"},
+			},
+		},
+		{
+			Kind:   "stage",
+			Name:   "deploy",
+			Change: "changed",
+			Fields: []*diffField{
+				{Field: "needs", From: "build", To: "build, lint"},
+				{Field: "independent", From: "false", To: "true"},
+			},
+		},
+		{Kind: "stage", Name: "lint", Change: "added"},
+		{Kind: "step", Name: "lint:lint", Change: "added"},
+	}
+
+	// run test
+	got := newPipelineDiff("octocat/hello-world@main", from, ".vela.yml", to)
+
+	if !reflect.DeepEqual(got.Changes, want) {
+		t.Errorf("newPipelineDiff is %v, want %v", got.Changes, want)
+	}
+}
@@ -0,0 +1,348 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/cli/version"
+	api "github.com/go-vela/server/api/types"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/compiler/types/yaml"
+	"github.com/go-vela/server/constants"
+)
+
+const (
+	// envOriginStep defines the origin of variables from the environment of the step.
+	envOriginStep = "step"
+
+	// envOriginStage defines the origin of variables from the environment of the stage.
+	envOriginStage = "stage"
+
+	// envOriginPipeline defines the origin of variables from the environment of the pipeline.
+	envOriginPipeline = "pipeline"
+
+	// envOriginParameter defines the origin of variables from the parameters of the step.
+	envOriginParameter = "parameter"
+
+	// envOriginBuild defines the origin of variables injected by the compiler for the build.
+	envOriginBuild = "build"
+
+	// envOriginRepo defines the origin of variables injected by the compiler for the repo.
+	envOriginRepo = "repo"
+
+	// envOriginExecutor defines the origin of variables injected by the executor for the step.
+	envOriginExecutor = "executor"
+
+	// envOriginPlatform defines the origin of variables injected by the compiler for Vela.
+	envOriginPlatform = "platform"
+
+	// envRuntimeValue defines the value displayed for variables only known when the step runs.
+	envRuntimeValue = "<set at runtime>"
+
+	// envNotSetValue defines the value displayed for secrets not set in the local environment.
+	envNotSetValue = "<not set>"
+
+	// executorEnvWorker defines the version of the worker the
+	// variables injected by the executor are kept in sync with.
+	executorEnvWorker = "v0.28.0"
+)
+
+// envVar represents an environment variable
+// injected into a step with where it came from.
+type envVar struct {
+	Name   string `json:"name"   yaml:"name"`
+	Value  string `json:"value"  yaml:"value"`
+	Origin string `json:"origin" yaml:"origin"`
+}
+
+// ViewEnv displays the environment variables injected into a step
+// of a local pipeline, annotated with their origin, based off the
+// provided configuration.
+func (c *Config) ViewEnv(ctx context.Context, client compiler.Engine) error {
+	logrus.Debug("executing view env for local pipeline configuration")
+
+	base, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	// compile the pipeline for the simulated build
+	p, b, err := c.compileLocal(ctx, client, path, base, "")
+	if err != nil {
+		return err
+	}
+
+	stage, ctn, err := findContainer(p, c.Step)
+	if err != nil {
+		return err
+	}
+
+	logrus.Tracef("capturing user defined environment for step %s", ctn.Name)
+
+	// expand the pipeline to capture the environment defined by the user
+	expanded, _, err := c.expandLocal(ctx, client.Duplicate())
+	if err != nil {
+		return err
+	}
+
+	vars := stepEnv(expanded, stage, ctn, b, newMasker(collectSecretValues(p)))
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverDump:
+		// output the environment in dump format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Dump
+		return output.Dump(vars)
+	case output.DriverJSON:
+		// output the environment in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(vars, c.Color)
+	case output.DriverSpew:
+		// output the environment in spew format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Spew
+		return output.Spew(vars)
+	case output.DriverYAML:
+		// output the environment in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(vars, c.Color)
+	default:
+		// output the environment in table format
+		return envTable(vars)
+	}
+}
+
+// findContainer returns the stage and container of the compiled
+// pipeline for the step, which can be qualified by its stage
+// as `<stage>:<step>` when the name is used in many stages.
+func findContainer(p *pipeline.Build, name string) (string, *pipeline.Container, error) {
+	stageName, stepName, found := strings.Cut(name, ":")
+	if !found {
+		stageName, stepName = "", name
+	}
+
+	type match struct {
+		stage string
+		ctn   *pipeline.Container
+	}
+
+	matches := []match{}
+
+	for _, stage := range p.Stages {
+		if len(stageName) > 0 && stage.Name != stageName {
+			continue
+		}
+
+		for _, ctn := range stage.Steps {
+			if ctn.Name == stepName {
+				matches = append(matches, match{stage.Name, ctn})
+			}
+		}
+	}
+
+	if len(stageName) == 0 {
+		for _, ctn := range p.Steps {
+			if ctn.Name == stepName {
+				matches = append(matches, match{"", ctn})
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", nil, fmt.Errorf("step %s not found in pipeline, it may not run for the simulated build", name)
+	case 1:
+		return matches[0].stage, matches[0].ctn, nil
+	default:
+		return "", nil, fmt.Errorf("step %s found in multiple stages, use <stage>:<step> to select one", name)
+	}
+}
+
+// stepEnv creates the environment variables of the container, merging in
+// the variables injected by the executor and the secrets of the step, where
+// each variable is annotated with its origin and secret values are masked.
+func stepEnv(p *yaml.Build, stage string, ctn *pipeline.Container, b *api.Build, m *masker) []*envVar {
+	// capture the environment defined by the user for the step
+	var pipelineEnv, stageEnv, userEnv map[string]string
+
+	if p != nil {
+		pipelineEnv = p.Environment
+
+		steps := p.Steps
+
+		for _, s := range p.Stages {
+			if s.Name == stage {
+				stageEnv = s.Environment
+				steps = s.Steps
+			}
+		}
+
+		for _, s := range steps {
+			if s.Name == ctn.Name {
+				userEnv = s.Environment
+			}
+		}
+	}
+
+	vars := make(map[string]*envVar)
+
+	for name, value := range ctn.Environment {
+		origin := envOriginPlatform
+
+		switch {
+		case hasValue(userEnv, name, value):
+			origin = envOriginStep
+		case hasValue(stageEnv, name, value):
+			origin = envOriginStage
+		case hasValue(pipelineEnv, name, value):
+			origin = envOriginPipeline
+		case strings.HasPrefix(name, "PARAMETER_"):
+			origin = envOriginParameter
+		case strings.HasPrefix(name, "BUILD_"), strings.HasPrefix(name, "VELA_BUILD_"):
+			origin = envOriginBuild
+		case strings.HasPrefix(name, "REPOSITORY_"), strings.HasPrefix(name, "VELA_REPO_"):
+			origin = envOriginRepo
+		}
+
+		vars[name] = &envVar{Name: name, Value: m.Mask(value), Origin: origin}
+	}
+
+	// the executor overrides the variables for the step when it runs
+	for name, value := range executorEnv(stage, ctn, b) {
+		vars[name] = &envVar{Name: name, Value: value, Origin: envOriginExecutor}
+	}
+
+	// the secrets are provided from the local environment
+	for _, secret := range ctn.Secrets {
+		value := envNotSetValue
+
+		val, exists := os.LookupEnv(secret.Target)
+		if exists && len(val) > 0 {
+			value = maskReplacement
+		}
+
+		vars[secret.Target] = &envVar{
+			Name:   secret.Target,
+			Value:  value,
+			Origin: fmt.Sprintf("secret (%s)", secret.Source),
+		}
+	}
+
+	env := make([]*envVar, 0, len(vars))
+
+	for _, v := range vars {
+		env = append(env, v)
+	}
+
+	slices.SortFunc(env, func(x, y *envVar) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+
+	return env
+}
+
+// executorEnv creates the environment variables the local executor
+// injects into the container when the step runs for the build.
+//
+// The worker does not export how it creates these variables, so they
+// mirror the worker at executorEnvWorker and must be updated with it.
+func executorEnv(stage string, ctn *pipeline.Container, b *api.Build) map[string]string {
+	host, err := os.Hostname()
+	if err != nil {
+		logrus.Debugf("unable to capture hostname: %v", err)
+	}
+
+	env := map[string]string{
+		"VELA_DISTRIBUTION":       constants.DriverLocal,
+		"VELA_HOST":               host,
+		"VELA_RUNTIME":            constants.DriverDocker,
+		"VELA_VERSION":            version.New().Semantic(),
+		"VELA_BUILD_DISTRIBUTION": constants.DriverLocal,
+		"VELA_BUILD_HOST":         host,
+		"VELA_BUILD_RUNTIME":      constants.DriverDocker,
+		"VELA_BUILD_STATUS":       constants.StatusRunning,
+		"VELA_BUILD_STARTED":      envRuntimeValue,
+		"BUILD_HOST":              host,
+		"BUILD_STATUS":            constants.StatusRunning,
+		"BUILD_STARTED":           envRuntimeValue,
+		"VELA_STEP_CREATED":       envRuntimeValue,
+		"VELA_STEP_DISTRIBUTION":  constants.DriverLocal,
+		"VELA_STEP_HOST":          host,
+		"VELA_STEP_IMAGE":         ctn.Image,
+		"VELA_STEP_NAME":          ctn.Name,
+		"VELA_STEP_NUMBER":        strconv.Itoa(int(ctn.Number)),
+		"VELA_STEP_RUNTIME":       constants.DriverDocker,
+		"VELA_STEP_STAGE":         stage,
+		"VELA_STEP_STARTED":       envRuntimeValue,
+		"VELA_STEP_STATUS":        constants.StatusRunning,
+	}
+
+	// the build number is assigned when the build is created
+	if b.GetNumber() == 0 {
+		env["BUILD_NUMBER"] = envRuntimeValue
+		env["VELA_BUILD_NUMBER"] = envRuntimeValue
+	}
+
+	return env
+}
+
+// hasValue returns true if the map contains the key with the value,
+// so variables overridden by the compiler keep the origin of the compiler.
+func hasValue(m map[string]string, key, value string) bool {
+	v, ok := m[key]
+
+	return ok && v == value
+}
+
+// envTable is a helper function to output the
+// environment variables of a step in a table format.
+func envTable(vars []*envVar) error {
+	logrus.Debug("creating table for step environment")
+
+	// create a new table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for table to 80
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 80
+
+	// ensure the table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	// set of environment fields we display in a table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("NAME", "VALUE", "ORIGIN")
+
+	// iterate through all variables in the list
+	for _, v := range vars {
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(v.Name, v.Value, v.Origin)
+	}
+
+	// output the table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
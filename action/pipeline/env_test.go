@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/server/compiler/native"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/compiler/types/yaml"
+)
+
+func TestPipeline_Config_ViewEnv(t *testing.T) {
+	// setup types
+	cmd := new(cli.Command)
+	cmd.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone-image",
+			Value: "target/vela-git:latest",
+		},
+	}
+
+	client, err := native.FromCLICommand(t.Context(), cmd)
+	if err != nil {
+		t.Errorf("unable to create client: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+	}{
+		{
+			name:    "step",
+			failure: false,
+			config:  &Config{Action: "view", File: ".vela.yml", Path: "testdata/env", Org: "octocat", Repo: "hello-world", Event: "push", Branch: "main", Env: true, Step: "test"},
+		},
+		{
+			name:    "step with json output",
+			failure: false,
+			config:  &Config{Action: "view", File: ".vela.yml", Path: "testdata/env", Org: "octocat", Repo: "hello-world", Event: "push", Branch: "main", Env: true, Step: "publish", Output: "json"},
+		},
+		{
+			name:    "step skipped by ruleset",
+			failure: true,
+			config:  &Config{Action: "view", File: ".vela.yml", Path: "testdata/env", Org: "octocat", Repo: "hello-world", Event: "pull_request", Branch: "main", Env: true, Step: "publish"},
+		},
+		{
+			name:    "missing step",
+			failure: true,
+			config:  &Config{Action: "view", File: ".vela.yml", Path: "testdata/env", Org: "octocat", Repo: "hello-world", Event: "push", Branch: "main", Env: true, Step: "deploy"},
+		},
+		{
+			name:    "missing pipeline",
+			failure: true,
+			config:  &Config{Action: "view", File: "missing.yml", Path: "testdata/env", Env: true, Step: "test"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.ViewEnv(t.Context(), client)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("ViewEnv should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ViewEnv returned err: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_stepEnv(t *testing.T) {
+	// setup types
+	t.Setenv("DOCKER_PASSWORD", "superSecretPassword")
+	t.Setenv("DOCKER_USERNAME", "")
+
+	p := &yaml.Build{
+		Environment: map[string]string{"CI": "false", "GOPROXY": "https://proxy.golang.org"},
+		Steps: yaml.StepSlice{
+			{Name: "publish", Environment: map[string]string{"BUILD_BRANCH": "dev", "CGO_ENABLED": "0"}},
+		},
+	}
+
+	ctn := &pipeline.Container{
+		Name:   "publish",
+		Image:  "target/vela-kaniko:latest",
+		Number: 2,
+		Environment: map[string]string{
+			"BUILD_BRANCH":       "main",
+			"CGO_ENABLED":        "0",
+			"CI":                 "true",
+			"GOPROXY":            "https://proxy.golang.org",
+			"PARAMETER_REGISTRY": "index.docker.io",
+			"PARAMETER_TOKEN":    "superSecretPassword",
+			"REPOSITORY_ORG":     "octocat",
+			"VELA_HOST":          "localhost",
+		},
+		Secrets: pipeline.StepSecretSlice{
+			{Source: "docker_password", Target: "DOCKER_PASSWORD"},
+			{Source: "docker_username", Target: "DOCKER_USERNAME"},
+		},
+	}
+
+	want := map[string]envVar{
+		"BUILD_BRANCH":       {Value: "main", Origin: envOriginBuild},
+		"CGO_ENABLED":        {Value: "0", Origin: envOriginStep},
+		"CI":                 {Value: "true", Origin: envOriginPlatform},
+		"GOPROXY":            {Value: "https://proxy.golang.org", Origin: envOriginPipeline},
+		"PARAMETER_REGISTRY": {Value: "index.docker.io", Origin: envOriginParameter},
+		"PARAMETER_TOKEN":    {Value: maskReplacement, Origin: envOriginParameter},
+		"REPOSITORY_ORG":     {Value: "octocat", Origin: envOriginRepo},
+		"VELA_STEP_NAME":     {Value: "publish", Origin: envOriginExecutor},
+		"VELA_STEP_NUMBER":   {Value: "2", Origin: envOriginExecutor},
+		"DOCKER_PASSWORD":    {Value: maskReplacement, Origin: "secret (docker_password)"},
+		"DOCKER_USERNAME":    {Value: envNotSetValue, Origin: "secret (docker_username)"},
+	}
+
+	// run test
+	got := stepEnv(p, "", ctn, nil, newMasker([]string{"superSecretPassword"}))
+
+	vars := make(map[string]*envVar)
+
+	for i, v := range got {
+		if i > 0 && got[i-1].Name > v.Name {
+			t.Errorf("stepEnv is not sorted: %s before %s", got[i-1].Name, v.Name)
+		}
+
+		vars[v.Name] = v
+	}
+
+	for name, w := range want {
+		v, ok := vars[name]
+		if !ok {
+			t.Errorf("stepEnv is missing %s", name)
+
+			continue
+		}
+
+		if v.Value != w.Value || v.Origin != w.Origin {
+			t.Errorf("stepEnv %s is %s (%s), want %s (%s)", name, v.Value, v.Origin, w.Value, w.Origin)
+		}
+	}
+
+	if vars["VELA_HOST"].Origin != envOriginExecutor {
+		t.Errorf("stepEnv VELA_HOST origin is %s, want %s", vars["VELA_HOST"].Origin, envOriginExecutor)
+	}
+}
+
+func TestPipeline_executorEnv_worker(t *testing.T) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Skip("unable to read build info")
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != "github.com/go-vela/worker" {
+			continue
+		}
+
+		if dep.Version != executorEnvWorker {
+			t.Errorf("executorEnv mirrors worker %s, but worker %s is used, update executorEnv and executorEnvWorker", executorEnvWorker, dep.Version)
+		}
+
+		return
+	}
+
+	t.Errorf("unable to find worker in build info")
+}
@@ -4,7 +4,9 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -12,8 +14,10 @@ import (
 	"strings"
 	"syscall"
 
+	dockerclient "github.com/moby/moby/client"
 	"github.com/sirupsen/logrus"
 
+	"github.com/go-vela/cli/internal"
 	"github.com/go-vela/cli/version"
 	api "github.com/go-vela/server/api/types"
 	"github.com/go-vela/server/compiler"
@@ -21,18 +25,56 @@ import (
 	"github.com/go-vela/server/constants"
 	"github.com/go-vela/worker/executor"
 	"github.com/go-vela/worker/runtime"
+	"github.com/go-vela/worker/runtime/docker"
 )
 
 // Exec executes a pipeline based off the provided configuration.
-//
-//nolint:funlen // ignore function length
 func (c *Config) Exec(client compiler.Engine) error {
 	logrus.Debug("executing exec for pipeline configuration")
 
+	ctx, done := signalContext("pipeline exec canceled! cleaning up - you may see some errors during cleanup")
+	defer done()
+
+	// check if the pipeline should be re-run on file changes
+	if c.Watch {
+		return c.watch(ctx, client)
+	}
+
+	return c.run(ctx, client, nil)
+}
+
+// signalContext returns a context that is canceled, after
+// logging the provided message, when the process is
+// interrupted or terminated.
+func signalContext(msg string) (context.Context, context.CancelFunc) {
+	// create a background context
+	ctx, done := context.WithCancel(context.Background())
+
+	// handle aborting local build process
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+
+	// spawn go routine to wait for syscall signals
+	go func() {
+		// wait for signal
+		<-signalChan
+
+		logrus.Info(msg)
+
+		// cancel the context passed into build process
+		done()
+	}()
+
+	return ctx, done
+}
+
+// execPath returns the base directory mounted into the
+// pipeline and the full path to the pipeline file.
+func (c *Config) execPath() (string, string, error) {
 	// send Filesystem call to capture base directory path
 	base, err := os.Getwd()
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	// create full path for pipeline file
@@ -46,15 +88,270 @@ func (c *Config) Exec(client compiler.Engine) error {
 
 	path, err = validateFile(path)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	// check if full path to pipeline file exists
 	_, err = os.Stat(path)
 	if err != nil {
-		return fmt.Errorf("unable to find pipeline %s: %w", path, err)
+		return "", "", fmt.Errorf("unable to find pipeline %s: %w", path, err)
+	}
+
+	return base, path, nil
+}
+
+// run executes a single build of the pipeline, destroying the
+// build once it completes or the provided context is canceled.
+// The build is restricted to the steps matching the changed
+// files when requested for re-running the pipeline on changes.
+//
+//nolint:funlen // ignore function length
+func (c *Config) run(ctx context.Context, client compiler.Engine, changed []string) error {
+	base, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	// capture the directory used to read local git metadata
+	repoDir := filepath.Dir(path)
+
+	// create the directory mounted into the pipeline workspace
+	workspace := base
+
+	// check if the pipeline should run against a clean checkout
+	var room *cleanRoom
+
+	if c.CleanRoom {
+		room, err = c.setupCleanRoom(base)
+		if err != nil {
+			return err
+		}
+
+		// copy artifacts back and remove the clean room after the build is destroyed
+		defer room.teardown(base, c.ExportArtifacts)
+
+		workspace = room.workspace
+		path = room.pipelinePath(path)
+	}
+
+	// capture the commit exported into the clean room
+	commit := ""
+	if room != nil {
+		commit = room.commit
+	}
+
+	// compile the pipeline for executing locally
+	_pipeline, b, err := c.compileLocal(ctx, client, path, repoDir, commit)
+	if err != nil {
+		return err
+	}
+
+	// check if the pipeline is re-run on file changes
+	if len(changed) > 0 {
+		err = watchedSteps(_pipeline, changed)
+		if err != nil {
+			return err
+		}
+	}
+
+	// find all secrets that were not provided
+	missingSecrets := collectMissingSecrets(_pipeline)
+
+	// setup the runtime with the workspace mounted
+	_runtime, closeRuntime, err := c.setupRuntime(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	defer closeRuntime()
+
+	// create the context for the build, canceled once the
+	// build times out or when a step fails with fail fast
+	var (
+		buildCtx context.Context
+		cancel   context.CancelFunc
+	)
+
+	if c.Timeout > 0 {
+		buildCtx, cancel = context.WithTimeout(ctx, c.Timeout)
+	} else {
+		buildCtx, cancel = context.WithCancel(ctx)
+	}
+
+	defer cancel()
+
+	// sanitize the pipeline for the runtime
+	sanitized := _pipeline.Sanitize(constants.DriverDocker)
+
+	// enforce the step timeouts and fail fast for the build
+	limited, err := c.newLimitedRuntime(_runtime, sanitized, cancel)
+	if err != nil {
+		return err
+	}
+
+	logrus.Tracef("creating executor engine %s", constants.DriverLocal)
+
+	execSetup := &executor.Setup{
+		Driver:   constants.DriverLocal,
+		Runtime:  limited,
+		Pipeline: sanitized,
+		Build:    b,
+		Version:  version.New().Semantic(),
+	}
+
+	if len(c.OutputsImage) > 0 {
+		logrus.Debugf("using image %s for outputs container", c.OutputsImage)
+
+		outputsCtn := &pipeline.Container{
+			ID:          fmt.Sprintf("outputs_%s_%s", b.GetRepo().GetOrg(), b.GetRepo().GetName()),
+			Detach:      true,
+			Image:       c.OutputsImage,
+			Environment: make(map[string]string),
+			Pull:        constants.PullNotPresent,
+		}
+
+		execSetup.OutputCtn = outputsCtn
+	}
+
+	var (
+		out io.Writer = os.Stdout
+		m   *masker
+	)
+
+	// check if masking secrets in the build output was disabled
+	if !c.NoMask {
+		logrus.Debug("masking secret values in build output")
+
+		m = newMasker(collectSecretValues(_pipeline))
+
+		masked := newMaskWriter(m, os.Stdout)
+		defer masked.Close()
+
+		out = masked
+
+		// mask the log messages written to stderr during the build
+		stderr := logrus.StandardLogger().Out
+		logs := newMaskWriter(m, stderr)
+
+		logrus.SetOutput(logs)
+
+		defer func() {
+			logrus.SetOutput(stderr)
+			logs.Close()
+		}()
+	} else {
+		logrus.Warn("secret masking disabled - secret values may be printed in build output")
+	}
+
+	// pull the images for the build before it starts
+	if !c.NoPrepull {
+		err = c.prepullImages(buildCtx, _runtime, sanitized, out, execSetup.OutputCtn)
+		if err != nil {
+			return err
+		}
+	}
+
+	// capture the outputs written by each step, printing
+	// them through the masked output of the build
+	outputs := newOutputsRuntime(limited, sanitized, execSetup.OutputCtn, out)
+	execSetup.Runtime = outputs
+
+	var (
+		_executor executor.Engine
+		flush     = func() {}
+	)
+
+	if m != nil {
+		_executor, flush, err = newMaskedExecutor(execSetup, m, os.Stdout)
+	} else {
+		_executor, err = executor.New(execSetup)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// print any secrets not set to the user
+		reportMissingSecrets(missingSecrets)
+
+		// destroy the build with the executor
+		err = _executor.DestroyBuild(context.Background())
+		if err != nil {
+			logrus.Errorf("unable to destroy build: %v", err)
+		}
+
+		// write the remaining output of the executor
+		flush()
+	}()
+
+	// create the build with the executor
+	err = _executor.CreateBuild(buildCtx)
+	if err != nil {
+		return fmt.Errorf("unable to create build: %w", err)
+	}
+
+	// plan the build with the executor
+	err = _executor.PlanBuild(buildCtx)
+	if err != nil {
+		return fmt.Errorf("unable to plan build: %w", err)
+	}
+
+	// log/event streaming
+	go func() {
+		logrus.Debug("streaming build logs")
+		// start process to handle StreamRequests
+		// from Steps and Services
+		err = _executor.StreamBuild(buildCtx)
+		if err != nil {
+			logrus.Errorf("unable to stream build logs: %v", err)
+		}
+	}()
+
+	// assemble the build with the executor
+	err = _executor.AssembleBuild(buildCtx)
+	if err != nil {
+		return fmt.Errorf("unable to assemble build: %w", err)
+	}
+
+	// execute the build with the executor
+	err = _executor.ExecBuild(buildCtx)
+
+	// check if a failed step should be debugged before the build is destroyed
+	if c.DebugOnFailure {
+		dErr := c.debugFailure(ctx, _runtime, internal.DockerShell{}, sanitized, os.Stdin, os.Stdout, os.Stderr)
+		if dErr != nil {
+			logrus.Errorf("unable to debug failed step: %v", dErr)
+		}
+	}
+
+	// print the outputs captured for the build
+	rErr := c.reportOutputs(outputs, m)
+	if rErr != nil {
+		logrus.Errorf("unable to report build outputs: %v", rErr)
+	}
+
+	// check if the build was stopped by a failed step or the timeout
+	if step := limited.failedStep(); step != nil {
+		return fmt.Errorf("build canceled after step %s failed with exit code %d", limited.name(step), step.ExitCode)
+	}
+
+	if errors.Is(buildCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("build exceeded timeout of %s", c.Timeout)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to execute build: %w", err)
 	}
 
+	return nil
+}
+
+// compileLocal creates the build and compiles the pipeline file at
+// the provided path for executing locally. The build is populated
+// with metadata from the git repository in repoDir and, when
+// provided, the commit overrides the commit from the repository.
+func (c *Config) compileLocal(ctx context.Context, client compiler.Engine, path, repoDir, commit string) (*pipeline.Build, *api.Build, error) {
 	// create build object for use in pipeline
 	b := new(api.Build)
 	b.SetBranch(c.Branch)
@@ -81,7 +378,7 @@ func (c *Config) Exec(client compiler.Engine) error {
 			b.SetEvent(constants.EventDeploy)
 			b.SetEventAction(constants.ActionCreated)
 		case constants.EventDelete:
-			return fmt.Errorf("event %s must supply an action (branch or tag)", c.Event)
+			return nil, nil, fmt.Errorf("event %s must supply an action (branch or tag)", c.Event)
 		}
 	}
 
@@ -91,6 +388,20 @@ func (c *Config) Exec(client compiler.Engine) error {
 		b.SetRef(c.Tag)
 	}
 
+	// populate the build with metadata from the local git repository
+	setGitMetadata(b, repoDir, c.ChangesetFromGit)
+
+	// use the provided commit, such as the one exported into a clean room
+	if len(commit) > 0 {
+		b.SetCommit(commit)
+	}
+
+	// capture the file changeset from the local git repository
+	err := c.loadGitChangeset(repoDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// create repo object for use in pipeline
 	r := new(api.Repo)
 	r.SetOrg(c.Org)
@@ -102,6 +413,18 @@ func (c *Config) Exec(client compiler.Engine) error {
 
 	logrus.Tracef("compiling pipeline %s", path)
 
+	// pin the remote templates to the commits in the lockfile
+	source, err := c.lockedPipeline(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// add the remote templates from the template cache
+	templateFiles, err := c.cachedTemplates(ctx, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// compile into a pipeline
 	_pipeline, _, err := client.
 		Duplicate().
@@ -110,10 +433,10 @@ func (c *Config) Exec(client compiler.Engine) error {
 		WithFiles(c.FileChangeset).
 		WithLocal(true).
 		WithRepo(r).
-		WithLocalTemplates(c.TemplateFiles).
-		Compile(context.Background(), path)
+		WithLocalTemplates(templateFiles).
+		Compile(ctx, source)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	_pipeline.Prepare(b.GetRepo().GetOrg(), b.GetRepo().GetName(), b.GetNumber(), true)
@@ -128,128 +451,102 @@ func (c *Config) Exec(client compiler.Engine) error {
 		}
 
 		if err := skipSteps(_pipeline, stepsToRemove); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
-	// find all secrets that were not provided
-	missingSecrets := collectMissingSecrets(_pipeline)
+	return _pipeline, b, nil
+}
 
-	// create current directory path for local mount
-	mount := fmt.Sprintf("%s:%s:rw", base, constants.WorkspaceDefault)
+// setupRuntime creates the runtime for executing the pipeline locally
+// with the workspace, volumes and caches mounted into every container,
+// returning a function to close the runtime once the build is destroyed.
+func (c *Config) setupRuntime(ctx context.Context, workspace string) (runtime.Engine, func(), error) {
+	// create workspace directory path for local mount
+	mount := fmt.Sprintf("%s:%s:rw", workspace, constants.WorkspaceDefault)
 
 	// add the current directory path to volume mounts
-	c.Volumes = append(c.Volumes, mount)
-
-	logrus.Tracef("creating runtime engine %s", constants.DriverDocker)
+	volumes := append(slices.Clone(c.Volumes), mount)
 
-	// setup the runtime
-	//
-	// https://pkg.go.dev/github.com/go-vela/worker/runtime?tab=doc#New
-	_runtime, err := runtime.New(&runtime.Setup{
-		Driver:           constants.DriverDocker,
-		HostVolumes:      c.Volumes,
-		PrivilegedImages: c.PrivilegedImages,
-	})
+	// create the caches mounted into every container
+	caches, err := c.mountCaches(ctx)
 	if err != nil {
-		return err
-	}
-
-	logrus.Tracef("creating executor engine %s", constants.DriverLocal)
-
-	execSetup := &executor.Setup{
-		Driver:   constants.DriverLocal,
-		Runtime:  _runtime,
-		Pipeline: _pipeline.Sanitize(constants.DriverDocker),
-		Build:    b,
-		Version:  version.New().Semantic(),
-	}
-
-	if len(c.OutputsImage) > 0 {
-		logrus.Debugf("using image %s for outputs container", c.OutputsImage)
-
-		outputsCtn := &pipeline.Container{
-			ID:          fmt.Sprintf("outputs_%s_%s", r.GetOrg(), r.GetName()),
-			Detach:      true,
-			Image:       c.OutputsImage,
-			Environment: make(map[string]string),
-			Pull:        constants.PullNotPresent,
-		}
-
-		execSetup.OutputCtn = outputsCtn
+		return nil, nil, err
 	}
 
-	_executor, err := executor.New(execSetup)
+	// capture the resource limits of every container
+	nanoCPUs, memory, err := parseLimits(c.CPUs, c.Memory)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	// create a background context
-	ctx, done := context.WithCancel(context.Background())
-	defer done()
-
-	// handle aborting local build process
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
-
-	// spawn go routine to wait for syscall signals
-	go func() {
-		// wait for signal
-		<-signalChan
-
-		logrus.Info("pipeline exec canceled! cleaning up - you may see some errors during cleanup")
-
-		// cancel the context passed into build process
-		done()
-	}()
+	setup := &runtime.Setup{
+		Driver:           constants.DriverDocker,
+		HostVolumes:      volumes,
+		PrivilegedImages: c.PrivilegedImages,
+	}
 
-	defer func() {
-		// print any secrets not set to the user
-		reportMissingSecrets(missingSecrets)
+	logrus.Tracef("creating runtime engine %s", constants.DriverDocker)
 
-		// destroy the build with the executor
-		err = _executor.DestroyBuild(context.Background())
+	if len(caches) == 0 && nanoCPUs == 0 && memory == 0 {
+		// setup the runtime
+		//
+		// https://pkg.go.dev/github.com/go-vela/worker/runtime?tab=doc#New
+		_runtime, err := runtime.New(setup)
 		if err != nil {
-			logrus.Errorf("unable to destroy build: %v", err)
+			return nil, nil, err
 		}
-	}()
 
-	// create the build with the executor
-	err = _executor.CreateBuild(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to create build: %w", err)
+		return _runtime, func() {}, nil
 	}
 
-	// plan the build with the executor
-	err = _executor.PlanBuild(ctx)
+	// the runtime only mounts directories from the host and does not
+	// limit resources, so the volumes of the caches and the limits are
+	// added to the containers it creates, before they start, through a
+	// proxy to the Docker daemon
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#NewDockerProxy
+	proxy, err := internal.NewDockerProxy(&internal.DockerCreateOptions{
+		Volumes:  caches,
+		NanoCPUs: nanoCPUs,
+		Memory:   memory,
+	})
 	if err != nil {
-		return fmt.Errorf("unable to plan build: %w", err)
+		return nil, nil, err
 	}
 
-	// log/event streaming
-	go func() {
-		logrus.Debug("streaming build logs")
-		// start process to handle StreamRequests
-		// from Steps and Services
-		err = _executor.StreamBuild(ctx)
+	closeProxy := func() {
+		err := proxy.Close()
 		if err != nil {
-			logrus.Errorf("unable to stream build logs: %v", err)
+			logrus.Debugf("unable to close docker proxy: %v", err)
 		}
-	}()
+	}
 
-	// assemble the build with the executor
-	err = _executor.AssembleBuild(ctx)
+	// setup the runtime
+	//
+	// https://pkg.go.dev/github.com/go-vela/worker/runtime/docker#New
+	_runtime, err := docker.New(
+		docker.WithHostVolumes(setup.HostVolumes),
+		docker.WithPrivilegedImages(setup.PrivilegedImages),
+	)
 	if err != nil {
-		return fmt.Errorf("unable to assemble build: %w", err)
+		closeProxy()
+
+		return nil, nil, err
 	}
 
-	// execute the build with the executor
-	err = _executor.ExecBuild(ctx)
+	// replace the Docker client of the runtime with a client of the
+	// proxy, so only the containers of the runtime use the proxy
+	//
+	// https://pkg.go.dev/github.com/moby/moby/client#New
+	_runtime.Docker, err = dockerclient.New(dockerclient.WithHost(proxy.Host))
 	if err != nil {
-		return fmt.Errorf("unable to execute build: %w", err)
+		closeProxy()
+
+		return nil, nil, err
 	}
 
-	return nil
+	return _runtime, closeProxy, nil
 }
 
 // reportMissingSecrets informs the user of any secrets not set.
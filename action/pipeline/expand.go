@@ -10,6 +10,9 @@ import (
 
 	"github.com/go-vela/cli/internal/output"
 	"github.com/go-vela/sdk-go/vela"
+	api "github.com/go-vela/server/api/types"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/types/yaml"
 )
 
 // Expand expands a pipeline based off the provided configuration.
@@ -62,3 +65,47 @@ func (c *Config) Expand(ctx context.Context, client *vela.Client) error {
 		return output.Stdout(pipeline)
 	}
 }
+
+// expandRemote expands the pipeline of the repository
+// for the provided ref with the server.
+func (c *Config) expandRemote(ctx context.Context, client *vela.Client, ref string) (*yaml.Build, error) {
+	logrus.Tracef("expanding pipeline %s/%s@%s", c.Org, c.Repo, ref)
+
+	// set the pipeline options for the call
+	//
+	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#PipelineOptions
+	opts := &vela.PipelineOptions{
+		Output: output.DriverJSON,
+	}
+
+	// send API call to expand a pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#PipelineService.Expand
+	pipeline, _, err := client.Pipeline.Expand(ctx, c.Org, c.Repo, ref, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline, nil
+}
+
+// expandLocal expands the templates of the local pipeline, without
+// ruledata to keep every step, and returns it with the path to the file.
+func (c *Config) expandLocal(ctx context.Context, client compiler.Engine) (*yaml.Build, string, error) {
+	_, path, err := c.execPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	logrus.Tracef("expanding local pipeline %s", path)
+
+	// set pipelineType within client
+	client.WithRepo(&api.Repo{PipelineType: &c.PipelineType})
+
+	pipeline, _, err := client.CompileLite(ctx, path, nil, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pipeline, path, nil
+}
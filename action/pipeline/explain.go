@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/compiler/types/yaml"
+	"github.com/go-vela/server/constants"
+)
+
+// scenario represents a build used to explain
+// which steps of a pipeline run.
+type scenario struct {
+	Name string
+	Data *pipeline.RuleData
+}
+
+// explainStep represents a step of a pipeline
+// and the results for each scenario.
+type explainStep struct {
+	Name    string
+	Results []string
+}
+
+// explain evaluates the ruleset of every step in the pipeline against
+// a matrix of events and the provided branches, tags and targets and
+// outputs which stages and steps run, and the rules that failed, for
+// each of them.
+func (c *Config) explain(p *yaml.Build) error {
+	logrus.Debug("explaining rulesets for pipeline")
+
+	scenarios := c.scenarios()
+
+	steps := []*explainStep{}
+
+	for _, stage := range p.Stages {
+		stageSteps := []*explainStep{}
+
+		for _, step := range stage.Steps {
+			stageSteps = append(stageSteps, explainRuleset(fmt.Sprintf("%s:%s", stage.Name, step.Name), step, scenarios))
+		}
+
+		steps = append(steps, explainStage(stage.Name, stageSteps, len(scenarios)))
+		steps = append(steps, stageSteps...)
+	}
+
+	for _, step := range p.Steps {
+		steps = append(steps, explainRuleset(step.Name, step, scenarios))
+	}
+
+	return explainTable(scenarios, steps)
+}
+
+// scenarios returns the builds, for every event and each of the
+// provided branches, tags and targets, used to explain a pipeline.
+func (c *Config) scenarios() []*scenario {
+	branches := splitValues(c.Branch, "main")
+	tags := splitValues(strings.TrimPrefix(c.Tag, "refs/tags/"), "v0.0.0")
+	targets := splitValues(c.Target, "production")
+
+	status := c.Status
+	if len(status) == 0 {
+		status = constants.StatusSuccess
+	}
+
+	repo := ""
+	if len(c.Org) > 0 && len(c.Repo) > 0 {
+		repo = fmt.Sprintf("%s/%s", c.Org, c.Repo)
+	}
+
+	scenarios := []*scenario{}
+
+	add := func(event, branch, tag, target string) {
+		name := fmt.Sprintf("%s (%s)", event, branch)
+
+		switch {
+		case len(tag) > 0:
+			name = fmt.Sprintf("%s (%s)", event, tag)
+		case len(target) > 0:
+			name = fmt.Sprintf("%s (%s)", event, target)
+		}
+
+		scenarios = append(scenarios, &scenario{
+			Name: name,
+			Data: &pipeline.RuleData{
+				Branch:  branch,
+				Comment: c.Comment,
+				Event:   event,
+				Path:    c.FileChangeset,
+				Repo:    repo,
+				Status:  status,
+				Tag:     tag,
+				Target:  target,
+			},
+		})
+	}
+
+	for _, branch := range branches {
+		add(constants.EventPush, branch, "", "")
+		add(fmt.Sprintf("%s:%s", constants.EventPull, constants.ActionOpened), branch, "", "")
+		add(fmt.Sprintf("%s:%s", constants.EventPull, constants.ActionSynchronize), branch, "", "")
+		add(fmt.Sprintf("%s:%s", constants.EventComment, constants.ActionCreated), branch, "", "")
+		add(constants.EventSchedule, branch, "", "")
+		add(fmt.Sprintf("%s:%s", constants.EventDelete, constants.ActionBranch), branch, "", "")
+	}
+
+	for _, tag := range tags {
+		add(constants.EventTag, branches[0], tag, "")
+		add(fmt.Sprintf("%s:%s", constants.EventDelete, constants.ActionTag), branches[0], tag, "")
+	}
+
+	for _, target := range targets {
+		add(fmt.Sprintf("%s:%s", constants.EventDeploy, constants.ActionCreated), branches[0], "", target)
+	}
+
+	return scenarios
+}
+
+// explainRuleset evaluates the ruleset of the step against each of the
+// scenarios, naming the rules that failed for the skipped scenarios.
+func explainRuleset(name string, step *yaml.Step, scenarios []*scenario) *explainStep {
+	result := &explainStep{Name: name}
+
+	// https://pkg.go.dev/github.com/go-vela/server/compiler/types/yaml?tab=doc#Ruleset.ToPipeline
+	ruleset := step.Ruleset.ToPipeline()
+
+	for _, s := range scenarios {
+		// https://pkg.go.dev/github.com/go-vela/server/compiler/types/pipeline?tab=doc#RuleData.Match
+		match, err := ruleData(s.Data, step.Environment).Match(*ruleset)
+		if err != nil {
+			result.Results = append(result.Results, fmt.Sprintf("error: %v", err))
+
+			continue
+		}
+
+		if match {
+			result.Results = append(result.Results, "run")
+
+			continue
+		}
+
+		result.Results = append(result.Results, fmt.Sprintf("skip (%s)", failedRules(ruleset, s.Data, step.Environment)))
+	}
+
+	return result
+}
+
+// explainStage returns the results for a stage, which runs for a
+// scenario when any of its steps run and is skipped otherwise.
+func explainStage(name string, steps []*explainStep, scenarios int) *explainStep {
+	result := &explainStep{Name: name}
+
+	for i := range scenarios {
+		run := slices.ContainsFunc(steps, func(step *explainStep) bool {
+			return step.Results[i] == "run"
+		})
+
+		if run {
+			result.Results = append(result.Results, "run")
+
+			continue
+		}
+
+		result.Results = append(result.Results, "skip (all steps skipped)")
+	}
+
+	return result
+}
+
+// failedRules returns the rules, of the provided ruleset, that
+// caused a step to be skipped for the provided build, evaluating
+// each of the rules on its own with the matching of the compiler.
+func failedRules(r *pipeline.Ruleset, data *pipeline.RuleData, envs map[string]string) string {
+	fields := []struct {
+		name  string
+		rules func(pipeline.Rules) pipeline.Rules
+	}{
+		{"branch", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Branch: r.Branch} }},
+		{"comment", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Comment: r.Comment} }},
+		{"event", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Event: r.Event} }},
+		{"path", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Path: r.Path} }},
+		{"repo", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Repo: r.Repo} }},
+		{"sender", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Sender: r.Sender} }},
+		{"status", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Status: r.Status} }},
+		{"tag", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Tag: r.Tag} }},
+		{"target", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Target: r.Target} }},
+		{"label", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Label: r.Label} }},
+		{"instance", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Instance: r.Instance} }},
+		{"eval", func(r pipeline.Rules) pipeline.Rules { return pipeline.Rules{Eval: r.Eval} }},
+	}
+
+	// match returns true when the single rule matches the build
+	match := func(rules pipeline.Rules, from pipeline.Rules) bool {
+		rules.Matcher = from.Matcher
+
+		// https://pkg.go.dev/github.com/go-vela/server/compiler/types/pipeline?tab=doc#RuleData.MatchRules
+		ok, err := ruleData(data, envs).MatchRules(rules)
+
+		return err == nil && ok
+	}
+
+	failed := []string{}
+
+	for _, field := range fields {
+		if rules := field.rules(r.If); !rules.Empty() && !match(rules, r.If) {
+			failed = append(failed, field.name)
+		}
+	}
+
+	for _, field := range fields {
+		if rules := field.rules(r.Unless); !rules.Empty() && match(rules, r.Unless) {
+			failed = append(failed, "unless "+field.name)
+		}
+	}
+
+	if len(failed) == 0 {
+		return "ruleset"
+	}
+
+	return strings.Join(failed, ", ")
+}
+
+// ruleData returns a copy of the ruledata with the environment of the
+// step, since the compiler updates the ruledata while matching it.
+func ruleData(data *pipeline.RuleData, envs map[string]string) *pipeline.RuleData {
+	d := *data
+	d.Env = envs
+
+	return &d
+}
+
+// splitValues returns the comma separated values,
+// or the provided default when none are provided.
+func splitValues(s, def string) []string {
+	values := []string{}
+
+	for value := range strings.SplitSeq(s, ",") {
+		value = strings.TrimSpace(value)
+		if len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return []string{def}
+	}
+
+	return values
+}
+
+// explainTable is a helper function to output the
+// results for the steps of a pipeline in a table.
+func explainTable(scenarios []*scenario, steps []*explainStep) error {
+	logrus.Debug("creating table for pipeline ruleset explanation")
+
+	// create a new table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for table to 30
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 30
+
+	// ensure the table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	header := []any{"STEP"}
+
+	for _, s := range scenarios {
+		header = append(header, strings.ToUpper(s.Name))
+	}
+
+	// set of scenarios we display in a table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow(header...)
+
+	// iterate through all steps in the list
+	for _, step := range steps {
+		row := []any{step.Name}
+
+		for _, result := range step.Results {
+			row = append(row, result)
+		}
+
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(row...)
+	}
+
+	// output the table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/compiler/types/yaml"
+)
+
+func TestPipeline_Config_scenarios(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		config *Config
+		want   []string
+	}{
+		{
+			name:   "defaults",
+			config: &Config{},
+			want: []string{
+				"push (main)",
+				"pull_request:opened (main)",
+				"pull_request:synchronize (main)",
+				"comment:created (main)",
+				"schedule (main)",
+				"delete:branch (main)",
+				"tag (v0.0.0)",
+				"delete:tag (v0.0.0)",
+				"deployment:created (production)",
+			},
+		},
+		{
+			name:   "branches, tags and targets",
+			config: &Config{Branch: "main, dev", Tag: "refs/tags/v1.0.0", Target: "staging"},
+			want: []string{
+				"push (main)",
+				"pull_request:opened (main)",
+				"pull_request:synchronize (main)",
+				"comment:created (main)",
+				"schedule (main)",
+				"delete:branch (main)",
+				"push (dev)",
+				"pull_request:opened (dev)",
+				"pull_request:synchronize (dev)",
+				"comment:created (dev)",
+				"schedule (dev)",
+				"delete:branch (dev)",
+				"tag (v1.0.0)",
+				"delete:tag (v1.0.0)",
+				"deployment:created (staging)",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := []string{}
+
+			for _, s := range test.config.scenarios() {
+				got = append(got, s.Name)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("scenarios is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_explainRuleset(t *testing.T) {
+	// setup types
+	scenarios := (&Config{Branch: "main,dev", Tag: "v1.0.0"}).scenarios()
+
+	step := &yaml.Step{
+		Name: "publish",
+		Ruleset: yaml.Ruleset{
+			If: yaml.Rules{
+				Branch: []string{"main"},
+				Event:  []string{"push"},
+			},
+		},
+	}
+
+	want := []string{
+		"run",
+		"skip (event)",
+		"skip (event)",
+		"skip (event)",
+		"skip (event)",
+		"skip (event)",
+		"skip (branch)",
+		"skip (branch, event)",
+		"skip (branch, event)",
+		"skip (branch, event)",
+		"skip (branch, event)",
+		"skip (branch, event)",
+		"skip (event)",
+		"skip (event)",
+		"skip (event)",
+	}
+
+	// run test
+	got := explainRuleset("publish", step, scenarios)
+
+	if got.Name != "publish" {
+		t.Errorf("explainRuleset name is %s, want publish", got.Name)
+	}
+
+	if !reflect.DeepEqual(got.Results, want) {
+		t.Errorf("explainRuleset is %v, want %v", got.Results, want)
+	}
+}
+
+func TestPipeline_explainStage(t *testing.T) {
+	// setup types
+	steps := []*explainStep{
+		{Name: "test:unit", Results: []string{"run", "skip (event)", "skip (branch)"}},
+		{Name: "test:lint", Results: []string{"skip (event)", "run", "skip (branch)"}},
+	}
+
+	want := []string{"run", "run", "skip (all steps skipped)"}
+
+	// run test
+	got := explainStage("test", steps, 3)
+
+	if got.Name != "test" {
+		t.Errorf("explainStage name is %s, want test", got.Name)
+	}
+
+	if !reflect.DeepEqual(got.Results, want) {
+		t.Errorf("explainStage is %v, want %v", got.Results, want)
+	}
+}
+
+func TestPipeline_failedRules(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		ruleset *pipeline.Ruleset
+		data    *pipeline.RuleData
+		want    string
+	}{
+		{
+			name: "branch",
+			ruleset: &pipeline.Ruleset{
+				If: pipeline.Rules{Branch: []string{"main", "release/*"}, Event: []string{"push"}},
+			},
+			data: &pipeline.RuleData{Branch: "dev", Event: "push"},
+			want: "branch",
+		},
+		{
+			name: "regexp tag",
+			ruleset: &pipeline.Ruleset{
+				If:      pipeline.Rules{Tag: []string{`^v[0-9]+\.[0-9]+\.[0-9]+$`}},
+				Matcher: "regexp",
+			},
+			data: &pipeline.RuleData{Event: "tag", Tag: "v1.0.0-rc1"},
+			want: "tag",
+		},
+		{
+			name: "unless path",
+			ruleset: &pipeline.Ruleset{
+				Unless: pipeline.Rules{Path: []string{"docs/*"}},
+			},
+			data: &pipeline.RuleData{Event: "push", Path: []string{"main.go", "docs/README.md"}},
+			want: "unless path",
+		},
+		{
+			name: "or operator",
+			ruleset: &pipeline.Ruleset{
+				If:       pipeline.Rules{Branch: []string{"dev"}, Event: []string{"pull_request:opened"}},
+				Operator: "or",
+			},
+			data: &pipeline.RuleData{Branch: "main", Event: "pull_request:opened"},
+			want: "branch",
+		},
+		{
+			name:    "unknown",
+			ruleset: &pipeline.Ruleset{},
+			data:    &pipeline.RuleData{Event: "push"},
+			want:    "ruleset",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := failedRules(test.ruleset, test.data, nil)
+
+			if got != test.want {
+				t.Errorf("failedRules is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_splitValues(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "main", want: []string{"main"}},
+		{value: "main, dev,,release", want: []string{"main", "dev", "release"}},
+		{value: "", want: []string{"default"}},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := splitValues(test.value, "default")
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("splitValues is %v, want %v", got, test.want)
+		}
+	}
+}
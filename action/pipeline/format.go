@@ -0,0 +1,450 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// formatUnknown is the position of the keys
+// without a canonical order in a mapping.
+const formatUnknown = "*"
+
+// formatRootKeys is the canonical order for the keys of a pipeline.
+var formatRootKeys = []string{
+	"version", "metadata", "worker", "git", "environment", formatUnknown,
+	"deployment", "templates", "secrets", "services", "stages", "steps",
+}
+
+// formatStageKeys is the canonical order for the keys of a stage.
+var formatStageKeys = []string{
+	"<<", "name", "needs", "independent", "environment", formatUnknown, "steps",
+}
+
+// formatStepKeys is the canonical order for the keys of a step or service.
+var formatStepKeys = []string{
+	"<<", "name", "template", "image", "pull", "detach", "privileged", "user",
+	"entrypoint", "ports", "volumes", "ulimits", "environment", "secrets",
+	"parameters", "commands", formatUnknown, "ruleset", "report_as", "id_request",
+}
+
+// formatRulesetKeys is the canonical order for the keys of a ruleset.
+var formatRulesetKeys = []string{
+	"<<", "if", "unless", "branch", "event", "tag", "target", "path", "comment",
+	"repo", "sender", "status", "label", "instance", formatUnknown,
+	"matcher", "operator", "continue",
+}
+
+// Format formats a local pipeline into the canonical
+// format based off the provided configuration.
+func (c *Config) Format() error {
+	logrus.Debug("executing format for local pipeline configuration")
+
+	_, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := format(data)
+	if err != nil {
+		return fmt.Errorf("unable to format %s: %w", path, err)
+	}
+
+	switch {
+	case c.Check:
+		if bytes.Equal(data, formatted) {
+			// output the message in stderr format
+			//
+			// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+			return output.Stderr(fmt.Sprintf("%s is formatted", path))
+		}
+
+		diff := internal.Diff(string(data), string(formatted), path, path+" (formatted)")
+
+		// output the diff in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		err = output.Stdout(strings.TrimSuffix(diff, "\n"))
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%s is not formatted", path)
+	case c.Write:
+		if bytes.Equal(data, formatted) {
+			logrus.Debugf("%s is already formatted", path)
+
+			return nil
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path, formatted, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+
+		// output the message in stderr format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+		return output.Stderr(fmt.Sprintf("%s formatted", path))
+	default:
+		// output the formatted pipeline in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(strings.TrimSuffix(string(formatted), "\n"))
+	}
+}
+
+// format returns the provided pipeline in the canonical format
+// while preserving the comments of the pipeline.
+func format(data []byte) ([]byte, error) {
+	for _, order := range []bool{true, false} {
+		out, err := formatNodes(data, order)
+		if err != nil {
+			return nil, err
+		}
+
+		// ensure anchors are still defined before their aliases
+		err = yaml.Unmarshal(out, new(yaml.Node))
+		if err == nil {
+			return out, nil
+		}
+
+		logrus.Debugf("unable to format pipeline with canonical order %t: %v", order, err)
+	}
+
+	return nil, fmt.Errorf("unable to preserve the anchors of the pipeline")
+}
+
+// formatNodes formats the nodes of the provided pipeline,
+// with the canonical order of the keys when requested.
+func formatNodes(data []byte, order bool) ([]byte, error) {
+	root := new(yaml.Node)
+
+	err := yaml.Unmarshal(data, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil, fmt.Errorf("pipeline is empty")
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("pipeline is not a mapping")
+	}
+
+	formatStyle(root)
+
+	for _, step := range formatSequence(yamlMapValue(doc, "steps")) {
+		formatStep(step, order)
+	}
+
+	for _, service := range formatSequence(yamlMapValue(doc, "services")) {
+		formatStep(service, order)
+	}
+
+	stages := yamlMapValue(doc, "stages")
+	if stages != nil && stages.Kind == yaml.MappingNode {
+		for i := 1; i < len(stages.Content); i += 2 {
+			stage := stages.Content[i]
+
+			for _, step := range formatSequence(yamlMapValue(stage, "steps")) {
+				formatStep(step, order)
+			}
+
+			if order {
+				formatKeys(stage, formatStageKeys)
+			}
+		}
+	}
+
+	if order {
+		formatKeys(doc, formatRootKeys)
+	}
+
+	buf := new(bytes.Buffer)
+
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	err = enc.Encode(root)
+	if err != nil {
+		return nil, err
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return formatSpacing(buf.Bytes()), nil
+}
+
+// formatStyle removes the quotes from scalars that do not need them,
+// the encoder quotes the scalars that would change type without them.
+func formatStyle(node *yaml.Node) {
+	if node == nil || node.Kind == yaml.AliasNode {
+		return
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		node.Style &^= yaml.SingleQuotedStyle | yaml.DoubleQuotedStyle
+
+		// avoid the encoder adding an explicit tag to merge keys
+		if node.Tag == "!!merge" {
+			node.Tag = ""
+		}
+
+		return
+	}
+
+	for _, child := range node.Content {
+		formatStyle(child)
+	}
+}
+
+// formatStep formats the keys and ruleset of a step or service.
+func formatStep(step *yaml.Node, order bool) {
+	if step.Kind != yaml.MappingNode {
+		return
+	}
+
+	_, ruleset := yamlMapPair(step, "ruleset")
+	if ruleset != nil && ruleset.Kind == yaml.MappingNode {
+		formatRuleset(ruleset, order)
+	}
+
+	if order {
+		formatKeys(step, formatStepKeys)
+	}
+}
+
+// formatRuleset normalizes a ruleset that only contains an if
+// condition into the shorthand for the ruleset and normalizes
+// the values of the rules.
+func formatRuleset(ruleset *yaml.Node, order bool) {
+	shorthand := false
+
+	for i := 0; i < len(ruleset.Content); i += 2 {
+		if slices.Contains(lintRulesetFields, ruleset.Content[i].Value) {
+			shorthand = true
+		}
+	}
+
+	when := yamlMapValue(ruleset, "if")
+	unless := yamlMapValue(ruleset, "unless")
+
+	// collapse the if condition into the shorthand for the ruleset
+	_, raw := formatPair(ruleset, "if")
+
+	if !shorthand && unless == nil && raw != nil && raw.Kind == yaml.MappingNode && len(raw.Anchor) == 0 && len(raw.Content) > 0 {
+		content := []*yaml.Node{}
+
+		for i := 0; i < len(ruleset.Content); i += 2 {
+			key := ruleset.Content[i]
+
+			if key.Value != "if" {
+				content = append(content, key, ruleset.Content[i+1])
+
+				continue
+			}
+
+			// keep the comments of the if condition
+			first := raw.Content[0]
+			first.HeadComment = strings.TrimSpace(key.HeadComment + "\n" + first.HeadComment)
+
+			content = append(content, raw.Content...)
+		}
+
+		ruleset.Content = content
+		when = nil
+	}
+
+	for _, rules := range []*yaml.Node{ruleset, when, unless} {
+		if rules == nil || rules.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(rules.Content); i += 2 {
+			if slices.Contains(lintRulesetFields, rules.Content[i].Value) {
+				rules.Content[i+1] = formatRule(rules.Content[i+1])
+			}
+		}
+
+		if order {
+			formatKeys(rules, formatRulesetKeys)
+		}
+	}
+}
+
+// formatRule normalizes the values of a rule to a scalar for a
+// single value or a flow sequence for multiple values.
+func formatRule(rule *yaml.Node) *yaml.Node {
+	if rule.Kind != yaml.SequenceNode || len(rule.Anchor) > 0 {
+		return rule
+	}
+
+	if len(rule.Content) == 1 && rule.Content[0].Kind == yaml.ScalarNode {
+		value := rule.Content[0]
+
+		value.HeadComment = strings.TrimSpace(rule.HeadComment + "\n" + value.HeadComment)
+		value.LineComment = strings.TrimSpace(rule.LineComment + " " + value.LineComment)
+		value.FootComment = strings.TrimSpace(value.FootComment + "\n" + rule.FootComment)
+
+		return value
+	}
+
+	// keep block sequences that contain comments for the values
+	for _, value := range rule.Content {
+		if len(value.HeadComment) > 0 || len(value.LineComment) > 0 || len(value.FootComment) > 0 {
+			return rule
+		}
+	}
+
+	rule.Style = yaml.FlowStyle
+
+	return rule
+}
+
+// formatKeys sorts the keys of the mapping into the provided order.
+func formatKeys(node *yaml.Node, order []string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	unknown := slices.Index(order, formatUnknown)
+	if unknown < 0 {
+		unknown = len(order)
+	}
+
+	rank := func(key string) int {
+		if i := slices.Index(order, key); i >= 0 {
+			return i
+		}
+
+		return unknown
+	}
+
+	pairs := [][]*yaml.Node{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, node.Content[i:i+2])
+	}
+
+	slices.SortStableFunc(pairs, func(a, b []*yaml.Node) int {
+		return rank(a[0].Value) - rank(b[0].Value)
+	})
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+
+	for _, pair := range pairs {
+		content = append(content, pair...)
+	}
+
+	node.Content = content
+}
+
+// formatPair returns the key and value nodes for the key of
+// the mapping node without resolving aliases or merged mappings.
+func formatPair(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// formatSequence returns the items of the sequence node.
+func formatSequence(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+// formatSpacing separates the keys of the pipeline, the items of
+// the top level sequences and the stages with an empty line.
+func formatSpacing(data []byte) []byte {
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+
+	result := []string{}
+
+	root := ""
+	stageSteps := false
+	firstStage, firstItem := true, true
+
+	for _, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		trimmed := strings.TrimSpace(line)
+
+		// leading comments belong to the next line that is not a comment
+		if strings.HasPrefix(trimmed, "#") || len(trimmed) == 0 {
+			result = append(result, line)
+
+			continue
+		}
+
+		separate := false
+
+		switch {
+		case indent == 0 && !strings.HasPrefix(trimmed, "-"):
+			root, _, _ = strings.Cut(trimmed, ":")
+			separate = true
+			stageSteps = false
+			firstStage, firstItem = true, true
+		case root == "stages" && indent == 2:
+			separate = !firstStage
+			stageSteps = false
+			firstStage = false
+		case root == "stages" && indent == 4:
+			stageSteps = trimmed == "steps:"
+			firstItem = true
+		case root == "stages" && stageSteps && indent == 6 && strings.HasPrefix(trimmed, "- "):
+			separate = !firstItem
+			firstItem = false
+		case indent == 2 && strings.HasPrefix(trimmed, "- ") && slices.Contains([]string{"secrets", "services", "steps", "templates"}, root):
+			separate = !firstItem
+			firstItem = false
+		}
+
+		if separate {
+			// move the empty line above the leading comments
+			at := len(result)
+			for at > 0 && strings.HasPrefix(strings.TrimSpace(result[at-1]), "#") {
+				at--
+			}
+
+			if at > 0 && len(strings.TrimSpace(result[at-1])) > 0 {
+				result = slices.Insert(result, at, "")
+			}
+		}
+
+		result = append(result, line)
+	}
+
+	return []byte(strings.Join(result, "\n") + "\n")
+}
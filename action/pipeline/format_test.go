@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPipeline_Config_Format(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "format", "unformatted.yml"))
+	if err != nil {
+		t.Fatalf("unable to read pipeline: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, ".vela.yml"), data, 0o600)
+	if err != nil {
+		t.Fatalf("unable to write pipeline: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+	}{
+		{
+			name:    "stdout",
+			failure: false,
+			config:  &Config{Action: "fmt", File: ".vela.yml", Path: dir},
+		},
+		{
+			name:    "check unformatted",
+			failure: true,
+			config:  &Config{Action: "fmt", File: ".vela.yml", Path: dir, Check: true},
+		},
+		{
+			name:    "write",
+			failure: false,
+			config:  &Config{Action: "fmt", File: ".vela.yml", Path: dir, Write: true},
+		},
+		{
+			name:    "check formatted",
+			failure: false,
+			config:  &Config{Action: "fmt", File: ".vela.yml", Path: dir, Check: true},
+		},
+		{
+			name:    "missing pipeline",
+			failure: true,
+			config:  &Config{Action: "fmt", File: "notfound.yml", Path: dir},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Format()
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Format should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Format returned err: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_format(t *testing.T) {
+	// setup types
+	data, err := os.ReadFile(filepath.Join("testdata", "format", "unformatted.yml"))
+	if err != nil {
+		t.Fatalf("unable to read pipeline: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "format", "formatted.yml"))
+	if err != nil {
+		t.Fatalf("unable to read formatted pipeline: %v", err)
+	}
+
+	// run test
+	got, err := format(data)
+	if err != nil {
+		t.Errorf("format returned err: %v", err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("format is %s, want %s", got, want)
+	}
+
+	again, err := format(got)
+	if err != nil {
+		t.Errorf("format returned err: %v", err)
+	}
+
+	if string(again) != string(got) {
+		t.Errorf("format is not idempotent, got %s, want %s", again, got)
+	}
+}
+
+func TestPipeline_format_Ruleset(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name     string
+		pipeline string
+		want     string
+	}{
+		{
+			name:     "if and unless",
+			pipeline: "steps:\n  - name: test\n    ruleset:\n      unless:\n        branch: [ main ]\n      if:\n        event: [ push, tag ]\n",
+			want:     "steps:\n  - name: test\n    ruleset:\n      if:\n        event: [push, tag]\n      unless:\n        branch: main\n",
+		},
+		{
+			name:     "anchored if",
+			pipeline: "steps:\n  - name: a\n    ruleset:\n      if: &rules\n        event: push\n  - name: b\n    ruleset:\n      if: *rules\n",
+			want:     "steps:\n  - name: a\n    ruleset:\n      if: &rules\n        event: push\n\n  - name: b\n    ruleset:\n      if: *rules\n",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := format([]byte(test.pipeline))
+			if err != nil {
+				t.Errorf("format returned err: %v", err)
+			}
+
+			if string(got) != test.want {
+				t.Errorf("format is %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_format_Anchors(t *testing.T) {
+	// setup types
+	pipeline := `steps:
+  - name: test
+    image: golang:1.26
+    <<: &defaults
+      pull: always
+  - <<: *defaults
+    name: build
+    image: golang:1.26
+
+version: "1"
+`
+
+	// run test
+	got, err := format([]byte(pipeline))
+	if err != nil {
+		t.Errorf("format returned err: %v", err)
+	}
+
+	want := `version: "1"
+
+steps:
+  - <<: &defaults
+      pull: always
+    name: test
+    image: golang:1.26
+
+  - <<: *defaults
+    name: build
+    image: golang:1.26
+`
+
+	if string(got) != want {
+		t.Errorf("format is %s, want %s", got, want)
+	}
+}
@@ -46,6 +46,30 @@ func TestPipeline_Config_Generate(t *testing.T) {
 				Type:   "node",
 			},
 		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				File:   ".vela.yml",
+				Type:   "python",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				File:   ".vela.yml",
+				Type:   "rust",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				File:   ".vela.yml",
+				Type:   "docker",
+			},
+		},
 		{
 			failure: false,
 			config: &Config{
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	api "github.com/go-vela/server/api/types"
+	"github.com/go-vela/server/constants"
+)
+
+// loadGitChangeset adds the files changed in the local git
+// repository, relative to the configured base revision, to
+// the file changeset used for ruleset matching.
+func (c *Config) loadGitChangeset(path string) error {
+	// check if a base revision was provided
+	if len(c.ChangesetFromGit) == 0 {
+		return nil
+	}
+
+	logrus.Debugf("capturing file changeset from git relative to %s", c.ChangesetFromGit)
+
+	// capture the files changed in the local git repository
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#GetGitChangeset
+	files, err := internal.GetGitChangeset(path, c.ChangesetFromGit)
+	if err != nil {
+		return fmt.Errorf("unable to capture file changeset from git: %w", err)
+	}
+
+	logrus.Tracef("captured %d changed files from git", len(files))
+
+	changeset := slices.Concat(c.FileChangeset, files)
+
+	slices.Sort(changeset)
+
+	c.FileChangeset = slices.Compact(changeset)
+
+	return nil
+}
+
+// setGitMetadata populates the build with the metadata for
+// the commit checked out in the local git repository. Any
+// values already provided for the build take precedence.
+//
+// For pull requests, the branch is the branch targeted by the
+// pull request, which is the provided base revision when it
+// names a branch or the default branch of the origin remote.
+func setGitMetadata(b *api.Build, path, base string) {
+	// capture the metadata for HEAD
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#GetGitHead
+	head, err := internal.GetGitHead(path)
+	if err != nil {
+		logrus.Debugf("unable to capture git metadata for %s: %v", path, err)
+
+		return
+	}
+
+	logrus.Debugf("setting build metadata from git commit %s", head.Commit)
+
+	b.SetCommit(head.Commit)
+	b.SetAuthor(head.Author)
+	b.SetEmail(head.Email)
+	b.SetMessage(head.Message)
+
+	if b.GetEvent() == constants.EventPull {
+		setGitPullBranch(b, path, base, head.Branch)
+	}
+
+	if len(b.GetBranch()) == 0 {
+		b.SetBranch(head.Branch)
+	}
+
+	// only use the ref from git when it matches the branch
+	if len(b.GetRef()) == 0 && b.GetBranch() == head.Branch {
+		b.SetRef(head.Ref)
+	}
+}
+
+// setGitPullBranch populates the branches of the build for a pull
+// request from the branch checked out to the branch it targets.
+func setGitPullBranch(b *api.Build, path, base, branch string) {
+	if len(b.GetHeadRef()) == 0 {
+		b.SetHeadRef(branch)
+	}
+
+	if len(b.GetBranch()) > 0 {
+		return
+	}
+
+	// capture the branch targeted by the pull request
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#GetGitTargetBranch
+	target, err := internal.GetGitTargetBranch(path, base)
+	if err != nil {
+		logrus.Debugf("unable to capture target branch, using %s: %v", branch, err)
+
+		return
+	}
+
+	b.SetBranch(target)
+
+	if len(b.GetBaseRef()) == 0 {
+		b.SetBaseRef(target)
+	}
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	api "github.com/go-vela/server/api/types"
+	"github.com/go-vela/server/constants"
+)
+
+func TestPipeline_setGitMetadata(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	hash := testGitCommit(t, r, dir, ".vela.yml", "add pipeline")
+
+	for _, ref := range []*plumbing.Reference{
+		plumbing.NewHashReference("refs/remotes/origin/main", hash),
+		plumbing.NewHashReference("refs/remotes/origin/release", hash),
+		plumbing.NewSymbolicReference(plumbing.NewRemoteHEADReferenceName("origin"), "refs/remotes/origin/main"),
+	} {
+		err = r.Storer.SetReference(ref)
+		if err != nil {
+			t.Fatalf("unable to set reference: %v", err)
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		name        string
+		event       string
+		branch      string
+		base        string
+		wantBranch  string
+		wantRef     string
+		wantHeadRef string
+	}{
+		{
+			name:       "branch from git",
+			branch:     "",
+			wantBranch: "master",
+			wantRef:    "refs/heads/master",
+		},
+		{
+			name:       "branch provided",
+			branch:     "main",
+			wantBranch: "main",
+			wantRef:    "",
+		},
+		{
+			name:        "pull request targets default branch",
+			event:       constants.EventPull,
+			wantBranch:  "main",
+			wantRef:     "",
+			wantHeadRef: "master",
+		},
+		{
+			name:        "pull request targets base branch",
+			event:       constants.EventPull,
+			base:        "origin/release",
+			wantBranch:  "release",
+			wantRef:     "",
+			wantHeadRef: "master",
+		},
+		{
+			name:        "pull request branch provided",
+			event:       constants.EventPull,
+			branch:      "develop",
+			wantBranch:  "develop",
+			wantRef:     "",
+			wantHeadRef: "master",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := new(api.Build)
+			b.SetEvent(test.event)
+			b.SetBranch(test.branch)
+
+			setGitMetadata(b, dir, test.base)
+
+			if b.GetCommit() != hash.String() {
+				t.Errorf("commit is %s, want %s", b.GetCommit(), hash.String())
+			}
+
+			if b.GetAuthor() != "Octocat" {
+				t.Errorf("author is %s, want Octocat", b.GetAuthor())
+			}
+
+			if b.GetMessage() != "add pipeline" {
+				t.Errorf("message is %s, want add pipeline", b.GetMessage())
+			}
+
+			if b.GetBranch() != test.wantBranch {
+				t.Errorf("branch is %s, want %s", b.GetBranch(), test.wantBranch)
+			}
+
+			if b.GetRef() != test.wantRef {
+				t.Errorf("ref is %s, want %s", b.GetRef(), test.wantRef)
+			}
+
+			if b.GetHeadRef() != test.wantHeadRef {
+				t.Errorf("head ref is %s, want %s", b.GetHeadRef(), test.wantHeadRef)
+			}
+		})
+	}
+
+	// a directory outside of a git repository leaves the build untouched
+	b := new(api.Build)
+
+	setGitMetadata(b, t.TempDir(), "")
+
+	if len(b.GetCommit()) > 0 {
+		t.Errorf("commit should be empty outside of a git repository")
+	}
+}
+
+func TestPipeline_Config_loadGitChangeset(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	testGitCommit(t, r, dir, ".vela.yml", "add pipeline")
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("unable to open worktree: %v", err)
+	}
+
+	err = w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true})
+	if err != nil {
+		t.Fatalf("unable to checkout branch: %v", err)
+	}
+
+	testGitCommit(t, r, dir, "docs/README.md", "add docs")
+
+	// run tests
+	c := &Config{FileChangeset: []string{"docs/README.md", "manual.txt"}}
+
+	err = c.loadGitChangeset(dir)
+	if err != nil {
+		t.Errorf("loadGitChangeset returned err: %v", err)
+	}
+
+	if !slices.Equal(c.FileChangeset, []string{"docs/README.md", "manual.txt"}) {
+		t.Errorf("FileChangeset is %v without base revision", c.FileChangeset)
+	}
+
+	c.ChangesetFromGit = "master"
+
+	err = c.loadGitChangeset(dir)
+	if err != nil {
+		t.Errorf("loadGitChangeset returned err: %v", err)
+	}
+
+	want := []string{"docs/README.md", "manual.txt"}
+
+	if !slices.Equal(c.FileChangeset, want) {
+		t.Errorf("FileChangeset is %v, want %v", c.FileChangeset, want)
+	}
+
+	c.ChangesetFromGit = "notfound"
+
+	err = c.loadGitChangeset(dir)
+	if err == nil {
+		t.Errorf("loadGitChangeset should have returned err")
+	}
+}
+
+// testGitCommit writes and commits a file to the provided repository.
+func testGitCommit(t *testing.T, r *git.Repository, dir, file, message string) plumbing.Hash {
+	t.Helper()
+
+	path := filepath.Join(dir, file)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	err = os.WriteFile(path, []byte(message), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("unable to open worktree: %v", err)
+	}
+
+	_, err = w.Add(file)
+	if err != nil {
+		t.Fatalf("unable to add file: %v", err)
+	}
+
+	hash, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Octocat",
+			Email: "octocat@github.com",
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+
+	return hash
+}
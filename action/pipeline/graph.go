@@ -0,0 +1,499 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler/types/yaml"
+	"github.com/go-vela/server/constants"
+)
+
+const (
+	// GraphASCII renders the graph of a pipeline as plain text.
+	GraphASCII = "ascii"
+
+	// GraphDOT renders the graph of a pipeline in the Graphviz DOT language.
+	GraphDOT = "dot"
+
+	// GraphMermaid renders the graph of a pipeline as a Mermaid flowchart.
+	GraphMermaid = "mermaid"
+)
+
+var (
+	// dotEscape escapes the characters of a string for the DOT language.
+	dotEscape = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	// mermaidEscape escapes the characters of a string for a Mermaid flowchart.
+	mermaidEscape = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+)
+
+// graphNode represents a step or service of a pipeline graph.
+type graphNode struct {
+	ID    string
+	Name  string
+	Notes []string
+}
+
+// graphStage represents a stage of a pipeline graph with
+// the steps of the stage executed in order. The stage has
+// no name when the pipeline is made of steps.
+type graphStage struct {
+	ID    string
+	Name  string
+	Needs []string
+	Steps []*graphNode
+}
+
+// pipelineGraph represents the directed acyclic graph
+// of the stages and steps for a pipeline.
+type pipelineGraph struct {
+	Services []*graphNode
+	Stages   []*graphStage
+}
+
+// graph outputs the graph of the provided pipeline
+// in the format from the provided configuration.
+func (c *Config) graph(p *yaml.Build) error {
+	logrus.Debugf("rendering %s graph for pipeline", c.Graph)
+
+	g := newGraph(p)
+
+	var (
+		out string
+		err error
+	)
+
+	switch c.Graph {
+	case GraphDOT:
+		out = g.dot()
+	case GraphMermaid:
+		out = g.mermaid()
+	default:
+		out, err = g.ascii()
+		if err != nil {
+			return err
+		}
+	}
+
+	// output the graph in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(strings.TrimSuffix(out, "\n"))
+}
+
+// newGraph creates the graph for the provided pipeline.
+func newGraph(p *yaml.Build) *pipelineGraph {
+	g := new(pipelineGraph)
+
+	for i, service := range p.Services {
+		notes := []string{service.Image}
+		if len(service.Ports) > 0 {
+			notes = append(notes, "ports: "+strings.Join(service.Ports, ", "))
+		}
+
+		g.Services = append(g.Services, &graphNode{
+			ID:    fmt.Sprintf("service%d", i+1),
+			Name:  service.Name,
+			Notes: notes,
+		})
+	}
+
+	stages := map[string]bool{}
+	for _, stage := range p.Stages {
+		stages[stage.Name] = true
+	}
+
+	for i, stage := range p.Stages {
+		s := &graphStage{
+			ID:   fmt.Sprintf("stage%d", i+1),
+			Name: stage.Name,
+		}
+
+		// the compiler makes every stage need the injected clone
+		// stage, so only keep the needs for stages in the pipeline
+		for _, need := range stage.Needs {
+			if stages[need] && need != stage.Name && !slices.Contains(s.Needs, need) {
+				s.Needs = append(s.Needs, need)
+			}
+		}
+
+		for j, step := range stage.Steps {
+			s.Steps = append(s.Steps, graphStep(fmt.Sprintf("%s_step%d", s.ID, j+1), step))
+		}
+
+		g.Stages = append(g.Stages, s)
+	}
+
+	if len(p.Steps) > 0 {
+		s := &graphStage{ID: "steps"}
+
+		for i, step := range p.Steps {
+			s.Steps = append(s.Steps, graphStep(fmt.Sprintf("step%d", i+1), step))
+		}
+
+		g.Stages = append(g.Stages, s)
+	}
+
+	return g
+}
+
+// graphStep creates the node for the provided step
+// with the ruleset of the step as annotations.
+func graphStep(id string, step *yaml.Step) *graphNode {
+	node := &graphNode{ID: id, Name: step.Name, Notes: rulesetNotes(step.Ruleset)}
+
+	if step.Detach {
+		node.Notes = append(node.Notes, "detach")
+	}
+
+	return node
+}
+
+// rulesetNotes returns the summary of the conditions for the provided ruleset.
+func rulesetNotes(r yaml.Ruleset) []string {
+	notes := append(rulesNotes("if", r.If), rulesNotes("unless", r.Unless)...)
+
+	if len(notes) > 0 {
+		if r.Matcher == constants.MatcherRegex {
+			notes = append(notes, "matcher: regexp")
+		}
+
+		if r.Operator == constants.OperatorOr {
+			notes = append(notes, "operator: or")
+		}
+	}
+
+	if r.Continue {
+		notes = append(notes, "continue")
+	}
+
+	return notes
+}
+
+// rulesNotes returns the summary of the conditions for the provided rules.
+func rulesNotes(prefix string, r yaml.Rules) []string {
+	fields := []struct {
+		name   string
+		values []string
+	}{
+		{"branch", r.Branch},
+		{"comment", r.Comment},
+		{"event", r.Event},
+		{"path", r.Path},
+		{"repo", r.Repo},
+		{"status", r.Status},
+		{"tag", r.Tag},
+		{"target", r.Target},
+	}
+
+	notes := []string{}
+
+	for _, field := range fields {
+		if len(field.values) > 0 {
+			notes = append(notes, fmt.Sprintf("%s %s: %s", prefix, field.name, strings.Join(field.values, ", ")))
+		}
+	}
+
+	return notes
+}
+
+// levels returns the stages of the graph grouped by the longest
+// path of needs leading to them, so the stages of a level can
+// be executed in parallel once the previous levels complete.
+func (g *pipelineGraph) levels() ([][]*graphStage, error) {
+	stages := map[string]*graphStage{}
+	for _, stage := range g.Stages {
+		stages[stage.Name] = stage
+	}
+
+	depth := map[*graphStage]int{}
+	visiting := map[*graphStage]bool{}
+
+	var visit func(stage *graphStage) (int, error)
+
+	visit = func(stage *graphStage) (int, error) {
+		if d, ok := depth[stage]; ok {
+			return d, nil
+		}
+
+		if visiting[stage] {
+			return 0, fmt.Errorf("unable to render graph: stage %s has a circular dependency", stage.Name)
+		}
+
+		visiting[stage] = true
+
+		d := 0
+
+		for _, need := range stage.Needs {
+			n, err := visit(stages[need])
+			if err != nil {
+				return 0, err
+			}
+
+			d = max(d, n+1)
+		}
+
+		depth[stage] = d
+
+		return d, nil
+	}
+
+	levels := [][]*graphStage{}
+
+	for _, stage := range g.Stages {
+		d, err := visit(stage)
+		if err != nil {
+			return nil, err
+		}
+
+		for len(levels) <= d {
+			levels = append(levels, []*graphStage{})
+		}
+
+		levels[d] = append(levels[d], stage)
+	}
+
+	return levels, nil
+}
+
+// ascii renders the graph as plain text with the stages
+// grouped by the level at which they can be executed.
+func (g *pipelineGraph) ascii() (string, error) {
+	levels, err := g.levels()
+	if err != nil {
+		return "", err
+	}
+
+	b := new(strings.Builder)
+
+	if len(g.Services) > 0 {
+		b.WriteString("== services ==\n")
+
+		for _, service := range g.Services {
+			fmt.Fprintf(b, "  %s%s\n", service.Name, asciiNotes(service.Notes))
+		}
+
+		b.WriteString("\n")
+	}
+
+	for i, level := range levels {
+		for _, stage := range level {
+			if len(stage.Name) == 0 {
+				b.WriteString("== steps ==\n")
+
+				asciiSteps(b, stage.Steps)
+
+				continue
+			}
+
+			if stage == level[0] {
+				fmt.Fprintf(b, "== level %d ==\n", i+1)
+			}
+
+			b.WriteString(stage.Name)
+
+			if len(stage.Needs) > 0 {
+				fmt.Fprintf(b, " (needs: %s)", strings.Join(stage.Needs, ", "))
+			}
+
+			b.WriteString("\n")
+
+			asciiSteps(b, stage.Steps)
+		}
+
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
+
+// asciiSteps writes the numbered steps of a stage.
+func asciiSteps(b *strings.Builder, steps []*graphNode) {
+	for i, step := range steps {
+		fmt.Fprintf(b, "  %d. %s%s\n", i+1, step.Name, asciiNotes(step.Notes))
+	}
+}
+
+// asciiNotes returns the annotations of a node for plain text.
+func asciiNotes(notes []string) string {
+	if len(notes) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" [%s]", strings.Join(notes, "; "))
+}
+
+// dot renders the graph in the Graphviz DOT language
+// with a cluster for the services and each stage.
+func (g *pipelineGraph) dot() string {
+	b := new(strings.Builder)
+
+	b.WriteString("digraph pipeline {\n")
+	b.WriteString("  compound=true;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	if len(g.Services) > 0 {
+		b.WriteString("\n  subgraph cluster_services {\n")
+		b.WriteString("    label=\"services\";\n")
+		b.WriteString("    style=dashed;\n")
+
+		for _, service := range g.Services {
+			fmt.Fprintf(b, "    %s [label=%s, style=dashed];\n", service.ID, dotLabel(service))
+		}
+
+		b.WriteString("  }\n")
+	}
+
+	stages := map[string]*graphStage{}
+
+	for _, stage := range g.Stages {
+		stages[stage.Name] = stage
+
+		indent := "  "
+
+		if len(stage.Name) > 0 {
+			fmt.Fprintf(b, "\n  subgraph cluster_%s {\n", stage.ID)
+			fmt.Fprintf(b, "    label=%s;\n", dotQuote(stage.Name))
+
+			indent = "    "
+		} else {
+			b.WriteString("\n")
+		}
+
+		for _, step := range stage.Steps {
+			fmt.Fprintf(b, "%s%s [label=%s];\n", indent, step.ID, dotLabel(step))
+		}
+
+		for i := 1; i < len(stage.Steps); i++ {
+			fmt.Fprintf(b, "%s%s -> %s;\n", indent, stage.Steps[i-1].ID, stage.Steps[i].ID)
+		}
+
+		if len(stage.Name) > 0 {
+			b.WriteString("  }\n")
+		}
+	}
+
+	edges := []string{}
+
+	for _, stage := range g.Stages {
+		if len(stage.Steps) == 0 {
+			continue
+		}
+
+		for _, need := range stage.Needs {
+			from := stages[need]
+			if len(from.Steps) == 0 {
+				continue
+			}
+
+			edges = append(edges, fmt.Sprintf("  %s -> %s [ltail=cluster_%s, lhead=cluster_%s];\n",
+				from.Steps[len(from.Steps)-1].ID, stage.Steps[0].ID, from.ID, stage.ID))
+		}
+	}
+
+	if len(edges) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(edges, ""))
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// dotLabel returns the quoted label, with the
+// annotations, of a node for the DOT language.
+func dotLabel(node *graphNode) string {
+	lines := append([]string{node.Name}, node.Notes...)
+
+	for i, line := range lines {
+		lines[i] = dotEscape.Replace(line)
+	}
+
+	return fmt.Sprintf(`"%s"`, strings.Join(lines, `\n`))
+}
+
+// dotQuote returns the quoted string for the DOT language.
+func dotQuote(s string) string {
+	return fmt.Sprintf(`"%s"`, dotEscape.Replace(s))
+}
+
+// mermaid renders the graph as a Mermaid flowchart
+// with a subgraph for the services and each stage.
+func (g *pipelineGraph) mermaid() string {
+	b := new(strings.Builder)
+
+	b.WriteString("flowchart TD\n")
+
+	if len(g.Services) > 0 {
+		b.WriteString("  subgraph services [services]\n")
+
+		for _, service := range g.Services {
+			fmt.Fprintf(b, "    %s[%s]:::service\n", service.ID, mermaidLabel(service))
+		}
+
+		b.WriteString("  end\n")
+	}
+
+	for _, stage := range g.Stages {
+		indent := "  "
+
+		if len(stage.Name) > 0 {
+			fmt.Fprintf(b, "  subgraph %s [%s]\n", stage.ID, mermaidQuote(stage.Name))
+
+			indent = "    "
+		}
+
+		for _, step := range stage.Steps {
+			fmt.Fprintf(b, "%s%s[%s]\n", indent, step.ID, mermaidLabel(step))
+		}
+
+		for i := 1; i < len(stage.Steps); i++ {
+			fmt.Fprintf(b, "%s%s --> %s\n", indent, stage.Steps[i-1].ID, stage.Steps[i].ID)
+		}
+
+		if len(stage.Name) > 0 {
+			b.WriteString("  end\n")
+		}
+	}
+
+	stages := map[string]*graphStage{}
+	for _, stage := range g.Stages {
+		stages[stage.Name] = stage
+	}
+
+	for _, stage := range g.Stages {
+		for _, need := range stage.Needs {
+			fmt.Fprintf(b, "  %s --> %s\n", stages[need].ID, stage.ID)
+		}
+	}
+
+	if len(g.Services) > 0 {
+		b.WriteString("  classDef service stroke-dasharray: 5 5\n")
+	}
+
+	return b.String()
+}
+
+// mermaidLabel returns the quoted label, with the
+// annotations, of a node for a Mermaid flowchart.
+func mermaidLabel(node *graphNode) string {
+	lines := append([]string{node.Name}, node.Notes...)
+
+	for i, line := range lines {
+		lines[i] = mermaidEscape.Replace(line)
+	}
+
+	return fmt.Sprintf(`"%s"`, strings.Join(lines, "<br/>"))
+}
+
+// mermaidQuote returns the quoted string for a Mermaid flowchart.
+func mermaidQuote(s string) string {
+	return fmt.Sprintf(`"%s"`, mermaidEscape.Replace(s))
+}
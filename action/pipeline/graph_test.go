@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/yaml"
+)
+
+// graphPipeline returns a staged pipeline used to test the graph renderers.
+func graphPipeline() *yaml.Build {
+	return &yaml.Build{
+		Version: "1",
+		Services: yaml.ServiceSlice{
+			{Name: "redis", Image: "redis:7", Ports: []string{"6379:6379"}},
+		},
+		Stages: yaml.StageSlice{
+			{
+				Name:  "build",
+				Needs: []string{"clone"},
+				Steps: yaml.StepSlice{
+					{Name: "install", Image: "golang:1.24"},
+					{Name: "build", Image: "golang:1.24"},
+				},
+			},
+			{
+				Name:  "test",
+				Needs: []string{"clone", "build"},
+				Steps: yaml.StepSlice{
+					{
+						Name:  "test",
+						Image: "golang:1.24",
+						Ruleset: yaml.Ruleset{
+							If:       yaml.Rules{Event: []string{"push", "pull_request"}},
+							Unless:   yaml.Rules{Branch: []string{"docs/*"}},
+							Continue: true,
+						},
+					},
+				},
+			},
+			{
+				Name:  "lint",
+				Needs: []string{"clone", "build"},
+				Steps: yaml.StepSlice{
+					{Name: "lint", Image: "golangci/golangci-lint:latest"},
+				},
+			},
+			{
+				Name:  "publish",
+				Needs: []string{"test", "lint"},
+				Steps: yaml.StepSlice{
+					{
+						Name:  "publish",
+						Image: "target/vela-docker:latest",
+						Ruleset: yaml.Ruleset{
+							If: yaml.Rules{Event: []string{"tag"}, Tag: []string{`"v*"`}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPipeline_pipelineGraph_ascii(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name     string
+		pipeline *yaml.Build
+		want     string
+	}{
+		{
+			name:     "stages",
+			pipeline: graphPipeline(),
+			want: `== services ==
+  redis [redis:7; ports: 6379:6379]
+
+== level 1 ==
+build
+  1. install
+  2. build
+
+== level 2 ==
+test (needs: build)
+  1. test [if event: push, pull_request; unless branch: docs/*; continue]
+lint (needs: build)
+  1. lint
+
+== level 3 ==
+publish (needs: test, lint)
+  1. publish [if event: tag; if tag: "v*"]
+`,
+		},
+		{
+			name: "steps",
+			pipeline: &yaml.Build{
+				Version: "1",
+				Steps: yaml.StepSlice{
+					{Name: "test", Image: "golang:1.24"},
+					{Name: "sidecar", Image: "alpine:latest", Detach: true},
+				},
+			},
+			want: `== steps ==
+  1. test
+  2. sidecar [detach]
+`,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newGraph(test.pipeline).ascii()
+			if err != nil {
+				t.Fatalf("ascii returned err: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("ascii is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_pipelineGraph_ascii_Cycle(t *testing.T) {
+	// setup types
+	p := &yaml.Build{
+		Version: "1",
+		Stages: yaml.StageSlice{
+			{Name: "one", Needs: []string{"two"}, Steps: yaml.StepSlice{{Name: "one"}}},
+			{Name: "two", Needs: []string{"one"}, Steps: yaml.StepSlice{{Name: "two"}}},
+		},
+	}
+
+	_, err := newGraph(p).ascii()
+	if err == nil {
+		t.Errorf("ascii should have returned err")
+	}
+}
+
+func TestPipeline_pipelineGraph_dot(t *testing.T) {
+	// setup types
+	want := `digraph pipeline {
+  compound=true;
+  node [shape=box];
+
+  subgraph cluster_services {
+    label="services";
+    style=dashed;
+    service1 [label="redis\nredis:7\nports: 6379:6379", style=dashed];
+  }
+
+  subgraph cluster_stage1 {
+    label="build";
+    stage1_step1 [label="install"];
+    stage1_step2 [label="build"];
+    stage1_step1 -> stage1_step2;
+  }
+
+  subgraph cluster_stage2 {
+    label="test";
+    stage2_step1 [label="test\nif event: push, pull_request\nunless branch: docs/*\ncontinue"];
+  }
+
+  subgraph cluster_stage3 {
+    label="lint";
+    stage3_step1 [label="lint"];
+  }
+
+  subgraph cluster_stage4 {
+    label="publish";
+    stage4_step1 [label="publish\nif event: tag\nif tag: \"v*\""];
+  }
+
+  stage1_step2 -> stage2_step1 [ltail=cluster_stage1, lhead=cluster_stage2];
+  stage1_step2 -> stage3_step1 [ltail=cluster_stage1, lhead=cluster_stage3];
+  stage2_step1 -> stage4_step1 [ltail=cluster_stage2, lhead=cluster_stage4];
+  stage3_step1 -> stage4_step1 [ltail=cluster_stage3, lhead=cluster_stage4];
+}
+`
+
+	got := newGraph(graphPipeline()).dot()
+
+	if got != want {
+		t.Errorf("dot is %s, want %s", got, want)
+	}
+}
+
+func TestPipeline_pipelineGraph_mermaid(t *testing.T) {
+	// setup types
+	want := `flowchart TD
+  subgraph services [services]
+    service1["redis<br/>redis:7<br/>ports: 6379:6379"]:::service
+  end
+  subgraph stage1 ["build"]
+    stage1_step1["install"]
+    stage1_step2["build"]
+    stage1_step1 --> stage1_step2
+  end
+  subgraph stage2 ["test"]
+    stage2_step1["test<br/>if event: push, pull_request<br/>unless branch: docs/*<br/>continue"]
+  end
+  subgraph stage3 ["lint"]
+    stage3_step1["lint"]
+  end
+  subgraph stage4 ["publish"]
+    stage4_step1["publish<br/>if event: tag<br/>if tag: #quot;v*#quot;"]
+  end
+  stage1 --> stage2
+  stage1 --> stage3
+  stage2 --> stage4
+  stage3 --> stage4
+  classDef service stroke-dasharray: 5 5
+`
+
+	got := newGraph(graphPipeline()).mermaid()
+
+	if got != want {
+		t.Errorf("mermaid is %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,470 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler"
+	pyaml "github.com/go-vela/server/compiler/types/yaml"
+)
+
+const (
+	// InitDocker defines the type for a pipeline publishing a Docker image.
+	InitDocker = "docker"
+
+	// InitGo defines the type for a pipeline of a Go project.
+	InitGo = "go"
+
+	// InitJava defines the type for a pipeline of a Java project.
+	InitJava = "java"
+
+	// InitNode defines the type for a pipeline of a Node.js project.
+	InitNode = "node"
+
+	// InitPython defines the type for a pipeline of a Python project.
+	InitPython = "python"
+
+	// InitRust defines the type for a pipeline of a Rust project.
+	InitRust = "rust"
+)
+
+// initTypes defines the types of pipelines offered when initializing a pipeline.
+var initTypes = []string{InitGo, InitJava, InitNode, InitPython, InitRust, InitDocker}
+
+// goVersionPattern matches the go directive of a go.mod file.
+var goVersionPattern = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+)`)
+
+// project represents the language, commands and publishing
+// needs of a project used to initialize a pipeline.
+type project struct {
+	Type    string
+	Image   string
+	Test    []string
+	Build   []string
+	Publish bool
+	Repo    string
+}
+
+// Init produces a pipeline for the project in the directory based
+// off the provided configuration, detecting the language, commands
+// and publishing needs from its files and prompting to confirm them.
+// The pipeline is compiled with the provided client before it is written.
+func (c *Config) Init(client compiler.Engine, in io.ReadCloser) error {
+	logrus.Debug("executing init for pipeline configuration")
+
+	// use custom filesystem which enables us to test
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#Afero
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	dir := c.Path
+
+	// send Filesystem call to capture base directory path
+	if len(dir) == 0 {
+		base, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		dir = base
+	}
+
+	// create full path for pipeline file
+	path := filepath.Join(dir, c.File)
+
+	found, err := a.Exists(path)
+	if err != nil {
+		return err
+	}
+
+	// check if an existing pipeline would be overwritten
+	if found && !c.Force {
+		return fmt.Errorf("pipeline file %s already exists, use --force to overwrite it", path)
+	}
+
+	kind := c.Type
+	if len(kind) == 0 {
+		kind = detectType(a, dir)
+	}
+
+	logrus.Tracef("creating %s project for %s", kind, dir)
+
+	p := c.project(a, dir, kind)
+
+	// check if the detected project should be confirmed
+	if !c.Defaults {
+		p, err = c.promptProject(a, dir, p, in)
+		if err != nil {
+			return err
+		}
+	}
+
+	logrus.Trace("creating file content from pipeline")
+
+	// create output for pipeline file
+	out, err := yaml.Marshal(p.pipeline())
+	if err != nil {
+		return err
+	}
+
+	logrus.Trace("compiling pipeline before writing it")
+
+	// compile the pipeline to ensure a valid pipeline is written
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/compiler?tab=doc#Engine.CompileLite
+	_, _, err = client.CompileLite(context.Background(), out, nil, false)
+	if err != nil {
+		return fmt.Errorf("unable to compile initialized pipeline: %w", err)
+	}
+
+	logrus.Tracef("writing file content to %s", path)
+
+	// send Filesystem call to create pipeline file
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#Afero.WriteFile
+	err = a.WriteFile(path, out, 0644)
+	if err != nil {
+		return err
+	}
+
+	// output the message in stderr format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+	return output.Stderr(fmt.Sprintf("created %s pipeline %s", p.Type, path))
+}
+
+// detectType detects the type of the project in the
+// directory from the files used by its language.
+func detectType(a *afero.Afero, dir string) string {
+	markers := []struct {
+		kind  string
+		files []string
+	}{
+		{InitGo, []string{"go.mod"}},
+		{InitNode, []string{"package.json"}},
+		{InitJava, []string{"pom.xml", "build.gradle", "build.gradle.kts"}},
+		{InitPython, []string{"pyproject.toml", "setup.py", "requirements.txt"}},
+		{InitRust, []string{"Cargo.toml"}},
+		{InitDocker, []string{"Dockerfile"}},
+	}
+
+	for _, marker := range markers {
+		for _, file := range marker.files {
+			if exists(a, dir, file) {
+				logrus.Debugf("detected %s project from %s", marker.kind, file)
+
+				return marker.kind
+			}
+		}
+	}
+
+	return ""
+}
+
+// project creates the project of the type with the image and commands
+// for its language, based off the files in the directory.
+func (c *Config) project(a *afero.Afero, dir, kind string) *project {
+	p := &project{
+		Type:    kind,
+		Image:   "alpine:latest",
+		Test:    []string{"echo hello"},
+		Publish: exists(a, dir, "Dockerfile"),
+		Repo:    fmt.Sprintf("index.docker.io/%s", filepath.Base(dir)),
+	}
+
+	// use the provided org and repo for the image
+	if len(c.Org) > 0 && len(c.Repo) > 0 {
+		p.Repo = fmt.Sprintf("index.docker.io/%s/%s", c.Org, c.Repo)
+	}
+
+	switch kind {
+	case InitGo:
+		p.Image = "golang:latest"
+
+		// use the go version from the module for the image
+		data, err := a.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			if match := goVersionPattern.FindSubmatch(data); match != nil {
+				p.Image = fmt.Sprintf("golang:%s", match[1])
+			}
+		}
+
+		p.Test = []string{"go test ./..."}
+		p.Build = []string{"go build ./..."}
+	case InitJava:
+		// check if the project is built with gradle
+		if exists(a, dir, "build.gradle") || exists(a, dir, "build.gradle.kts") {
+			p.Image = "gradle:latest"
+			p.Test = []string{"gradle test"}
+			p.Build = []string{"gradle assemble"}
+
+			// prefer the gradle wrapper of the project
+			if exists(a, dir, "gradlew") {
+				p.Test = []string{"./gradlew test"}
+				p.Build = []string{"./gradlew assemble"}
+			}
+
+			break
+		}
+
+		p.Image = "maven:latest"
+		p.Test = []string{"mvn --batch-mode test"}
+		p.Build = []string{"mvn --batch-mode package -DskipTests"}
+	case InitNode:
+		p.Image = "node:lts"
+
+		install := "npm install"
+
+		switch {
+		case exists(a, dir, "yarn.lock"):
+			install = "yarn install --frozen-lockfile"
+		case exists(a, dir, "package-lock.json"):
+			install = "npm ci"
+		}
+
+		scripts := packageScripts(a, dir)
+
+		p.Test = []string{install}
+		p.Build = nil
+
+		if slices.Contains(scripts, "test") {
+			p.Test = append(p.Test, "npm test")
+		}
+
+		if slices.Contains(scripts, "build") {
+			p.Build = []string{install, "npm run build"}
+		}
+	case InitPython:
+		p.Image = "python:3"
+
+		install := "pip install ."
+		if exists(a, dir, "requirements.txt") {
+			install = "pip install -r requirements.txt"
+		}
+
+		p.Test = []string{install, "pip install pytest", "python -m pytest"}
+		p.Build = nil
+
+		// check if the project can be packaged
+		if exists(a, dir, "pyproject.toml") {
+			p.Build = []string{"pip install build", "python -m build"}
+		}
+	case InitRust:
+		p.Image = "rust:latest"
+		p.Test = []string{"cargo test"}
+		p.Build = []string{"cargo build --release"}
+	case InitDocker:
+		p.Test = nil
+		p.Publish = true
+	}
+
+	return p
+}
+
+// promptProject provides prompts to confirm the type,
+// commands and publishing needs of the project.
+func (c *Config) promptProject(a *afero.Afero, dir string, p *project, in io.ReadCloser) (*project, error) {
+	logrus.Debug("executing prompt to confirm pipeline project")
+
+	s := promptui.Select{
+		Label:     "Select the type of pipeline",
+		Items:     initTypes,
+		CursorPos: max(slices.Index(initTypes, p.Type), 0),
+		Stdin:     in,
+	}
+
+	_, kind, err := s.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	// create the project again for a different type
+	if kind != p.Type {
+		p = c.project(a, dir, kind)
+	}
+
+	if kind != InitDocker {
+		p.Test, err = promptCommands("Test commands", p.Test, in)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Build, err = promptCommands("Build commands", p.Build, in)
+		if err != nil {
+			return nil, err
+		}
+
+		def := "n"
+		if p.Publish {
+			def = "y"
+		}
+
+		confirm := promptui.Prompt{
+			Label:     "Publish a Docker image",
+			IsConfirm: true,
+			Default:   def,
+			Stdin:     in,
+		}
+
+		_, err = confirm.Run()
+		if err != nil && !errors.Is(err, promptui.ErrAbort) {
+			return nil, err
+		}
+
+		p.Publish = err == nil
+	}
+
+	if p.Publish {
+		repo := promptui.Prompt{
+			Label:     "Docker image repository",
+			Default:   p.Repo,
+			AllowEdit: true,
+			Stdin:     in,
+		}
+
+		p.Repo, err = repo.Run()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// promptCommands provides a prompt to edit commands,
+// where the commands are separated by `&&`.
+func promptCommands(label string, commands []string, in io.ReadCloser) ([]string, error) {
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("%s (separated by &&, leave empty to skip)", label),
+		Default:   strings.Join(commands, " && "),
+		AllowEdit: true,
+		Stdin:     in,
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	commands = []string{}
+
+	for command := range strings.SplitSeq(result, "&&") {
+		command = strings.TrimSpace(command)
+
+		if len(command) > 0 {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands, nil
+}
+
+// pipeline creates the pipeline to test, build and publish the project.
+func (p *project) pipeline() *pyaml.Build {
+	logrus.Debugf("creating %s pipeline", p.Type)
+
+	steps := pyaml.StepSlice{}
+
+	if len(p.Test) > 0 {
+		steps = append(steps, &pyaml.Step{
+			Commands: p.Test,
+			Image:    p.Image,
+			Name:     "test",
+			Pull:     "always",
+		})
+	}
+
+	if len(p.Build) > 0 {
+		steps = append(steps, &pyaml.Step{
+			Commands: p.Build,
+			Image:    p.Image,
+			Name:     "build",
+			Pull:     "always",
+		})
+	}
+
+	if p.Publish {
+		steps = append(steps, &pyaml.Step{
+			Image: "target/vela-kaniko:latest",
+			Name:  "publish",
+			Pull:  "always",
+			Ruleset: pyaml.Ruleset{
+				If: pyaml.Rules{
+					Branch: []string{"main"},
+					Event:  []string{"push"},
+				},
+			},
+			Secrets: pyaml.StepSecretSlice{
+				{Source: "docker_username", Target: "DOCKER_USERNAME"},
+				{Source: "docker_password", Target: "DOCKER_PASSWORD"},
+			},
+			Parameters: map[string]any{
+				"registry": strings.SplitN(p.Repo, "/", 2)[0],
+				"repo":     p.Repo,
+				"auto_tag": true,
+			},
+		})
+	}
+
+	// return a pipeline with the steps for the project
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/compiler/types/yaml?tab=doc#Build
+	return &pyaml.Build{
+		Version: "1",
+		Steps:   steps,
+	}
+}
+
+// packageScripts returns the names of the scripts in the package.json of the directory.
+func packageScripts(a *afero.Afero, dir string) []string {
+	data, err := a.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	pkg := struct {
+		Scripts map[string]string `yaml:"scripts"`
+	}{}
+
+	// JSON is valid YAML, so the package is parsed like the pipeline
+	err = yaml.Unmarshal(data, &pkg)
+	if err != nil {
+		logrus.Debugf("unable to parse package.json: %v", err)
+
+		return nil
+	}
+
+	scripts := []string{}
+
+	for name := range pkg.Scripts {
+		scripts = append(scripts, name)
+	}
+
+	slices.Sort(scripts)
+
+	return scripts
+}
+
+// exists returns true if the file exists in the directory.
+func exists(a *afero.Afero, dir, file string) bool {
+	ok, err := a.Exists(filepath.Join(dir, file))
+
+	return err == nil && ok
+}
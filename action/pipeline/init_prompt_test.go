@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !race
+
+package pipeline
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_promptCommands(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		commands []string
+		data     string
+		want     []string
+	}{
+		{
+			failure:  false,
+			commands: []string{"go test ./..."},
+			data:     "\n",
+			want:     []string{"go test ./..."},
+		},
+		{
+			failure:  false,
+			commands: nil,
+			data:     "make test && make lint\n",
+			want:     []string{"make test", "make lint"},
+		},
+		{
+			failure:  false,
+			commands: []string{"go test ./..."},
+			data:     " && go vet ./...\n",
+			want:     []string{"go test ./...", "go vet ./..."},
+		},
+		{
+			failure:  true,
+			commands: []string{"go test ./..."},
+			data:     "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		in, err := os.CreateTemp(t.TempDir(), "commands")
+		if err != nil {
+			t.Errorf("unable to create temporary file: %v", err)
+		}
+
+		_, err = in.WriteString(test.data)
+		if err != nil {
+			t.Errorf("unable to write content to temporary file: %v", err)
+		}
+
+		_, err = in.Seek(0, 0)
+		if err != nil {
+			t.Errorf("unable to seek temporary file: %v", err)
+		}
+
+		got, err := promptCommands("Test commands", test.commands, in)
+
+		in.Close()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("promptCommands should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("promptCommands returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("promptCommands is %v, want %v", got, test.want)
+		}
+	}
+}
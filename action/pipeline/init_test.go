@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/server/compiler/native"
+)
+
+func TestPipeline_Config_Init(t *testing.T) {
+	// setup types
+	cmd := new(cli.Command)
+	cmd.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone-image",
+			Value: "target/vela-git:latest",
+		},
+	}
+
+	client, err := native.FromCLICommand(t.Context(), cmd)
+	if err != nil {
+		t.Errorf("unable to create client: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		files   map[string]string
+		config  *Config
+	}{
+		{
+			name:  "detected go with docker",
+			files: map[string]string{"go.mod": "module github.com/octocat/hello\n\ngo 1.26.1\n", "Dockerfile": "FROM scratch\n"},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Org:      "octocat",
+				Repo:     "hello",
+				Defaults: true,
+			},
+		},
+		{
+			name:  "detected node",
+			files: map[string]string{"package.json": `{"scripts": {"test": "jest", "build": "tsc"}}`, "package-lock.json": "{}"},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Defaults: true,
+			},
+		},
+		{
+			name:  "detected nothing",
+			files: map[string]string{},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Defaults: true,
+			},
+		},
+		{
+			name:  "provided docker",
+			files: map[string]string{},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Type:     "docker",
+				Defaults: true,
+			},
+		},
+		{
+			name:  "provided python",
+			files: map[string]string{"pyproject.toml": "[project]\nname = \"hello\"\n"},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Type:     "python",
+				Defaults: true,
+			},
+		},
+		{
+			name:    "existing pipeline",
+			failure: true,
+			files:   map[string]string{".vela.yml": "version: \"1\"\n"},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Defaults: true,
+			},
+		},
+		{
+			name:  "existing pipeline with force",
+			files: map[string]string{".vela.yml": "version: \"1\"\n", "Cargo.toml": "[package]\nname = \"hello\"\n"},
+			config: &Config{
+				Action:   "init",
+				File:     ".vela.yml",
+				Path:     "/project",
+				Defaults: true,
+				Force:    true,
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// setup filesystem
+		appFS = afero.NewMemMapFs()
+
+		for file, content := range test.files {
+			err := afero.WriteFile(appFS, "/project/"+file, []byte(content), 0644)
+			if err != nil {
+				t.Errorf("(%s) unable to write file: %v", test.name, err)
+			}
+		}
+
+		err := test.config.Init(client, nil)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("(%s) Init should have returned err", test.name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("(%s) Init returned err: %v", test.name, err)
+
+			continue
+		}
+
+		out, err := afero.ReadFile(appFS, "/project/.vela.yml")
+		if err != nil {
+			t.Errorf("(%s) unable to read pipeline: %v", test.name, err)
+
+			continue
+		}
+
+		// check that the initialized pipeline compiles
+		_, _, err = client.CompileLite(t.Context(), out, nil, false)
+		if err != nil {
+			t.Errorf("(%s) initialized pipeline is invalid: %v\n%s", test.name, err, out)
+		}
+	}
+}
+
+func TestPipeline_Config_project(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		files  []string
+		config *Config
+		want   *project
+	}{
+		{
+			name:   "go",
+			files:  []string{"go.mod"},
+			config: &Config{Org: "octocat", Repo: "hello"},
+			want: &project{
+				Type:  "go",
+				Image: "golang:1.26",
+				Test:  []string{"go test ./..."},
+				Build: []string{"go build ./..."},
+				Repo:  "index.docker.io/octocat/hello",
+			},
+		},
+		{
+			name:   "java with maven",
+			files:  []string{"pom.xml"},
+			config: &Config{},
+			want: &project{
+				Type:  "java",
+				Image: "maven:latest",
+				Test:  []string{"mvn --batch-mode test"},
+				Build: []string{"mvn --batch-mode package -DskipTests"},
+				Repo:  "index.docker.io/project",
+			},
+		},
+		{
+			name:   "java with gradle wrapper",
+			files:  []string{"build.gradle.kts", "gradlew"},
+			config: &Config{},
+			want: &project{
+				Type:  "java",
+				Image: "gradle:latest",
+				Test:  []string{"./gradlew test"},
+				Build: []string{"./gradlew assemble"},
+				Repo:  "index.docker.io/project",
+			},
+		},
+		{
+			name:   "node with yarn",
+			files:  []string{"package.json", "yarn.lock"},
+			config: &Config{},
+			want: &project{
+				Type:  "node",
+				Image: "node:lts",
+				Test:  []string{"yarn install --frozen-lockfile", "npm test"},
+				Build: []string{"yarn install --frozen-lockfile", "npm run build"},
+				Repo:  "index.docker.io/project",
+			},
+		},
+		{
+			name:   "python with requirements",
+			files:  []string{"requirements.txt"},
+			config: &Config{},
+			want: &project{
+				Type:  "python",
+				Image: "python:3",
+				Test:  []string{"pip install -r requirements.txt", "pip install pytest", "python -m pytest"},
+				Repo:  "index.docker.io/project",
+			},
+		},
+		{
+			name:   "rust with docker",
+			files:  []string{"Cargo.toml", "Dockerfile"},
+			config: &Config{},
+			want: &project{
+				Type:    "rust",
+				Image:   "rust:latest",
+				Test:    []string{"cargo test"},
+				Build:   []string{"cargo build --release"},
+				Publish: true,
+				Repo:    "index.docker.io/project",
+			},
+		},
+		{
+			name:   "docker",
+			files:  []string{"Dockerfile"},
+			config: &Config{},
+			want: &project{
+				Type:    "docker",
+				Image:   "alpine:latest",
+				Publish: true,
+				Repo:    "index.docker.io/project",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// setup filesystem
+		a := &afero.Afero{Fs: afero.NewMemMapFs()}
+
+		for _, file := range test.files {
+			content := ""
+
+			switch file {
+			case "go.mod":
+				content = "module github.com/octocat/hello\n\ngo 1.26.1\n"
+			case "package.json":
+				content = `{"scripts": {"build": "tsc", "test": "jest"}}`
+			}
+
+			err := a.WriteFile("/project/"+file, []byte(content), 0644)
+			if err != nil {
+				t.Errorf("(%s) unable to write file: %v", test.name, err)
+			}
+		}
+
+		got := test.config.project(a, "/project", detectType(a, "/project"))
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("(%s) project is %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
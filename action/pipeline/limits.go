@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/worker/runtime"
+)
+
+// limitedRuntime wraps a runtime to enforce the step
+// timeouts and fail fast behavior of a local build.
+type limitedRuntime struct {
+	runtime.Engine
+
+	// timeouts are the step timeouts keyed by container ID.
+	timeouts map[string]time.Duration
+	// names are the names of the steps keyed by container ID, in
+	// the form <stage>:<step> for the steps of a stage.
+	names map[string]string
+	// cancel cancels the build when a step fails, if set.
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	failed *pipeline.Container
+}
+
+// newLimitedRuntime wraps the provided runtime with the timeouts from
+// the configuration for the steps of the pipeline, canceling the build
+// with the provided function when a step fails and fail fast is enabled.
+func (c *Config) newLimitedRuntime(r runtime.Engine, p *pipeline.Build, cancel context.CancelFunc) (*limitedRuntime, error) {
+	timeouts, err := parseStepTimeouts(c.StepTimeouts)
+	if err != nil {
+		return nil, err
+	}
+
+	limited := &limitedRuntime{
+		Engine:   r,
+		timeouts: make(map[string]time.Duration),
+		names:    make(map[string]string),
+	}
+
+	// add the timeout of a step, preferring the timeout
+	// for the step of the stage over the step name
+	add := func(stage string, step *pipeline.Container) {
+		name := step.Name
+		if len(stage) > 0 {
+			name = fmt.Sprintf("%s:%s", stage, step.Name)
+		}
+
+		limited.names[step.ID] = name
+
+		for _, key := range []string{name, step.Name, ""} {
+			if timeout, ok := timeouts[key]; ok {
+				limited.timeouts[step.ID] = timeout
+
+				return
+			}
+		}
+	}
+
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			add(stage.Name, step)
+		}
+	}
+
+	for _, step := range p.Steps {
+		add("", step)
+	}
+
+	if c.FailFast {
+		limited.cancel = cancel
+	}
+
+	return limited, nil
+}
+
+// WaitContainer waits for the container to exit, killing
+// the container if it exceeds the timeout for the step.
+func (r *limitedRuntime) WaitContainer(ctx context.Context, ctn *pipeline.Container) error {
+	timeout, ok := r.timeouts[ctn.ID]
+	if !ok || ctn.Detach {
+		return r.Engine.WaitContainer(ctx, ctn)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := r.Engine.WaitContainer(waitCtx, ctn)
+
+	// check if the step exceeded its timeout rather than the build being canceled
+	if !errors.Is(waitCtx.Err(), context.DeadlineExceeded) || ctx.Err() != nil {
+		return err
+	}
+
+	logrus.Errorf("step %s exceeded timeout of %s - stopping step", r.name(ctn), timeout)
+
+	// kill the container so the step fails with its exit code
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#Docker
+	_, err = internal.Docker(ctx, "kill", ctn.ID)
+	if err != nil {
+		return fmt.Errorf("unable to stop step %s after timeout: %w", r.name(ctn), err)
+	}
+
+	return r.Engine.WaitContainer(ctx, ctn)
+}
+
+// InspectContainer inspects the container, canceling the build
+// when a step failed and fail fast is enabled.
+func (r *limitedRuntime) InspectContainer(ctx context.Context, ctn *pipeline.Container) error {
+	err := r.Engine.InspectContainer(ctx, ctn)
+	if err != nil {
+		return err
+	}
+
+	if r.cancel == nil || ctn.Detach || ctn.ExitCode == 0 || ctn.Ruleset.Continue {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// only the first failed step cancels the build
+	if r.failed != nil {
+		return nil
+	}
+
+	logrus.Errorf("step %s failed with exit code %d - canceling remaining steps and stages", r.name(ctn), ctn.ExitCode)
+
+	r.failed = ctn
+	r.cancel()
+
+	return nil
+}
+
+// failedStep returns the step that canceled the build, if any.
+func (r *limitedRuntime) failedStep() *pipeline.Container {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.failed
+}
+
+// name returns the name of the step, in the form
+// <stage>:<step> for the steps of a stage.
+func (r *limitedRuntime) name(ctn *pipeline.Container) string {
+	name, ok := r.names[ctn.ID]
+	if !ok {
+		return ctn.Name
+	}
+
+	return name
+}
+
+// parseStepTimeouts parses step timeouts provided in the form
+// <step>=<duration> or <stage>:<step>=<duration>, or <duration>
+// to set the default timeout for every step.
+func parseStepTimeouts(values []string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+
+	for _, value := range values {
+		name, duration, ok := strings.Cut(value, "=")
+		if !ok {
+			name, duration = "", value
+		}
+
+		timeout, err := time.ParseDuration(duration)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid step timeout: %s (valid format: <step>=<duration>, <stage>:<step>=<duration> or <duration>)", value)
+		}
+
+		timeouts[name] = timeout
+	}
+
+	return timeouts, nil
+}
+
+// parseLimits parses the resource limits provided into the CPU
+// quota, in units of 1e-9 CPUs, and the memory limit in bytes.
+func parseLimits(cpus, memory string) (int64, int64, error) {
+	var nanoCPUs, bytes int64
+
+	if len(cpus) > 0 {
+		n, err := strconv.ParseFloat(cpus, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid cpus limit: %s", cpus)
+		}
+
+		nanoCPUs = int64(n * 1e9)
+	}
+
+	if len(memory) > 0 {
+		// https://pkg.go.dev/github.com/docker/go-units#RAMInBytes
+		n, err := units.RAMInBytes(memory)
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid memory limit: %s", memory)
+		}
+
+		bytes = n
+	}
+
+	return nanoCPUs, bytes, nil
+}
@@ -0,0 +1,364 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/worker/runtime"
+)
+
+// testEngine is a runtime that runs containers which wait until
+// the provided context is done and exit with the provided code.
+type testEngine struct {
+	runtime.Engine
+
+	exitCode int32
+	waits    int
+}
+
+func (e *testEngine) RunContainer(context.Context, *pipeline.Container, *pipeline.Build) error {
+	return nil
+}
+
+func (e *testEngine) WaitContainer(ctx context.Context, _ *pipeline.Container) error {
+	e.waits++
+
+	// the first wait blocks until the step is stopped
+	if e.waits == 1 {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (e *testEngine) InspectContainer(_ context.Context, ctn *pipeline.Container) error {
+	ctn.ExitCode = e.exitCode
+
+	return nil
+}
+
+// testDockerArgs replaces the docker CLI with a fake
+// capturing the arguments of each command.
+func testDockerArgs(t *testing.T) *[][]string {
+	t.Helper()
+
+	docker := internal.Docker
+
+	t.Cleanup(func() { internal.Docker = docker })
+
+	calls := [][]string{}
+
+	internal.Docker = func(_ context.Context, args ...string) (string, error) {
+		calls = append(calls, args)
+
+		return "", nil
+	}
+
+	return &calls
+}
+
+func TestPipeline_limitedRuntime_WaitContainer(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		config *Config
+		ctn    *pipeline.Container
+		want   [][]string
+	}{
+		{
+			name:   "step timeout",
+			config: &Config{StepTimeouts: []string{"test=10ms"}},
+			ctn:    &pipeline.Container{ID: "step_github_octocat_1_test", Name: "test"},
+			want:   [][]string{{"kill", "step_github_octocat_1_test"}},
+		},
+		{
+			name:   "default timeout",
+			config: &Config{StepTimeouts: []string{"10ms", "build=1h"}},
+			ctn:    &pipeline.Container{ID: "step_github_octocat_1_test", Name: "test"},
+			want:   [][]string{{"kill", "step_github_octocat_1_test"}},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := testDockerArgs(t)
+
+			engine := new(testEngine)
+
+			r, err := test.config.newLimitedRuntime(engine, &pipeline.Build{Steps: pipeline.ContainerSlice{test.ctn}}, func() {})
+			if err != nil {
+				t.Fatalf("newLimitedRuntime returned err: %v", err)
+			}
+
+			err = r.WaitContainer(context.Background(), test.ctn)
+			if err != nil {
+				t.Errorf("WaitContainer returned err: %v", err)
+			}
+
+			if engine.waits != 2 {
+				t.Errorf("WaitContainer waited %d times, want 2", engine.waits)
+			}
+
+			if !reflect.DeepEqual(*calls, test.want) {
+				t.Errorf("WaitContainer is %v, want %v", *calls, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_limitedRuntime_WaitContainer_Stages(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		Stages: pipeline.StageSlice{
+			{
+				Name: "unit",
+				Steps: pipeline.ContainerSlice{
+					{ID: "github_octocat_1_unit_test", Name: "test"},
+				},
+			},
+			{
+				Name: "integration",
+				Steps: pipeline.ContainerSlice{
+					{ID: "github_octocat_1_integration_test", Name: "test"},
+				},
+			},
+		},
+	}
+
+	calls := testDockerArgs(t)
+
+	r, err := (&Config{StepTimeouts: []string{"unit:test=10ms", "test=1h"}}).newLimitedRuntime(new(testEngine), p, func() {})
+	if err != nil {
+		t.Fatalf("newLimitedRuntime returned err: %v", err)
+	}
+
+	want := map[string]time.Duration{
+		"github_octocat_1_unit_test":        10 * time.Millisecond,
+		"github_octocat_1_integration_test": time.Hour,
+	}
+
+	if !reflect.DeepEqual(r.timeouts, want) {
+		t.Errorf("newLimitedRuntime timeouts are %v, want %v", r.timeouts, want)
+	}
+
+	// run test
+	err = r.WaitContainer(context.Background(), p.Stages[0].Steps[0])
+	if err != nil {
+		t.Errorf("WaitContainer returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(*calls, [][]string{{"kill", "github_octocat_1_unit_test"}}) {
+		t.Errorf("WaitContainer is %v, want kill of github_octocat_1_unit_test", *calls)
+	}
+
+	if got := r.name(p.Stages[1].Steps[0]); got != "integration:test" {
+		t.Errorf("name is %s, want integration:test", got)
+	}
+}
+
+func TestPipeline_limitedRuntime_WaitContainer_Canceled(t *testing.T) {
+	// setup types
+	calls := testDockerArgs(t)
+
+	ctn := &pipeline.Container{ID: "step_github_octocat_1_test", Name: "test"}
+
+	r, err := (&Config{StepTimeouts: []string{"1h"}}).newLimitedRuntime(new(testEngine), &pipeline.Build{Steps: pipeline.ContainerSlice{ctn}}, func() {})
+	if err != nil {
+		t.Fatalf("newLimitedRuntime returned err: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// run test
+	err = r.WaitContainer(ctx, ctn)
+	if err == nil {
+		t.Errorf("WaitContainer should have returned err")
+	}
+
+	if len(*calls) > 0 {
+		t.Errorf("WaitContainer stopped the step after the build was canceled: %v", *calls)
+	}
+}
+
+func TestPipeline_limitedRuntime_InspectContainer(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name     string
+		failFast bool
+		exitCode int32
+		ctn      *pipeline.Container
+		want     bool
+	}{
+		{
+			name:     "failed step",
+			failFast: true,
+			exitCode: 1,
+			ctn:      &pipeline.Container{Name: "test"},
+			want:     true,
+		},
+		{
+			name:     "fail fast disabled",
+			failFast: false,
+			exitCode: 1,
+			ctn:      &pipeline.Container{Name: "test"},
+			want:     false,
+		},
+		{
+			name:     "successful step",
+			failFast: true,
+			exitCode: 0,
+			ctn:      &pipeline.Container{Name: "test"},
+			want:     false,
+		},
+		{
+			name:     "failed step with continue",
+			failFast: true,
+			exitCode: 1,
+			ctn:      &pipeline.Container{Name: "test", Ruleset: pipeline.Ruleset{Continue: true}},
+			want:     false,
+		},
+		{
+			name:     "failed detached step",
+			failFast: true,
+			exitCode: 1,
+			ctn:      &pipeline.Container{Name: "test", Detach: true},
+			want:     false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			canceled := false
+
+			r, err := (&Config{FailFast: test.failFast}).newLimitedRuntime(&testEngine{exitCode: test.exitCode}, new(pipeline.Build), func() { canceled = true })
+			if err != nil {
+				t.Fatalf("newLimitedRuntime returned err: %v", err)
+			}
+
+			err = r.InspectContainer(context.Background(), test.ctn)
+			if err != nil {
+				t.Errorf("InspectContainer returned err: %v", err)
+			}
+
+			if canceled != test.want {
+				t.Errorf("InspectContainer canceled is %t, want %t", canceled, test.want)
+			}
+
+			if got := r.failedStep() != nil; got != test.want {
+				t.Errorf("failedStep is %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_parseStepTimeouts(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		values  []string
+		want    map[string]time.Duration
+	}{
+		{
+			name:   "step and default timeouts",
+			values: []string{"5m", "test=15m", "build and push=1h", "unit:test=30s"},
+			want: map[string]time.Duration{
+				"":               5 * time.Minute,
+				"test":           15 * time.Minute,
+				"build and push": time.Hour,
+				"unit:test":      30 * time.Second,
+			},
+		},
+		{
+			name:   "no timeouts",
+			values: nil,
+			want:   map[string]time.Duration{},
+		},
+		{
+			name:    "invalid duration",
+			failure: true,
+			values:  []string{"test=forever"},
+		},
+		{
+			name:    "negative duration",
+			failure: true,
+			values:  []string{"-5m"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseStepTimeouts(test.values)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("parseStepTimeouts should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("parseStepTimeouts returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseStepTimeouts is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_parseLimits(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name     string
+		failure  bool
+		cpus     string
+		memory   string
+		nanoCPUs int64
+		bytes    int64
+	}{
+		{name: "valid limits", cpus: "0.5", memory: "2g", nanoCPUs: 500000000, bytes: 2147483648},
+		{name: "memory in bytes", memory: "1073741824", bytes: 1073741824},
+		{name: "no limits"},
+		{name: "invalid cpus", failure: true, cpus: "two"},
+		{name: "zero cpus", failure: true, cpus: "0"},
+		{name: "invalid memory", failure: true, memory: "lots"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nanoCPUs, bytes, err := parseLimits(test.cpus, test.memory)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("parseLimits should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("parseLimits returned err: %v", err)
+			}
+
+			if nanoCPUs != test.nanoCPUs || bytes != test.bytes {
+				t.Errorf("parseLimits is %d CPUs and %d bytes, want %d and %d", nanoCPUs, bytes, test.nanoCPUs, test.bytes)
+			}
+		})
+	}
+}
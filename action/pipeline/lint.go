@@ -0,0 +1,378 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal/output"
+)
+
+// lint severities for the findings of a pipeline.
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+	lintNote    = "note"
+	lintOff     = "off"
+	lintNone    = "none"
+)
+
+// lint output formats in addition to JSON.
+const (
+	lintText  = "text"
+	lintSARIF = "sarif"
+)
+
+// lintConfigFile is the default file name
+// for the configuration of the linter.
+const lintConfigFile = ".vela-lint.yml"
+
+// lintIgnore is the comment used to suppress the
+// findings on the same line of a pipeline.
+const lintIgnore = "vela-lint-ignore"
+
+// lintFinding represents a rule of the linter
+// that was not satisfied by the pipeline.
+type lintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Step     string `json:"step,omitempty"`
+}
+
+// lintConfig represents the configuration of the
+// linter provided in the .vela-lint.yml file.
+type lintConfig struct {
+	Rules        map[string]*lintRuleConfig `yaml:"rules"`
+	Suppressions []*lintSuppression         `yaml:"suppressions"`
+}
+
+// lintRuleConfig represents the configuration for a rule of the linter.
+type lintRuleConfig struct {
+	Severity string   `yaml:"severity"`
+	Images   []string `yaml:"images"`
+	Patterns []string `yaml:"patterns"`
+}
+
+// lintSuppression represents findings of the linter that are ignored.
+type lintSuppression struct {
+	Rule   string `yaml:"rule"`
+	Step   string `yaml:"step"`
+	File   string `yaml:"file"`
+	Reason string `yaml:"reason"`
+}
+
+// Lint checks a local pipeline against the rules of the
+// linter based off the provided configuration.
+func (c *Config) Lint() error {
+	logrus.Debug("executing lint for local pipeline configuration")
+
+	base, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	// capture the file name used in the findings
+	file, err := filepath.Rel(base, path)
+	if err != nil || strings.HasPrefix(file, "..") {
+		file = path
+	}
+
+	file = filepath.ToSlash(file)
+
+	config, err := c.loadLintConfig(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	findings, err := lint(data, config)
+	if err != nil {
+		return fmt.Errorf("unable to lint %s: %w", file, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	result := []*lintFinding{}
+
+	for _, finding := range findings {
+		finding.File = file
+
+		if config.suppressed(finding, lines) {
+			logrus.Debugf("suppressing %s finding on line %d", finding.Rule, finding.Line)
+
+			continue
+		}
+
+		result = append(result, finding)
+	}
+
+	// sort the findings by their position in the pipeline
+	slices.SortStableFunc(result, func(a, b *lintFinding) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return a.Column - b.Column
+	})
+
+	err = c.lintOutput(file, result)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, finding := range result {
+		if lintFails(finding.Severity, c.FailOn) {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%s has %d lint findings at or above %s severity", file, failed, c.lintFailOn())
+	}
+
+	return nil
+}
+
+// loadLintConfig loads the configuration for the linter from the
+// provided file, or the default file when it exists.
+func (c *Config) loadLintConfig(dir string) (*lintConfig, error) {
+	config := new(lintConfig)
+
+	file := c.LintConfig
+	if len(file) == 0 {
+		file = lintConfigFile
+	}
+
+	// check the directory of the pipeline for a relative configuration file
+	if !filepath.IsAbs(file) {
+		_, err := os.Stat(file)
+		if err != nil {
+			file = filepath.Join(dir, file)
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		// the default configuration file is optional
+		if errors.Is(err, os.ErrNotExist) && (len(c.LintConfig) == 0 || c.LintConfig == lintConfigFile) {
+			logrus.Debugf("no lint configuration found, using default rules")
+
+			return config, nil
+		}
+
+		return nil, fmt.Errorf("unable to read lint configuration %s: %w", file, err)
+	}
+
+	logrus.Debugf("loading lint configuration from %s", file)
+
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse lint configuration %s: %w", file, err)
+	}
+
+	for name, rule := range config.Rules {
+		if lintRuleByID(name) == nil {
+			return nil, fmt.Errorf("unknown lint rule %s in %s", name, file)
+		}
+
+		if rule != nil && len(rule.Severity) > 0 && !slices.Contains([]string{lintError, lintWarning, lintNote, lintOff}, rule.Severity) {
+			return nil, fmt.Errorf("invalid severity %s for lint rule %s (valid severities: error, warning, note, off)", rule.Severity, name)
+		}
+	}
+
+	return config, nil
+}
+
+// rule returns the configuration for the rule of the linter.
+func (l *lintConfig) rule(id string) *lintRuleConfig {
+	if l == nil || l.Rules[id] == nil {
+		return new(lintRuleConfig)
+	}
+
+	return l.Rules[id]
+}
+
+// severity returns the configured severity for the rule of the linter.
+func (l *lintConfig) severity(id string) string {
+	severity := l.rule(id).Severity
+	if len(severity) > 0 {
+		return severity
+	}
+
+	return lintRuleByID(id).Severity
+}
+
+// suppressed returns true when the finding is ignored by the configuration
+// of the linter or by a comment on the same line of the pipeline.
+func (l *lintConfig) suppressed(finding *lintFinding, lines []string) bool {
+	for _, s := range l.Suppressions {
+		if s.Rule != "*" && s.Rule != finding.Rule {
+			continue
+		}
+
+		if len(s.Step) > 0 {
+			match, _ := path.Match(s.Step, finding.Step)
+			if !match {
+				continue
+			}
+		}
+
+		if len(s.File) > 0 {
+			match, _ := path.Match(s.File, finding.File)
+			if !match {
+				continue
+			}
+		}
+
+		return true
+	}
+
+	if finding.Line < 1 || finding.Line > len(lines) {
+		return false
+	}
+
+	_, comment, found := strings.Cut(lines[finding.Line-1], lintIgnore)
+	if !found {
+		return false
+	}
+
+	rules := strings.FieldsFunc(comment, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r'
+	})
+
+	return len(rules) == 0 || slices.Contains(rules, finding.Rule)
+}
+
+// lintFailOn returns the severity that causes the linter to fail.
+func (c *Config) lintFailOn() string {
+	if len(c.FailOn) == 0 {
+		return lintError
+	}
+
+	return c.FailOn
+}
+
+// lintFails returns true when the severity of a finding
+// is at or above the severity that causes a failure.
+func lintFails(severity, failOn string) bool {
+	rank := map[string]int{lintNote: 1, lintWarning: 2, lintError: 3}
+
+	switch failOn {
+	case lintNone:
+		return false
+	case "":
+		failOn = lintError
+	}
+
+	return rank[severity] >= rank[failOn]
+}
+
+// lintOutput outputs the findings of the linter in the provided format.
+func (c *Config) lintOutput(file string, findings []*lintFinding) error {
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverJSON:
+		// output the findings in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(findings, c.Color)
+	case lintSARIF:
+		// output the findings in SARIF format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(lintSARIFLog(findings), c.Color)
+	default:
+		if len(findings) == 0 {
+			// output the message in stderr format
+			//
+			// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+			return output.Stderr(fmt.Sprintf("%s has no lint findings", file))
+		}
+
+		lines := []string{}
+
+		for _, finding := range findings {
+			lines = append(lines, fmt.Sprintf("%s:%d:%d: %s: %s [%s]",
+				finding.File, finding.Line, finding.Column, finding.Severity, finding.Message, finding.Rule))
+		}
+
+		// output the findings in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(strings.Join(lines, "\n"))
+	}
+}
+
+// lintSARIFLog creates a SARIF log for the findings of the linter.
+//
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+func lintSARIFLog(findings []*lintFinding) map[string]any {
+	rules := []map[string]any{}
+
+	for _, rule := range lintRules {
+		rules = append(rules, map[string]any{
+			"id":               rule.ID,
+			"shortDescription": map[string]any{"text": rule.Description},
+			"defaultConfiguration": map[string]any{
+				"level": rule.Severity,
+			},
+		})
+	}
+
+	results := []map[string]any{}
+
+	for _, finding := range findings {
+		results = append(results, map[string]any{
+			"ruleId":  finding.Rule,
+			"level":   finding.Severity,
+			"message": map[string]any{"text": finding.Message},
+			"locations": []map[string]any{
+				{
+					"physicalLocation": map[string]any{
+						"artifactLocation": map[string]any{"uri": finding.File},
+						"region": map[string]any{
+							"startLine":   finding.Line,
+							"startColumn": finding.Column,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []map[string]any{
+			{
+				"tool": map[string]any{
+					"driver": map[string]any{
+						"name":           "vela-lint",
+						"informationUri": "https://go-vela.github.io/docs/reference/cli/pipeline/lint/",
+						"rules":          rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+}
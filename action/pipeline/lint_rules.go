@@ -0,0 +1,462 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/server/constants"
+)
+
+// lint rules for a pipeline.
+const (
+	lintUnpinnedImage       = "unpinned-image"
+	lintPrivilegedImage     = "privileged-image"
+	lintSecretInEnvironment = "secret-in-environment"
+	lintPullPolicy          = "pull-policy"
+	lintUnusedSecret        = "unused-secret"
+	lintDuplicateStepName   = "duplicate-step-name"
+	lintBroadRuleset        = "broad-ruleset"
+	lintDeprecatedPull      = "deprecated-pull"
+)
+
+// lintRule represents a rule of the linter.
+type lintRule struct {
+	ID          string
+	Severity    string
+	Description string
+}
+
+// lintRules are the rules of the linter with their default severities.
+var lintRules = []*lintRule{
+	{lintUnpinnedImage, lintWarning, "images should be pinned to a digest instead of a mutable tag"},
+	{lintPrivilegedImage, lintWarning, "images that run privileged have full access to the host of the worker"},
+	{lintSecretInEnvironment, lintError, "secrets should be provided with secrets instead of environment"},
+	{lintPullPolicy, lintWarning, "images with mutable tags should be pulled with pull: always"},
+	{lintUnusedSecret, lintWarning, "secrets declared in the pipeline should be used by a container"},
+	{lintDuplicateStepName, lintError, "names of steps and services should be unique"},
+	{lintBroadRuleset, lintNote, "rulesets should not use patterns that match every value"},
+	{lintDeprecatedPull, lintWarning, "pull: true and pull: false are deprecated and should be replaced with a pull policy"},
+}
+
+// lintPrivilegedImages are the default images that run privileged.
+var lintPrivilegedImages = []string{
+	"docker:*dind*",
+	"target/vela-docker*",
+	"target/vela-kaniko*",
+}
+
+// lintSecretPatterns are the default patterns for the
+// names of environment variables that contain a secret.
+var lintSecretPatterns = []string{
+	`(?i)(password|passwd|secret|token|api_?key|private_?key|credential)`,
+}
+
+// lintBroadPatterns are patterns in a ruleset that match every value.
+var lintBroadPatterns = []string{"*", "**", ".*", ".+", "^.*$", "^.+$"}
+
+// lintRulesetFields are the fields of a ruleset that contain patterns.
+var lintRulesetFields = []string{"branch", "comment", "event", "path", "repo", "sender", "status", "tag", "target", "label", "instance"}
+
+// lintRuleByID returns the rule of the linter with the provided ID.
+func lintRuleByID(id string) *lintRule {
+	for _, rule := range lintRules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+// linter captures the findings for a pipeline.
+type linter struct {
+	config   *lintConfig
+	findings []*lintFinding
+}
+
+// lintContainer represents a step, service or
+// secret origin of a pipeline that is linted.
+type lintContainer struct {
+	Name string
+	Node *yaml.Node
+	Step bool
+}
+
+// lint checks the provided pipeline against the rules
+// of the linter based off the provided configuration.
+func lint(data []byte, config *lintConfig) ([]*lintFinding, error) {
+	root := new(yaml.Node)
+
+	err := yaml.Unmarshal(data, root)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := yamlResolve(root)
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = yamlResolve(doc.Content[0])
+	}
+
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("pipeline is not a mapping")
+	}
+
+	l := &linter{config: config}
+
+	containers := l.containers(doc)
+
+	for _, ctn := range containers {
+		l.image(ctn)
+		l.environment(ctn.Name, yamlMapValue(ctn.Node, "environment"))
+		l.deprecated(ctn)
+
+		if ctn.Step {
+			l.ruleset(ctn.Name, yamlMapValue(ctn.Node, "ruleset"))
+		}
+	}
+
+	l.environment("", yamlMapValue(doc, "environment"))
+
+	stages := yamlMapValue(doc, "stages")
+	if stages != nil && stages.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(stages.Content); i += 2 {
+			l.environment("", yamlMapValue(yamlResolve(stages.Content[i+1]), "environment"))
+		}
+	}
+
+	l.secrets(doc, containers)
+
+	return l.findings, nil
+}
+
+// report adds a finding for the rule on the provided node
+// when the rule is not disabled by the configuration.
+func (l *linter) report(rule string, node *yaml.Node, step, format string, args ...any) {
+	severity := l.config.severity(rule)
+	if severity == lintOff {
+		return
+	}
+
+	finding := &lintFinding{
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Step:     step,
+	}
+
+	if node != nil {
+		finding.Line = node.Line
+		finding.Column = node.Column
+	}
+
+	l.findings = append(l.findings, finding)
+}
+
+// containers returns the steps, services and secret origins of the
+// pipeline and checks the names of the steps and services are unique.
+func (l *linter) containers(doc *yaml.Node) []*lintContainer {
+	containers := []*lintContainer{}
+
+	list := func(prefix string, node *yaml.Node, step bool) {
+		if node == nil || node.Kind != yaml.SequenceNode {
+			return
+		}
+
+		names := map[string]int{}
+
+		for _, item := range node.Content {
+			item = yamlResolve(item)
+
+			nameNode := yamlMapValue(item, "name")
+
+			name := ""
+			if nameNode != nil {
+				name = nameNode.Value
+			}
+
+			if nameNode != nil && len(name) > 0 {
+				if line, ok := names[name]; ok {
+					l.report(lintDuplicateStepName, nameNode, prefix+name, "%s is already defined on line %d", name, line)
+				} else {
+					names[name] = nameNode.Line
+				}
+			}
+
+			containers = append(containers, &lintContainer{Name: prefix + name, Node: item, Step: step})
+		}
+	}
+
+	list("", yamlMapValue(doc, "steps"), true)
+
+	stages := yamlMapValue(doc, "stages")
+	if stages != nil && stages.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(stages.Content); i += 2 {
+			list(stages.Content[i].Value+":", yamlMapValue(yamlResolve(stages.Content[i+1]), "steps"), true)
+		}
+	}
+
+	list("", yamlMapValue(doc, "services"), false)
+
+	secrets := yamlMapValue(doc, "secrets")
+	if secrets != nil && secrets.Kind == yaml.SequenceNode {
+		for _, item := range secrets.Content {
+			origin := yamlMapValue(yamlResolve(item), "origin")
+			if origin == nil || origin.Kind != yaml.MappingNode {
+				continue
+			}
+
+			name := ""
+			if nameNode := yamlMapValue(origin, "name"); nameNode != nil {
+				name = nameNode.Value
+			}
+
+			containers = append(containers, &lintContainer{Name: name, Node: origin})
+		}
+	}
+
+	return containers
+}
+
+// image checks the image of the container is pinned, pulled
+// when the tag is mutable and does not run privileged.
+func (l *linter) image(ctn *lintContainer) {
+	node := yamlMapValue(ctn.Node, "image")
+	if node == nil || node.Kind != yaml.ScalarNode || len(node.Value) == 0 {
+		return
+	}
+
+	image := node.Value
+
+	// skip images that are rendered by a template or substituted
+	if strings.Contains(image, "{{") || strings.Contains(image, "${") {
+		return
+	}
+
+	tag, digest := lintImageReference(image)
+
+	switch {
+	case digest:
+	case len(tag) == 0 || tag == "latest":
+		l.report(lintUnpinnedImage, node, ctn.Name, "image %s uses the mutable latest tag", image)
+	default:
+		l.report(lintUnpinnedImage, node, ctn.Name, "image %s is not pinned to a digest", image)
+	}
+
+	// any tag can be pushed again, so only a digest is immutable
+	if !digest {
+		pull := yamlMapValue(ctn.Node, "pull")
+
+		switch {
+		case pull == nil:
+			l.report(lintPullPolicy, node, ctn.Name, "image %s uses a mutable tag without pull: always", image)
+		case lintPullPolicyOf(pull.Value) != constants.PullAlways && pull.Value != constants.PullOnStart:
+			l.report(lintPullPolicy, pull, ctn.Name, "image %s uses a mutable tag with pull: %s instead of pull: always", image, pull.Value)
+		}
+	}
+
+	images := l.config.rule(lintPrivilegedImage).Images
+	if len(images) == 0 {
+		images = lintPrivilegedImages
+	}
+
+	// match the images without the default registry
+	full := lintImageName(image)
+	name, _, _ := strings.Cut(full, "@")
+
+	for _, pattern := range images {
+		pattern = lintImageName(pattern)
+
+		matchImage, _ := path.Match(pattern, full)
+		matchName, _ := path.Match(pattern, name)
+
+		if matchImage || matchName {
+			l.report(lintPrivilegedImage, node, ctn.Name, "image %s runs privileged", image)
+
+			break
+		}
+	}
+}
+
+// environment checks the environment does not contain secrets.
+func (l *linter) environment(step string, node *yaml.Node) {
+	if node == nil {
+		return
+	}
+
+	patterns := l.config.rule(lintSecretInEnvironment).Patterns
+	if len(patterns) == 0 {
+		patterns = lintSecretPatterns
+	}
+
+	check := func(keyNode *yaml.Node, key, value string) {
+		// skip empty values and references to other variables
+		if len(value) == 0 || strings.Contains(value, "${") || strings.Contains(value, "$(") {
+			return
+		}
+
+		for _, pattern := range patterns {
+			match, err := regexp.MatchString(pattern, key)
+			if err == nil && match {
+				l.report(lintSecretInEnvironment, keyNode, step, "environment %s looks like a secret, provide it with secrets instead", key)
+
+				return
+			}
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			check(node.Content[i], node.Content[i].Value, yamlResolve(node.Content[i+1]).Value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			key, value, _ := strings.Cut(item.Value, "=")
+
+			check(item, key, value)
+		}
+	}
+}
+
+// ruleset checks the ruleset of the step does not
+// contain patterns that match every value.
+func (l *linter) ruleset(step string, node *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	nodes := []*yaml.Node{node}
+
+	if when := yamlMapValue(node, "if"); when != nil {
+		nodes = append(nodes, when)
+	}
+
+	for _, rules := range nodes {
+		for _, field := range lintRulesetFields {
+			value := yamlMapValue(rules, field)
+			if value == nil {
+				continue
+			}
+
+			values := []*yaml.Node{value}
+			if value.Kind == yaml.SequenceNode {
+				values = value.Content
+			}
+
+			for _, v := range values {
+				if slices.Contains(lintBroadPatterns, v.Value) {
+					l.report(lintBroadRuleset, v, step, "ruleset %s pattern %s matches every %s", field, v.Value, field)
+				}
+			}
+		}
+	}
+}
+
+// deprecated checks the container does not use the deprecated pull values.
+func (l *linter) deprecated(ctn *lintContainer) {
+	// https://pkg.go.dev/github.com/go-vela/server/compiler/types/yaml?tab=doc#Step
+	pull := yamlMapValue(ctn.Node, "pull")
+	if pull != nil && (pull.Value == "true" || pull.Value == "false") {
+		l.report(lintDeprecatedPull, pull, ctn.Name, "pull: %s is deprecated, use pull: %s instead",
+			pull.Value, lintPullPolicyOf(pull.Value))
+	}
+}
+
+// lintPullPolicyOf returns the pull policy for the provided
+// pull value, which the compiler converts for the deprecated
+// boolean values.
+func lintPullPolicyOf(pull string) string {
+	switch pull {
+	case "true":
+		return constants.PullAlways
+	case "false":
+		return constants.PullNotPresent
+	default:
+		return pull
+	}
+}
+
+// secrets checks the secrets declared in the pipeline are
+// used by at least one step, service or secret origin.
+func (l *linter) secrets(doc *yaml.Node, containers []*lintContainer) {
+	secrets := yamlMapValue(doc, "secrets")
+	if secrets == nil || secrets.Kind != yaml.SequenceNode {
+		return
+	}
+
+	// secrets may be used by the steps of templates
+	if yamlMapValue(doc, "templates") != nil {
+		return
+	}
+
+	used := map[string]bool{}
+
+	for _, ctn := range containers {
+		if yamlMapValue(ctn.Node, "template") != nil {
+			return
+		}
+
+		list := yamlMapValue(ctn.Node, "secrets")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for _, item := range list.Content {
+			item = yamlResolve(item)
+
+			switch item.Kind {
+			case yaml.ScalarNode:
+				used[item.Value] = true
+			case yaml.MappingNode:
+				if source := yamlMapValue(item, "source"); source != nil {
+					used[source.Value] = true
+				}
+			}
+		}
+	}
+
+	for _, item := range secrets.Content {
+		name := yamlMapValue(yamlResolve(item), "name")
+		if name == nil || used[name.Value] {
+			continue
+		}
+
+		l.report(lintUnusedSecret, name, "", "secret %s is not used by any step, service or secret origin", name.Value)
+	}
+}
+
+// lintImageName returns the image without the prefix of the
+// default registry, and the library namespace of its official
+// images, as images are referenced with or without them.
+func lintImageName(image string) string {
+	for _, registry := range []string{"docker.io/", "index.docker.io/", "registry-1.docker.io/"} {
+		if name, ok := strings.CutPrefix(image, registry); ok {
+			image = name
+
+			break
+		}
+	}
+
+	return strings.TrimPrefix(image, "library/")
+}
+
+// lintImageReference returns the tag of the image
+// and if the image is pinned to a digest.
+func lintImageReference(image string) (string, bool) {
+	if strings.Contains(image, "@") {
+		return "", true
+	}
+
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	_, tag, _ := strings.Cut(name, ":")
+
+	return tag, false
+}
@@ -0,0 +1,400 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPipeline_Config_Lint(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+	}{
+		{
+			name:    "default",
+			failure: true,
+			config: &Config{
+				Action: "lint",
+				File:   ".vela.yml",
+				Path:   "testdata/lint",
+			},
+		},
+		{
+			name:    "fail on none",
+			failure: false,
+			config: &Config{
+				Action: "lint",
+				File:   ".vela.yml",
+				Path:   "testdata/lint",
+				FailOn: "none",
+				Output: "json",
+			},
+		},
+		{
+			name:    "sarif output",
+			failure: false,
+			config: &Config{
+				Action: "lint",
+				File:   ".vela.yml",
+				Path:   "testdata/lint",
+				FailOn: "none",
+				Output: "sarif",
+			},
+		},
+		{
+			name:    "default pipeline",
+			failure: false,
+			config: &Config{
+				Action: "lint",
+				File:   "default.yml",
+				Path:   "testdata",
+				FailOn: "none",
+			},
+		},
+		{
+			name:    "missing lint configuration",
+			failure: true,
+			config: &Config{
+				Action:     "lint",
+				File:       ".vela.yml",
+				Path:       "testdata/lint",
+				LintConfig: "notfound.yml",
+			},
+		},
+		{
+			name:    "missing pipeline",
+			failure: true,
+			config: &Config{
+				Action: "lint",
+				File:   "notfound.yml",
+				Path:   "testdata/lint",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Lint()
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Lint should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Lint returned err: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_lint(t *testing.T) {
+	// setup types
+	data, err := os.ReadFile(filepath.Join("testdata", "lint", ".vela.yml"))
+	if err != nil {
+		t.Fatalf("unable to read pipeline: %v", err)
+	}
+
+	want := []string{
+		"duplicate-step-name:error:28:11:test",
+		"unpinned-image:warning:19:12:test",
+		"pull-policy:warning:19:12:test",
+		"broad-ruleset:note:24:15:test",
+		"deprecated-pull:warning:30:11:test",
+		"unpinned-image:warning:35:12:publish",
+		"privileged-image:warning:35:12:publish",
+		"broad-ruleset:note:41:14:publish",
+		"secret-in-environment:error:4:3:",
+		"unused-secret:warning:12:11:",
+	}
+
+	// run test
+	findings, err := lint(data, new(lintConfig))
+	if err != nil {
+		t.Errorf("lint returned err: %v", err)
+	}
+
+	got := []string{}
+
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%s:%s:%d:%d:%s", f.Rule, f.Severity, f.Line, f.Column, f.Step))
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lint is %v, want %v", got, want)
+	}
+}
+
+func TestPipeline_lint_Config(t *testing.T) {
+	// setup types
+	pipeline := `
+steps:
+  - name: build
+    image: alpine:3.20
+  - name: publish
+    image: docker:27-dind
+`
+
+	config := &lintConfig{
+		Rules: map[string]*lintRuleConfig{
+			lintUnpinnedImage:   {Severity: lintOff},
+			lintPrivilegedImage: {Severity: lintError, Images: []string{"docker.io/library/docker:*"}},
+		},
+	}
+
+	want := []string{
+		"pull-policy:warning:image alpine:3.20 uses a mutable tag without pull: always",
+		"pull-policy:warning:image docker:27-dind uses a mutable tag without pull: always",
+		"privileged-image:error:image docker:27-dind runs privileged",
+	}
+
+	// run test
+	findings, err := lint([]byte(pipeline), config)
+	if err != nil {
+		t.Errorf("lint returned err: %v", err)
+	}
+
+	got := []string{}
+
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%s:%s:%s", f.Rule, f.Severity, f.Message))
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lint is %v, want %v", got, want)
+	}
+}
+
+func TestPipeline_lint_Pull(t *testing.T) {
+	// setup types
+	config := &lintConfig{
+		Rules: map[string]*lintRuleConfig{
+			lintUnpinnedImage: {Severity: lintOff},
+		},
+	}
+
+	// setup tests
+	tests := []struct {
+		name  string
+		image string
+		pull  string
+		want  []string
+	}{
+		{
+			name:  "mutable tag without pull",
+			image: "alpine:3.20",
+			want:  []string{lintPullPolicy},
+		},
+		{
+			name:  "mutable tag with pull always",
+			image: "alpine:3.20",
+			pull:  "always",
+			want:  []string{},
+		},
+		{
+			name:  "mutable tag with pull on start",
+			image: "alpine:3.20",
+			pull:  "on_start",
+			want:  []string{},
+		},
+		{
+			name:  "mutable tag with pull not present",
+			image: "alpine:3.20",
+			pull:  "not_present",
+			want:  []string{lintPullPolicy},
+		},
+		{
+			name:  "mutable tag with deprecated pull true",
+			image: "alpine:3.20",
+			pull:  "true",
+			want:  []string{lintDeprecatedPull},
+		},
+		{
+			name:  "mutable tag with deprecated pull false",
+			image: "alpine:3.20",
+			pull:  "false",
+			want:  []string{lintPullPolicy, lintDeprecatedPull},
+		},
+		{
+			name:  "digest without pull",
+			image: "alpine@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			want:  []string{},
+		},
+		{
+			name:  "digest with deprecated pull true",
+			image: "alpine@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			pull:  "true",
+			want:  []string{lintDeprecatedPull},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pipeline := fmt.Sprintf("steps:\n  - name: test\n    image: %s\n", test.image)
+			if len(test.pull) > 0 {
+				pipeline += fmt.Sprintf("    pull: %s\n", test.pull)
+			}
+
+			findings, err := lint([]byte(pipeline), config)
+			if err != nil {
+				t.Errorf("lint returned err: %v", err)
+			}
+
+			got := []string{}
+
+			for _, f := range findings {
+				got = append(got, f.Rule)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("lint is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_lint_Anchors(t *testing.T) {
+	// setup types
+	pipeline := `
+aliases:
+  defaults: &defaults
+    image: golang:1.26@sha256:0000000000000000000000000000000000000000000000000000000000000000
+    environment:
+      DB_PASSWORD: hunter2
+
+steps:
+  - name: test
+    <<: *defaults
+    commands:
+      - go test ./...
+`
+
+	// run test
+	findings, err := lint([]byte(pipeline), new(lintConfig))
+	if err != nil {
+		t.Errorf("lint returned err: %v", err)
+	}
+
+	if len(findings) != 1 || findings[0].Rule != lintSecretInEnvironment || findings[0].Step != "test" {
+		t.Errorf("lint is %v, want a single %s finding for test", findings, lintSecretInEnvironment)
+	}
+}
+
+func TestPipeline_lintConfig_suppressed(t *testing.T) {
+	// setup types
+	config := &lintConfig{
+		Suppressions: []*lintSuppression{
+			{Rule: lintUnpinnedImage, Step: "test*"},
+			{Rule: "*", File: "nested/*.yml"},
+		},
+	}
+
+	lines := strings.Split("steps:\n  image: alpine # vela-lint-ignore pull-policy, unpinned-image\n  image: alpine # vela-lint-ignore", "\n")
+
+	// setup tests
+	tests := []struct {
+		finding *lintFinding
+		want    bool
+	}{
+		{finding: &lintFinding{Rule: lintUnpinnedImage, Step: "test-unit", File: ".vela.yml"}, want: true},
+		{finding: &lintFinding{Rule: lintUnpinnedImage, Step: "build", File: ".vela.yml"}, want: false},
+		{finding: &lintFinding{Rule: lintPullPolicy, Step: "build", File: "nested/.vela.yml"}, want: true},
+		{finding: &lintFinding{Rule: lintPullPolicy, Step: "build", File: ".vela.yml", Line: 2}, want: true},
+		{finding: &lintFinding{Rule: lintPrivilegedImage, Step: "build", File: ".vela.yml", Line: 2}, want: false},
+		{finding: &lintFinding{Rule: lintPrivilegedImage, Step: "build", File: ".vela.yml", Line: 3}, want: true},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := config.suppressed(test.finding, lines)
+
+		if got != test.want {
+			t.Errorf("suppressed for %v is %v, want %v", test.finding, got, test.want)
+		}
+	}
+}
+
+func TestPipeline_lintImageReference(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		image  string
+		tag    string
+		digest bool
+	}{
+		{image: "alpine", tag: "", digest: false},
+		{image: "alpine:latest", tag: "latest", digest: false},
+		{image: "localhost:5000/team/alpine", tag: "", digest: false},
+		{image: "localhost:5000/team/alpine:3.20", tag: "3.20", digest: false},
+		{image: "alpine:3.20@sha256:abc", tag: "", digest: true},
+	}
+
+	// run tests
+	for _, test := range tests {
+		tag, digest := lintImageReference(test.image)
+
+		if tag != test.tag || digest != test.digest {
+			t.Errorf("lintImageReference for %s is %s, %v, want %s, %v", test.image, tag, digest, test.tag, test.digest)
+		}
+	}
+}
+
+func TestPipeline_lintImageName(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "target/vela-docker:latest", want: "target/vela-docker:latest"},
+		{image: "docker.io/target/vela-docker:latest", want: "target/vela-docker:latest"},
+		{image: "index.docker.io/target/vela-kaniko", want: "target/vela-kaniko"},
+		{image: "docker.io/library/docker:27-dind", want: "docker:27-dind"},
+		{image: "ghcr.io/target/vela-docker:latest", want: "ghcr.io/target/vela-docker:latest"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := lintImageName(test.image)
+
+		if got != test.want {
+			t.Errorf("lintImageName for %s is %s, want %s", test.image, got, test.want)
+		}
+	}
+}
+
+func TestPipeline_lintFails(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		severity string
+		failOn   string
+		want     bool
+	}{
+		{severity: lintError, failOn: "", want: true},
+		{severity: lintWarning, failOn: "", want: false},
+		{severity: lintWarning, failOn: lintWarning, want: true},
+		{severity: lintNote, failOn: lintWarning, want: false},
+		{severity: lintNote, failOn: lintNote, want: true},
+		{severity: lintError, failOn: lintNone, want: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := lintFails(test.severity, test.failOn)
+
+		if got != test.want {
+			t.Errorf("lintFails for %s and %s is %v, want %v", test.severity, test.failOn, got, test.want)
+		}
+	}
+}
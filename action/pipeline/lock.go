@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/server/constants"
+)
+
+// lockedPipeline returns the pipeline to compile for the provided path,
+// with its remote templates pinned to the commits for the pipeline in
+// the lockfile next to it. The path is returned when there is no
+// lockfile to honor.
+func (c *Config) lockedPipeline(path string) (any, error) {
+	// only yaml pipelines declare templates that can be pinned
+	if len(c.PipelineType) > 0 && c.PipelineType != constants.PipelineTypeYAML {
+		return path, nil
+	}
+
+	lock, err := internal.ReadLock(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if lock == nil {
+		return path, nil
+	}
+
+	logrus.Debugf("pinning templates for pipeline %s with %s", path, internal.LockFile)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pipeline %s: %w", path, err)
+	}
+
+	pins := lock.Pipeline(filepath.Base(path))
+	if pins == nil {
+		// report the templates of the pipeline as not pinned
+		pins = new(internal.PipelineLock)
+	}
+
+	pinned, err := internal.PinTemplates(data, pins)
+	if err != nil {
+		// leave reporting the invalid pipeline to the compiler
+		logrus.Debugf("unable to pin templates for pipeline %s: %v", path, err)
+
+		return path, nil
+	}
+
+	return pinned, nil
+}
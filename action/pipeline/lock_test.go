@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipeline_Config_lockedPipeline(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		config *Config
+		path   string
+		want   string
+	}{
+		{
+			name:   "pipeline with lockfile",
+			config: &Config{PipelineType: "yaml"},
+			path:   "testdata/lock/.vela.yml",
+			want:   "github.com/octocat/templates/go.yml@7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		},
+		{
+			name:   "pipeline without lockfile",
+			config: &Config{PipelineType: "yaml"},
+			path:   "testdata/default.yml",
+		},
+		{
+			name:   "starlark pipeline with lockfile",
+			config: &Config{PipelineType: "starlark"},
+			path:   "testdata/lock/.vela.yml",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.config.lockedPipeline(test.path)
+			if err != nil {
+				t.Errorf("lockedPipeline returned err: %v", err)
+			}
+
+			// check if the path should be returned for the compiler to read
+			if len(test.want) == 0 {
+				if got != test.path {
+					t.Errorf("lockedPipeline is %v, want %s", got, test.path)
+				}
+
+				return
+			}
+
+			pinned, ok := got.([]byte)
+			if !ok {
+				t.Fatalf("lockedPipeline is %v, want pinned pipeline", got)
+			}
+
+			if !strings.Contains(string(pinned), test.want) {
+				t.Errorf("lockedPipeline is %s, want source %s", pinned, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/worker/executor"
+)
+
+// maskReplacement is the value written in place of secrets.
+const maskReplacement = "***"
+
+// masker redacts a set of secret values from text.
+type masker struct {
+	replacer *strings.Replacer
+}
+
+// newMasker creates a masker for the provided secret values.
+//
+// Every value is expanded into the variants it is likely
+// to be printed as, which includes the base64 and URL
+// encoded forms as well as each line of multi-line values.
+func newMasker(values []string) *masker {
+	variants := make(map[string]struct{})
+
+	for _, value := range values {
+		for _, v := range maskVariants(value) {
+			variants[v] = struct{}{}
+		}
+	}
+
+	secrets := make([]string, 0, len(variants))
+	for v := range variants {
+		secrets = append(secrets, v)
+	}
+
+	// sort the secrets longest first so the replacer
+	// prefers the largest match at a given position
+	sort.SliceStable(secrets, func(i, j int) bool {
+		if len(secrets[i]) == len(secrets[j]) {
+			return secrets[i] < secrets[j]
+		}
+
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	pairs := make([]string, 0, len(secrets)*2)
+	for _, s := range secrets {
+		pairs = append(pairs, s, maskReplacement)
+	}
+
+	return &masker{replacer: strings.NewReplacer(pairs...)}
+}
+
+// Mask returns the provided text with all secrets redacted.
+func (m *masker) Mask(s string) string {
+	if m == nil {
+		return s
+	}
+
+	return m.replacer.Replace(s)
+}
+
+// Copy reads from src and writes the masked
+// content to dst until src is exhausted.
+func (m *masker) Copy(dst io.Writer, src io.Reader) error {
+	w := newMaskWriter(m, dst)
+
+	_, err := io.Copy(w, src)
+
+	return errors.Join(err, w.Close())
+}
+
+// maskWriter is an io.WriteCloser that redacts secrets
+// from the content before writing it to the underlying writer.
+//
+// Content is held back until a line break (\n or \r) is written,
+// so a progress bar redrawing its line is flushed on every update.
+// Secret values are masked by the line they are printed on and never
+// contain a line break, so holding back the content until the next
+// line break ensures a secret is not split between two writes.
+type maskWriter struct {
+	mu      sync.Mutex
+	masker  *masker
+	writer  io.Writer
+	pending []byte
+}
+
+// newMaskWriter creates a maskWriter for the provided writer.
+func newMaskWriter(m *masker, w io.Writer) *maskWriter {
+	return &maskWriter{masker: m, writer: w}
+}
+
+// Write masks all complete lines of the content
+// and writes them to the underlying writer.
+func (w *maskWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, p...)
+
+	i := bytes.LastIndexAny(w.pending, "\r\n")
+	if i < 0 {
+		return len(p), nil
+	}
+
+	return len(p), w.flush(i + 1)
+}
+
+// Close masks and writes any remaining content
+// that was not terminated by a line break.
+func (w *maskWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.flush(len(w.pending))
+}
+
+// flush masks and writes the first n bytes of the pending content.
+func (w *maskWriter) flush(n int) error {
+	if n == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(w.writer, w.masker.Mask(string(w.pending[:n])))
+
+	w.pending = append(w.pending[:0], w.pending[n:]...)
+
+	return err
+}
+
+// maskVariants returns the forms a secret value
+// may take when it is printed by a step.
+func maskVariants(value string) []string {
+	value = strings.TrimRight(value, "\r\n")
+
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil
+	}
+
+	candidates := []string{
+		value,
+		base64.StdEncoding.EncodeToString([]byte(value)),
+		base64.RawStdEncoding.EncodeToString([]byte(value)),
+		base64.URLEncoding.EncodeToString([]byte(value)),
+		url.QueryEscape(value),
+		url.PathEscape(value),
+	}
+
+	// multi-line values are written one line at a
+	// time so each line must be masked on its own
+	if strings.ContainsAny(value, "\r\n") {
+		candidates = append(candidates, strings.FieldsFunc(value, isLineBreak)...)
+	}
+
+	variants := make([]string, 0, len(candidates))
+
+	for _, c := range candidates {
+		if len(strings.TrimSpace(c)) == 0 {
+			continue
+		}
+
+		variants = append(variants, c)
+	}
+
+	return variants
+}
+
+// collectSecretValues searches a given pipeline for used secrets
+// and returns the values provided for them in the current environment.
+func collectSecretValues(p *pipeline.Build) []string {
+	if p == nil {
+		return nil
+	}
+
+	targets := make(map[string]struct{})
+
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			for _, secret := range step.Secrets {
+				targets[secret.Target] = struct{}{}
+			}
+		}
+	}
+
+	for _, step := range p.Steps {
+		for _, secret := range step.Secrets {
+			targets[secret.Target] = struct{}{}
+		}
+	}
+
+	for _, s := range p.Secrets {
+		if !s.Origin.Empty() {
+			for _, secret := range s.Origin.Secrets {
+				targets[secret.Target] = struct{}{}
+			}
+		}
+	}
+
+	values := []string{}
+
+	for target := range targets {
+		val, exists := os.LookupEnv(target)
+		if exists && len(val) > 0 {
+			values = append(values, val)
+		}
+	}
+
+	return values
+}
+
+// isLineBreak reports whether the rune ends a line of output.
+func isLineBreak(r rune) bool {
+	return r == '\n' || r == '\r'
+}
+
+// newMaskedExecutor creates the executor for the build
+// with its output masked and written to the provided writer.
+//
+// The local executor has no option for the writer of the build
+// output and captures os.Stdout when it is created instead, so
+// os.Stdout is only replaced with a pipe while creating it. The
+// returned function must be invoked once the build is destroyed
+// to write the remaining output of the executor.
+func newMaskedExecutor(s *executor.Setup, m *masker, w io.Writer) (executor.Engine, func(), error) {
+	r, pw, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stdout := os.Stdout
+	os.Stdout = pw
+
+	_executor, err := executor.New(s)
+
+	os.Stdout = stdout
+
+	if err != nil {
+		pw.Close()
+		r.Close()
+
+		return nil, nil, err
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		err := m.Copy(w, r)
+		if err != nil {
+			logrus.Errorf("unable to mask build output: %v", err)
+
+			// drain the remaining output to avoid blocking
+			// writers without leaking any unmasked values
+			_, _ = io.Copy(io.Discard, r)
+		}
+	}()
+
+	return _executor, func() {
+		pw.Close()
+		<-done
+		r.Close()
+	}, nil
+}
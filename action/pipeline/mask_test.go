@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+)
+
+func TestPipeline_Masker_Mask(t *testing.T) {
+	// setup types
+	secret := "sup3r$ecret value"
+	multi := "-----BEGIN KEY-----\nabc123\n-----END KEY-----"
+
+	m := newMasker([]string{secret, multi, "", "  "})
+
+	// setup tests
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "no secret",
+			input: "hello world\n",
+			want:  "hello world\n",
+		},
+		{
+			name:  "plain secret",
+			input: "password is " + secret + "\n",
+			want:  "password is ***\n",
+		},
+		{
+			name:  "base64 secret",
+			input: base64.StdEncoding.EncodeToString([]byte(secret)),
+			want:  "***",
+		},
+		{
+			name:  "url encoded secret",
+			input: "https://example.com/?token=" + url.QueryEscape(secret),
+			want:  "https://example.com/?token=***",
+		},
+		{
+			name:  "multi-line secret line",
+			input: "$ cat key\nabc123\n",
+			want:  "$ cat key\n***\n",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := m.Mask(test.input)
+
+			if got != test.want {
+				t.Errorf("Mask is %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_Masker_Copy(t *testing.T) {
+	// setup types
+	m := newMasker([]string{"foo"})
+
+	input := "line one foo\nline two\npartial foo"
+
+	var buf bytes.Buffer
+
+	// run test
+	err := m.Copy(&buf, strings.NewReader(input))
+	if err != nil {
+		t.Errorf("Copy returned err: %v", err)
+	}
+
+	want := "line one ***\nline two\npartial ***"
+
+	if buf.String() != want {
+		t.Errorf("Copy is %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPipeline_maskWriter(t *testing.T) {
+	// setup types
+	m := newMasker([]string{"foo", "bar\rbaz"})
+
+	// setup tests
+	tests := []struct {
+		name    string
+		writes  []string
+		flushed string
+		want    string
+	}{
+		{
+			name:    "secret split between writes",
+			writes:  []string{"line one f", "oo\nline two"},
+			flushed: "line one ***\n",
+			want:    "line one ***\nline two",
+		},
+		{
+			name:    "progress bar",
+			writes:  []string{"10% foo\r", "50% foo\r", "100% f"},
+			flushed: "10% ***\r50% ***\r",
+			want:    "10% ***\r50% ***\r100% f",
+		},
+		{
+			name:    "no trailing newline",
+			writes:  []string{"partial ", "foo"},
+			flushed: "",
+			want:    "partial ***",
+		},
+		{
+			name:    "secret with carriage return",
+			writes:  []string{"bar\r", "baz\n"},
+			flushed: "***\r***\n",
+			want:    "***\r***\n",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w := newMaskWriter(m, &buf)
+
+			for _, write := range test.writes {
+				n, err := w.Write([]byte(write))
+				if err != nil {
+					t.Errorf("Write returned err: %v", err)
+				}
+
+				if n != len(write) {
+					t.Errorf("Write is %d, want %d", n, len(write))
+				}
+			}
+
+			if buf.String() != test.flushed {
+				t.Errorf("Write flushed %q, want %q", buf.String(), test.flushed)
+			}
+
+			err := w.Close()
+			if err != nil {
+				t.Errorf("Close returned err: %v", err)
+			}
+
+			if buf.String() != test.want {
+				t.Errorf("Close is %q, want %q", buf.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_collectSecretValues(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		Steps: []*pipeline.Container{
+			{
+				Name: "step1",
+				Secrets: pipeline.StepSecretSlice{
+					{Source: "source", Target: "STEP_SECRET"},
+					{Source: "source", Target: "UNSET_SECRET"},
+				},
+			},
+		},
+		Stages: []*pipeline.Stage{
+			{
+				Name: "stage1",
+				Steps: []*pipeline.Container{
+					{
+						Name: "step1",
+						Secrets: pipeline.StepSecretSlice{
+							{Source: "source", Target: "STAGE_SECRET"},
+							{Source: "source", Target: "EMPTY_SECRET"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Setenv("STEP_SECRET", "step")
+	t.Setenv("STAGE_SECRET", "stage")
+	t.Setenv("EMPTY_SECRET", "")
+
+	// run test
+	got := collectSecretValues(p)
+
+	slices.Sort(got)
+
+	want := []string{"stage", "step"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("collectSecretValues is %v, want %v", got, want)
+	}
+
+	if collectSecretValues(nil) != nil {
+		t.Errorf("collectSecretValues for nil pipeline should be nil")
+	}
+}
@@ -0,0 +1,289 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gosuri/uitable"
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/worker/runtime"
+)
+
+const (
+	// outputsPath is the path to the file, in the outputs
+	// container, containing the outputs written to $VELA_OUTPUTS.
+	outputsPath = "/vela/outputs/.env"
+
+	// maskedOutputsPath is the path to the file, in the outputs
+	// container, containing the outputs written to $VELA_MASKED_OUTPUTS.
+	maskedOutputsPath = "/vela/outputs/masked.env"
+)
+
+// stepOutput represents an output written by a step.
+type stepOutput struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Masked bool   `json:"masked"`
+	Step   string `json:"step"`
+}
+
+// stepReport represents the result of a step in the report.
+type stepReport struct {
+	Name     string        `json:"name"`
+	Stage    string        `json:"stage,omitempty"`
+	ExitCode int           `json:"exit_code"`
+	Outputs  []*stepOutput `json:"outputs"`
+}
+
+// execReport represents the report of a local build.
+type execReport struct {
+	Steps   []*stepReport `json:"steps"`
+	Outputs []*stepOutput `json:"outputs"`
+}
+
+// outputsRuntime wraps a runtime to capture the outputs
+// written by each step of a local build.
+type outputsRuntime struct {
+	runtime.Engine
+
+	// ctn is the outputs container for the build.
+	ctn *pipeline.Container
+	// stages are the stage names for the steps keyed by container ID.
+	stages map[string]string
+	// out is where the outputs are printed after each step.
+	out io.Writer
+
+	mu      sync.Mutex
+	steps   []*stepReport
+	outputs map[string]*stepOutput
+}
+
+// newOutputsRuntime wraps the provided runtime to capture the outputs
+// written by the steps of the pipeline to the outputs container.
+func newOutputsRuntime(r runtime.Engine, p *pipeline.Build, ctn *pipeline.Container, out io.Writer) *outputsRuntime {
+	stages := make(map[string]string)
+
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			stages[step.ID] = stage.Name
+		}
+	}
+
+	for _, step := range p.Steps {
+		stages[step.ID] = ""
+	}
+
+	return &outputsRuntime{
+		Engine:  r,
+		ctn:     ctn,
+		stages:  stages,
+		out:     out,
+		outputs: make(map[string]*stepOutput),
+	}
+}
+
+// InspectContainer inspects the container and, once a step has
+// completed, prints the outputs written by the step.
+func (r *outputsRuntime) InspectContainer(ctx context.Context, ctn *pipeline.Container) error {
+	err := r.Engine.InspectContainer(ctx, ctn)
+	if err != nil {
+		return err
+	}
+
+	stage, ok := r.stages[ctn.ID]
+	if !ok || ctn.Detach {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	step := &stepReport{
+		Name:     ctn.Name,
+		Stage:    stage,
+		ExitCode: int(ctn.ExitCode),
+		Outputs:  []*stepOutput{},
+	}
+
+	r.steps = append(r.steps, step)
+
+	// check if the build has an outputs container
+	if r.ctn == nil {
+		return nil
+	}
+
+	outputs, err := r.poll(ctx)
+	if err != nil {
+		logrus.Warnf("unable to capture outputs for step %s: %v", ctn.Name, err)
+
+		return nil
+	}
+
+	// capture the outputs added or changed by the step
+	for _, key := range slices.Sorted(maps.Keys(outputs)) {
+		o := outputs[key]
+
+		prev, ok := r.outputs[key]
+		if ok && prev.Value == o.Value && prev.Masked == o.Masked {
+			continue
+		}
+
+		o.Step = ctn.Name
+		r.outputs[key] = o
+		step.Outputs = append(step.Outputs, o)
+
+		fmt.Fprintf(r.out, "%s output %s=%s\n", formatStepIdentifier(stage, ctn.Name, false), o.Key, o.redacted())
+	}
+
+	return nil
+}
+
+// poll captures the outputs from the outputs container.
+func (r *outputsRuntime) poll(ctx context.Context) (map[string]*stepOutput, error) {
+	outputs := make(map[string]*stepOutput)
+
+	for _, path := range []string{outputsPath, maskedOutputsPath} {
+		// https://pkg.go.dev/github.com/go-vela/worker/runtime?tab=doc#Engine
+		data, err := r.Engine.PollOutputsContainer(ctx, r.ctn, path)
+		if err != nil {
+			return nil, err
+		}
+
+		// https://pkg.go.dev/github.com/joho/godotenv?tab=doc#UnmarshalBytes
+		env, err := godotenv.UnmarshalBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse outputs from %s: %w", path, err)
+		}
+
+		for key, value := range env {
+			outputs[key] = &stepOutput{
+				Key:    key,
+				Value:  value,
+				Masked: path == maskedOutputsPath,
+			}
+		}
+	}
+
+	return outputs, nil
+}
+
+// report returns the report of the steps and outputs captured
+// for the build, with masked values and secrets redacted.
+func (r *outputsRuntime) report(m *masker) *execReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	redact := func(outputs []*stepOutput) []*stepOutput {
+		redacted := []*stepOutput{}
+
+		for _, o := range outputs {
+			redacted = append(redacted, &stepOutput{
+				Key:    o.Key,
+				Value:  m.Mask(o.redacted()),
+				Masked: o.Masked,
+				Step:   o.Step,
+			})
+		}
+
+		return redacted
+	}
+
+	report := &execReport{
+		Steps: []*stepReport{},
+		Outputs: redact(slices.SortedFunc(maps.Values(r.outputs), func(a, b *stepOutput) int {
+			return strings.Compare(a.Key, b.Key)
+		})),
+	}
+
+	for _, step := range r.steps {
+		report.Steps = append(report.Steps, &stepReport{
+			Name:     step.Name,
+			Stage:    step.Stage,
+			ExitCode: step.ExitCode,
+			Outputs:  redact(step.Outputs),
+		})
+	}
+
+	return report
+}
+
+// reportOutputs prints the outputs captured for the
+// build, or the report of the build when requested.
+func (c *Config) reportOutputs(r *outputsRuntime, m *masker) error {
+	report := r.report(m)
+
+	// handle the report format based off the provided configuration
+	switch c.Report {
+	case output.DriverJSON:
+		// output the report in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(report, c.Color)
+	default:
+		if len(report.Outputs) == 0 {
+			return nil
+		}
+
+		// output the outputs in table format
+		return outputsTable(report.Outputs)
+	}
+}
+
+// redacted returns the value of the output, redacting masked values.
+func (o *stepOutput) redacted() string {
+	if o.Masked {
+		return maskReplacement
+	}
+
+	return o.Value
+}
+
+// outputsTable is a helper function to output the
+// provided outputs of a build in a table.
+func outputsTable(outputs []*stepOutput) error {
+	logrus.Debug("creating table for step outputs")
+
+	// create a new table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for table to 50
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 50
+
+	// ensure the table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	// set of output fields we display in a table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("OUTPUT", "VALUE", "STEP")
+
+	// iterate through all outputs in the list
+	for _, o := range outputs {
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(o.Key, o.Value, o.Step)
+	}
+
+	// output the table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
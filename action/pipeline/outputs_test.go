@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/worker/runtime"
+)
+
+// testOutputsEngine is a runtime with an outputs
+// container returning the provided files.
+type testOutputsEngine struct {
+	runtime.Engine
+
+	files map[string]string
+}
+
+func (e *testOutputsEngine) InspectContainer(context.Context, *pipeline.Container) error {
+	return nil
+}
+
+func (e *testOutputsEngine) PollOutputsContainer(_ context.Context, _ *pipeline.Container, path string) ([]byte, error) {
+	return []byte(e.files[path]), nil
+}
+
+func TestPipeline_outputsRuntime_InspectContainer(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		Stages: pipeline.StageSlice{
+			{
+				Name: "test",
+				Steps: pipeline.ContainerSlice{
+					{ID: "github_octocat_1_test_version", Name: "version"},
+					{ID: "github_octocat_1_test_publish", Name: "publish"},
+				},
+			},
+		},
+		Services: pipeline.ContainerSlice{
+			{ID: "service_github_octocat_1_postgres", Name: "postgres"},
+		},
+	}
+
+	engine := &testOutputsEngine{files: map[string]string{}}
+	out := new(bytes.Buffer)
+
+	r := newOutputsRuntime(engine, p, &pipeline.Container{ID: "outputs_github_octocat"}, out)
+
+	// run test
+	engine.files[outputsPath] = "VERSION=1.2.3\n"
+	engine.files[maskedOutputsPath] = "TOKEN=superSecret\n"
+
+	err := r.InspectContainer(context.Background(), p.Stages[0].Steps[0])
+	if err != nil {
+		t.Errorf("InspectContainer returned err: %v", err)
+	}
+
+	engine.files[outputsPath] = "VERSION=1.2.3\nDIGEST=sha256:abc\n"
+
+	p.Stages[0].Steps[1].ExitCode = 1
+
+	err = r.InspectContainer(context.Background(), p.Stages[0].Steps[1])
+	if err != nil {
+		t.Errorf("InspectContainer returned err: %v", err)
+	}
+
+	err = r.InspectContainer(context.Background(), p.Services[0])
+	if err != nil {
+		t.Errorf("InspectContainer returned err: %v", err)
+	}
+
+	wantOut := "[stage: test][step: version] output TOKEN=***\n" +
+		"[stage: test][step: version] output VERSION=1.2.3\n" +
+		"[stage: test][step: publish] output DIGEST=sha256:abc\n"
+
+	if out.String() != wantOut {
+		t.Errorf("InspectContainer output is %q, want %q", out.String(), wantOut)
+	}
+
+	want := &execReport{
+		Steps: []*stepReport{
+			{
+				Name:  "version",
+				Stage: "test",
+				Outputs: []*stepOutput{
+					{Key: "TOKEN", Value: "***", Masked: true, Step: "version"},
+					{Key: "VERSION", Value: "1.2.3", Step: "version"},
+				},
+			},
+			{
+				Name:     "publish",
+				Stage:    "test",
+				ExitCode: 1,
+				Outputs: []*stepOutput{
+					{Key: "DIGEST", Value: "sha256:abc", Step: "publish"},
+				},
+			},
+		},
+		Outputs: []*stepOutput{
+			{Key: "DIGEST", Value: "sha256:abc", Step: "publish"},
+			{Key: "TOKEN", Value: "***", Masked: true, Step: "version"},
+			{Key: "VERSION", Value: "1.2.3", Step: "version"},
+		},
+	}
+
+	got := r.report(nil)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("report is %v, want %v", got, want)
+	}
+}
+
+func TestPipeline_outputsRuntime_InspectContainer_NoOutputs(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		Steps: pipeline.ContainerSlice{
+			{ID: "step_github_octocat_1_test", Name: "test"},
+		},
+	}
+
+	out := new(bytes.Buffer)
+
+	r := newOutputsRuntime(&testOutputsEngine{}, p, nil, out)
+
+	// run test
+	err := r.InspectContainer(context.Background(), p.Steps[0])
+	if err != nil {
+		t.Errorf("InspectContainer returned err: %v", err)
+	}
+
+	if out.Len() > 0 {
+		t.Errorf("InspectContainer output is %q, want none", out.String())
+	}
+
+	want := &execReport{
+		Steps:   []*stepReport{{Name: "test", Outputs: []*stepOutput{}}},
+		Outputs: []*stepOutput{},
+	}
+
+	got := r.report(nil)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("report is %v, want %v", got, want)
+	}
+}
+
+func TestPipeline_Config_reportOutputs(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		Steps: pipeline.ContainerSlice{
+			{ID: "step_github_octocat_1_test", Name: "test"},
+		},
+	}
+
+	engine := &testOutputsEngine{files: map[string]string{outputsPath: "VERSION=1.2.3\n"}}
+
+	r := newOutputsRuntime(engine, p, &pipeline.Container{ID: "outputs_github_octocat"}, new(bytes.Buffer))
+
+	err := r.InspectContainer(context.Background(), p.Steps[0])
+	if err != nil {
+		t.Errorf("InspectContainer returned err: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name   string
+		config *Config
+	}{
+		{
+			name:   "table",
+			config: &Config{},
+		},
+		{
+			name:   "json",
+			config: &Config{Report: "json"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.reportOutputs(r, nil)
+			if err != nil {
+				t.Errorf("reportOutputs returned err: %v", err)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@
 
 package pipeline
 
-import "github.com/go-vela/cli/internal/output"
+import (
+	"time"
+
+	"github.com/go-vela/cli/internal/output"
+)
 
 // Config represents the configuration necessary
 // to perform pipeline related requests with Vela.
@@ -18,17 +22,60 @@ type Config struct {
 	Repo             string
 	SkipSteps        []string
 	Ref              string
+	Refs             []string
 	File             string
+	Files            []string
+	Concurrency      int
 	FileChangeset    []string
+	ChangesetFromGit string
 	Path             string
 	Type             string
 	Stages           bool
 	TemplateFiles    []string
+	TestFiles        []string
+	TemplateCache    string
+	CacheTemplates   bool
+	Offline          bool
+	GitHubURL        string
+	GitHubToken      string
 	Local            bool
 	Remote           bool
+	Explain          bool
+	NoSchema         bool
+	Strict           bool
+	LintConfig       string
+	FailOn           string
+	Check            bool
+	Write            bool
+	Force            bool
+	Defaults         bool
+	From             string
+	Source           string
+	Graph            string
+	Env              bool
+	Step             string
 	Volumes          []string
+	Caches           []string
 	PrivilegedImages []string
+	CPUs             string
+	Memory           string
+	Timeout          time.Duration
+	StepTimeouts     []string
+	FailFast         bool
 	OutputsImage     string
+	NoPrepull        bool
+	PrepullLimit     int
+	Report           string
+	NoMask           bool
+	CleanRoom        bool
+	CleanRoomRef     string
+	ExportArtifacts  []string
+	Watch            bool
+	WatchPaths       []string
+	WatchDebounce    time.Duration
+	WatchMatching    bool
+	DebugOnFailure   bool
+	DebugShell       string
 	Page             int
 	PerPage          int
 	Output           string
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/constants"
+	"github.com/go-vela/worker/runtime"
+)
+
+// prepullImages pulls every distinct image used by the pipeline, and
+// the provided containers, concurrently before the build starts. The
+// pulls are stopped as soon as any image fails to pull.
+//
+// The runtime consumes the progress of a pull while pulling the image,
+// so only the start and finish of each pull are written to out.
+func (c *Config) prepullImages(ctx context.Context, r runtime.Engine, p *pipeline.Build, out io.Writer, extra ...*pipeline.Container) error {
+	images := []*pipeline.Container{}
+
+	for _, ctn := range collectImages(p, extra...) {
+		// check if the image must be pulled or is already present
+		if ctn.Pull != constants.PullAlways {
+			// https://pkg.go.dev/github.com/go-vela/worker/runtime?tab=doc#Engine
+			_, err := r.InspectImage(ctx, ctn)
+			if err == nil {
+				logrus.Debugf("image %s already present", ctn.Image)
+
+				continue
+			}
+		}
+
+		images = append(images, ctn)
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+
+	logrus.Infof("pre-pulling %d images", len(images))
+
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#WithContext
+	g, gCtx := errgroup.WithContext(ctx)
+
+	if c.PrepullLimit > 0 {
+		g.SetLimit(c.PrepullLimit)
+	}
+
+	var (
+		mu     sync.Mutex
+		pulled atomic.Int32
+	)
+
+	// print the status of the pulls one line at a time
+	status := func(format string, a ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fmt.Fprintf(out, format, a...)
+	}
+
+	for _, ctn := range images {
+		g.Go(func() error {
+			// check if another image already failed to pull
+			if gCtx.Err() != nil {
+				return nil
+			}
+
+			status("[image: %s] pulling\n", ctn.Image)
+
+			start := time.Now()
+
+			// https://pkg.go.dev/github.com/go-vela/worker/runtime?tab=doc#Engine
+			err := r.CreateImage(gCtx, ctn)
+			if err != nil {
+				// check if the pull was stopped by the build being canceled
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				return pullError(ctn.Image, err)
+			}
+
+			status("[image: %s] pulled in %s (%d/%d)\n", ctn.Image, time.Since(start).Round(100*time.Millisecond), pulled.Add(1), len(images))
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	if err != nil {
+		return err
+	}
+
+	// the images were just pulled, so the containers pulling
+	// their image always don't need to pull it again
+	for _, ctn := range pipelineContainers(p, extra...) {
+		if ctn != nil && ctn.Pull == constants.PullAlways {
+			ctn.Pull = constants.PullNotPresent
+		}
+	}
+
+	return nil
+}
+
+// pipelineContainers returns the steps and services
+// of the pipeline and the provided containers.
+func pipelineContainers(p *pipeline.Build, extra ...*pipeline.Container) pipeline.ContainerSlice {
+	containers := pipeline.ContainerSlice{}
+
+	for _, stage := range p.Stages {
+		containers = append(containers, stage.Steps...)
+	}
+
+	containers = append(containers, p.Steps...)
+	containers = append(containers, p.Services...)
+
+	return append(containers, extra...)
+}
+
+// collectImages returns a container for every distinct image,
+// that may be pulled before the build starts, used by the
+// pipeline and the provided containers.
+func collectImages(p *pipeline.Build, extra ...*pipeline.Container) []*pipeline.Container {
+	images := []*pipeline.Container{}
+	seen := make(map[string]bool)
+
+	for _, ctn := range pipelineContainers(p, extra...) {
+		// skip containers without an image, such as the init step
+		if ctn == nil || len(ctn.Image) == 0 || strings.HasPrefix(ctn.Image, "#") {
+			continue
+		}
+
+		// skip images that are never pulled or pulled when the step starts
+		if ctn.Pull == constants.PullNever || ctn.Pull == constants.PullOnStart || seen[ctn.Image] {
+			continue
+		}
+
+		seen[ctn.Image] = true
+
+		images = append(images, ctn)
+	}
+
+	return images
+}
+
+// pullError returns an error explaining why the provided image
+// could not be pulled for the common registry failures.
+func pullError(image string, err error) error {
+	msg := strings.ToLower(err.Error())
+
+	switch {
+	case strings.Contains(msg, "manifest unknown"),
+		strings.Contains(msg, "not found"):
+		return fmt.Errorf("unable to pull image %s: image or tag not found - verify the image name and tag exist: %w", image, err)
+	case strings.Contains(msg, "unauthorized"),
+		strings.Contains(msg, "authentication required"),
+		strings.Contains(msg, "access denied"),
+		strings.Contains(msg, "no basic auth credentials"):
+		return fmt.Errorf("unable to pull image %s: access denied - run `docker login` for the registry: %w", image, err)
+	default:
+		return fmt.Errorf("unable to pull image %s: %w", image, err)
+	}
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/constants"
+	"github.com/go-vela/worker/runtime"
+)
+
+// testImageEngine is a runtime with the provided images present
+// that fails to pull the images with the provided errors.
+type testImageEngine struct {
+	runtime.Engine
+
+	present map[string]bool
+	errs    map[string]error
+
+	mu     sync.Mutex
+	pulled []string
+}
+
+func (e *testImageEngine) InspectImage(_ context.Context, ctn *pipeline.Container) ([]byte, error) {
+	if e.present[ctn.Image] {
+		return []byte("{}"), nil
+	}
+
+	return nil, errors.New("No such image")
+}
+
+func (e *testImageEngine) CreateImage(_ context.Context, ctn *pipeline.Container) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.pulled = append(e.pulled, ctn.Image)
+
+	return e.errs[ctn.Image]
+}
+
+func TestPipeline_Config_prepullImages(t *testing.T) {
+	// setup types
+	newPipeline := func() *pipeline.Build {
+		return &pipeline.Build{
+			Steps: pipeline.ContainerSlice{
+				{Name: "init", Image: "#init"},
+				{Name: "clone", Image: "target/vela-git-slim:latest", Pull: constants.PullNotPresent},
+				{Name: "test", Image: "golang:latest", Pull: constants.PullNotPresent},
+				{Name: "lint", Image: "golang:latest", Pull: constants.PullNotPresent},
+				{Name: "build", Image: "golang:latest", Pull: constants.PullNotPresent},
+				{Name: "publish", Image: "target/vela-docker:latest", Pull: constants.PullAlways},
+				{Name: "local", Image: "local/image:dev", Pull: constants.PullNever},
+				{Name: "deploy", Image: "target/vela-kubernetes:latest", Pull: constants.PullOnStart},
+			},
+			Services: pipeline.ContainerSlice{
+				{Name: "postgres", Image: "postgres:16", Pull: constants.PullNotPresent},
+			},
+		}
+	}
+
+	outputs := &pipeline.Container{Image: "alpine:latest", Pull: constants.PullNotPresent}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure string
+		config  *Config
+		engine  *testImageEngine
+		want    []string
+	}{
+		{
+			name:   "pull missing images",
+			config: &Config{PrepullLimit: 2},
+			engine: &testImageEngine{
+				present: map[string]bool{"target/vela-git-slim:latest": true, "target/vela-docker:latest": true},
+			},
+			want: []string{"alpine:latest", "golang:latest", "postgres:16", "target/vela-docker:latest"},
+		},
+		{
+			name:   "all images present",
+			config: &Config{},
+			engine: &testImageEngine{
+				present: map[string]bool{
+					"target/vela-git-slim:latest": true,
+					"golang:latest":               true,
+					"postgres:16":                 true,
+					"alpine:latest":               true,
+				},
+			},
+			want: []string{"target/vela-docker:latest"},
+		},
+		{
+			name:    "missing tag",
+			failure: "image or tag not found",
+			config:  &Config{PrepullLimit: 1},
+			engine: &testImageEngine{
+				errs: map[string]error{"golang:latest": errors.New("Error response from daemon: manifest for golang:latest not found: manifest unknown")},
+			},
+		},
+		{
+			name:    "unauthorized",
+			failure: "docker login",
+			config:  &Config{PrepullLimit: 1},
+			engine: &testImageEngine{
+				errs: map[string]error{"postgres:16": errors.New("Error response from daemon: pull access denied for postgres, repository does not exist or may require 'docker login'")},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := newPipeline()
+
+			err := test.config.prepullImages(context.Background(), test.engine, p, out, outputs)
+
+			if len(test.failure) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.failure) {
+					t.Errorf("prepullImages returned err %v, want %s", err, test.failure)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("prepullImages returned err: %v", err)
+			}
+
+			slices.Sort(test.engine.pulled)
+
+			if !reflect.DeepEqual(test.engine.pulled, test.want) {
+				t.Errorf("prepullImages pulled %v, want %v", test.engine.pulled, test.want)
+			}
+
+			for _, image := range test.want {
+				if !strings.Contains(out.String(), "[image: "+image+"] pulled in") {
+					t.Errorf("prepullImages output %q missing status for %s", out.String(), image)
+				}
+			}
+
+			// the pulled images shouldn't be pulled again when the steps start
+			for _, ctn := range p.Steps {
+				if ctn.Pull == constants.PullAlways {
+					t.Errorf("prepullImages did not update pull policy for %s", ctn.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestPipeline_collectImages(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		Stages: pipeline.StageSlice{
+			{
+				Name: "init",
+				Steps: pipeline.ContainerSlice{
+					{Name: "init", Image: "#init"},
+				},
+			},
+			{
+				Name: "test",
+				Steps: pipeline.ContainerSlice{
+					{Name: "test", Image: "golang:latest"},
+					{Name: "lint", Image: "golangci/golangci-lint:latest"},
+					{Name: "local", Image: "local/image:dev", Pull: constants.PullNever},
+					{Name: "deploy", Image: "target/vela-kubernetes:latest", Pull: constants.PullOnStart},
+				},
+			},
+			{
+				Name: "build",
+				Steps: pipeline.ContainerSlice{
+					{Name: "build", Image: "golang:latest"},
+				},
+			},
+		},
+		Services: pipeline.ContainerSlice{
+			{Name: "redis", Image: "redis:7"},
+		},
+	}
+
+	want := []string{"golang:latest", "golangci/golangci-lint:latest", "redis:7", "alpine:latest"}
+
+	// run test
+	got := []string{}
+
+	for _, ctn := range collectImages(p, &pipeline.Container{Image: "alpine:latest"}, nil) {
+		got = append(got, ctn.Image)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("collectImages is %v, want %v", got, want)
+	}
+}
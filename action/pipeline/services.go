@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/cli/version"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	"github.com/go-vela/server/constants"
+	"github.com/go-vela/worker/executor"
+)
+
+// ExecServices compiles a pipeline and starts only the services
+// defined in it, keeping them running until the process is
+// interrupted or terminated.
+func (c *Config) ExecServices(client compiler.Engine) error {
+	logrus.Debug("executing exec for pipeline services configuration")
+
+	ctx, done := signalContext("pipeline services canceled! cleaning up - you may see some errors during cleanup")
+	defer done()
+
+	base, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	// compile the pipeline for executing locally
+	_pipeline, b, err := c.compileLocal(ctx, client, path, filepath.Dir(path), "")
+	if err != nil {
+		return err
+	}
+
+	if len(_pipeline.Services) == 0 {
+		return fmt.Errorf("no services found in pipeline %s", path)
+	}
+
+	// remove the steps so only the services are started
+	servicesOnly(_pipeline)
+
+	// setup the runtime with the workspace mounted
+	_runtime, closeRuntime, err := c.setupRuntime(ctx, base)
+	if err != nil {
+		return err
+	}
+
+	defer closeRuntime()
+
+	logrus.Tracef("creating executor engine %s", constants.DriverLocal)
+
+	// sanitize the pipeline for the runtime
+	sanitized := _pipeline.Sanitize(constants.DriverDocker)
+
+	_executor, err := executor.New(&executor.Setup{
+		Driver:   constants.DriverLocal,
+		Runtime:  _runtime,
+		Pipeline: sanitized,
+		Build:    b,
+		Version:  version.New().Semantic(),
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// destroy the services, volume and network with the executor
+		err := _executor.DestroyBuild(context.Background())
+		if err != nil {
+			logrus.Errorf("unable to destroy services: %v", err)
+		}
+	}()
+
+	// create the build with the executor
+	err = _executor.CreateBuild(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to create build: %w", err)
+	}
+
+	// plan the build, creating the network and volume, with the executor
+	err = _executor.PlanBuild(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to plan build: %w", err)
+	}
+
+	for _, service := range sanitized.Services {
+		logrus.Debugf("starting service %s", service.Name)
+
+		// create the service with the executor
+		err = _executor.CreateService(ctx, service)
+		if err != nil {
+			return fmt.Errorf("unable to create service %s: %w", service.Name, err)
+		}
+
+		// plan the service with the executor
+		err = _executor.PlanService(ctx, service)
+		if err != nil {
+			return fmt.Errorf("unable to plan service %s: %w", service.Name, err)
+		}
+
+		// execute the service, streaming its logs, with the executor
+		err = _executor.ExecService(ctx, service)
+		if err != nil {
+			return fmt.Errorf("unable to execute service %s: %w", service.Name, err)
+		}
+	}
+
+	err = servicesTable(sanitized)
+	if err != nil {
+		return err
+	}
+
+	logrus.Info("services running - press Ctrl+C to stop")
+
+	// wait for the services to be stopped
+	<-ctx.Done()
+
+	return nil
+}
+
+// servicesOnly removes every step, except the init step
+// required by the executor, from the pipeline.
+func servicesOnly(p *pipeline.Build) {
+	steps := pipeline.ContainerSlice{}
+
+	// the init step is always the first step of the pipeline
+	switch {
+	case len(p.Stages) > 0 && len(p.Stages[0].Steps) > 0:
+		steps = append(steps, p.Stages[0].Steps[0])
+	case len(p.Steps) > 0:
+		steps = append(steps, p.Steps[0])
+	}
+
+	p.Stages = pipeline.StageSlice{}
+	p.Steps = steps
+}
+
+// servicesTable is a helper function to output the connection
+// information for the provided pipeline services in a table.
+func servicesTable(p *pipeline.Build) error {
+	logrus.Debug("creating table for pipeline services")
+
+	// create a new table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for table to 50
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 50
+
+	// ensure the table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	// set of service fields we display in a table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("SERVICE", "IMAGE", "NETWORK", "ALIAS", "PORTS")
+
+	// iterate through all services in the pipeline
+	for _, s := range p.Services {
+		ports := []string{}
+
+		for _, port := range s.Ports {
+			ports = append(ports, servicePort(s.Name, port))
+		}
+
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(s.Name, s.Image, p.ID, s.Name, strings.Join(ports, ", "))
+	}
+
+	// output the table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
+
+// servicePort returns the address to connect to the
+// provided port of the service.
+func servicePort(name, port string) string {
+	parts := strings.Split(port, ":")
+
+	switch len(parts) {
+	case 1:
+		// unpublished ports are only reachable on the network
+		return fmt.Sprintf("%s:%s", name, port)
+	case 2:
+		return fmt.Sprintf("localhost:%s->%s", parts[0], parts[1])
+	default:
+		return fmt.Sprintf("%s->%s", strings.Join(parts[:len(parts)-1], ":"), parts[len(parts)-1])
+	}
+}
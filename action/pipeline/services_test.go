@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+)
+
+func TestPipeline_servicesOnly(t *testing.T) {
+	// setup types
+	initStep := &pipeline.Container{ID: "step_github_octocat_1_init", Name: "init", Image: "#init"}
+	initStage := &pipeline.Container{ID: "github_octocat_1_init_init", Name: "init", Image: "#init"}
+
+	services := pipeline.ContainerSlice{
+		{ID: "service_github_octocat_1_postgres", Name: "postgres", Image: "postgres:latest"},
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		pipeline *pipeline.Build
+		want     *pipeline.Container
+	}{
+		{
+			name: "steps",
+			pipeline: &pipeline.Build{
+				Services: services,
+				Steps: pipeline.ContainerSlice{
+					initStep,
+					{ID: "step_github_octocat_1_test", Name: "test", Image: "golang:latest"},
+				},
+			},
+			want: initStep,
+		},
+		{
+			name: "stages",
+			pipeline: &pipeline.Build{
+				Services: services,
+				Stages: pipeline.StageSlice{
+					{Name: "init", Steps: pipeline.ContainerSlice{initStage}},
+					{Name: "test", Steps: pipeline.ContainerSlice{
+						{ID: "github_octocat_1_test_test", Name: "test", Image: "golang:latest"},
+					}},
+				},
+			},
+			want: initStage,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			servicesOnly(test.pipeline)
+
+			if len(test.pipeline.Stages) != 0 {
+				t.Errorf("servicesOnly should have removed the stages")
+			}
+
+			if len(test.pipeline.Steps) != 1 || test.pipeline.Steps[0] != test.want {
+				t.Errorf("servicesOnly steps are %v, want only the init step", test.pipeline.Steps)
+			}
+
+			if len(test.pipeline.Services) != 1 {
+				t.Errorf("servicesOnly should not have removed the services")
+			}
+		})
+	}
+}
+
+func TestPipeline_servicesTable(t *testing.T) {
+	// setup types
+	p := &pipeline.Build{
+		ID: "github_octocat_1",
+		Services: pipeline.ContainerSlice{
+			{Name: "postgres", Image: "postgres:latest", Ports: []string{"5432:5432"}},
+			{Name: "redis", Image: "redis:latest"},
+		},
+	}
+
+	// run test
+	err := servicesTable(p)
+	if err != nil {
+		t.Errorf("servicesTable returned err: %v", err)
+	}
+}
+
+func TestPipeline_servicePort(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		port string
+		want string
+	}{
+		{
+			port: "5432",
+			want: "postgres:5432",
+		},
+		{
+			port: "15432:5432",
+			want: "localhost:15432->5432",
+		},
+		{
+			port: "127.0.0.1:15432:5432",
+			want: "127.0.0.1:15432->5432",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := servicePort("postgres", test.port)
+
+		if got != test.want {
+			t.Errorf("servicePort for %s is %s, want %s", test.port, got, test.want)
+		}
+	}
+}
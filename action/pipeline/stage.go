@@ -34,6 +34,21 @@ func stages(pipelineType string) *yaml.Build {
 		image = "node:latest"
 		// set the commands for a node stages pipeline
 		commands = []string{"node --version"}
+	case "python":
+		// set the image for a python stages pipeline
+		image = "python:latest"
+		// set the commands for a python stages pipeline
+		commands = []string{"python --version"}
+	case "rust":
+		// set the image for a rust stages pipeline
+		image = "rust:latest"
+		// set the commands for a rust stages pipeline
+		commands = []string{"cargo --version"}
+	case "docker":
+		// set the image for a docker stages pipeline
+		image = "docker:latest"
+		// set the commands for a docker stages pipeline
+		commands = []string{"docker --version"}
 	}
 
 	// return a stages pipeline based off the type
@@ -34,6 +34,21 @@ func steps(pipelineType string) *yaml.Build {
 		image = "node:latest"
 		// set the commands for a node steps pipeline
 		commands = []string{"node --version"}
+	case "python":
+		// set the image for a python steps pipeline
+		image = "python:latest"
+		// set the commands for a python steps pipeline
+		commands = []string{"python --version"}
+	case "rust":
+		// set the image for a rust steps pipeline
+		image = "rust:latest"
+		// set the commands for a rust steps pipeline
+		commands = []string{"cargo --version"}
+	case "docker":
+		// set the image for a docker steps pipeline
+		image = "docker:latest"
+		// set the commands for a docker steps pipeline
+		commands = []string{"docker --version"}
 	}
 
 	// return a steps pipeline based off the type
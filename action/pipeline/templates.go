@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/server/constants"
+)
+
+// localTemplate represents a template compiled
+// from a local file instead of fetched remotely.
+type localTemplate struct {
+	Name string
+	Path string
+}
+
+// cachedTemplates returns the local template files to compile the pipeline
+// with, adding the remote templates of the pipeline, and the templates they
+// reference, from the template cache.
+//
+// Remote templates pinned to a commit, including those pinned by a lockfile,
+// are served from the cache. Templates on a branch or tag are left for the
+// compiler to fetch, unless the template cache was set explicitly, where they
+// are fetched again to pick up upstream changes and fall back to the cache
+// when they can't be fetched. Compiling the pipeline offline serves every
+// template from the cache, which requires them to be cached. Templates the
+// pipeline provides with a local template file are not cached.
+func (c *Config) cachedTemplates(ctx context.Context, source any) ([]string, error) {
+	files := slices.Clone(c.TemplateFiles)
+
+	// check if the template cache is disabled
+	if len(c.TemplateCache) == 0 {
+		return files, nil
+	}
+
+	// only yaml pipelines declare templates that can be cached
+	if len(c.PipelineType) > 0 && c.PipelineType != constants.PipelineTypeYAML {
+		return files, nil
+	}
+
+	templates, err := pipelineTemplates(source)
+	if err != nil {
+		// leave reporting the invalid pipeline to the compiler
+		logrus.Debugf("unable to capture templates for pipeline: %v", err)
+
+		return files, nil
+	}
+
+	local := []localTemplate{}
+
+	for _, file := range c.TemplateFiles {
+		name, path, _ := strings.Cut(file, ":")
+
+		local = append(local, localTemplate{Name: name, Path: path})
+	}
+
+	local, err = c.addCachedTemplates(ctx, local, templates)
+	if err != nil {
+		return nil, err
+	}
+
+	// add the cached templates in the name:path
+	// format the compiler expects for local templates
+	for _, tmpl := range local[len(c.TemplateFiles):] {
+		files = append(files, fmt.Sprintf("%s:%s", tmpl.Name, tmpl.Path))
+	}
+
+	return files, nil
+}
+
+// addCachedTemplates adds the remote templates from the template cache to
+// the local templates, along with the templates they reference.
+func (c *Config) addCachedTemplates(ctx context.Context, local []localTemplate, templates []*internal.RemoteTemplate) ([]localTemplate, error) {
+	for _, tmpl := range templates {
+		// skip templates provided with a local template file
+		//
+		// this also stops templates that reference each other
+		// from being added more than once
+		if slices.ContainsFunc(local, func(l localTemplate) bool {
+			return strings.EqualFold(l.Name, tmpl.Name)
+		}) {
+			continue
+		}
+
+		cached, err := c.cachedTemplate(ctx, tmpl)
+		if err != nil {
+			return nil, err
+		}
+
+		// leave fetching the template to the compiler
+		if cached == nil {
+			continue
+		}
+
+		logrus.Debugf("using cached template %s for %s", cached.Digest, tmpl.Source)
+
+		local = append(local, localTemplate{Name: tmpl.Name, Path: cached.Path})
+
+		data, err := os.ReadFile(cached.Path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cached template %s: %w", tmpl.Source, err)
+		}
+
+		// capture the templates referenced by the template
+		nested, err := internal.RemoteTemplates(data)
+		if err != nil {
+			if c.Offline {
+				logrus.Warnf("unable to capture templates referenced by template %s, they may be fetched from GitHub: %v", tmpl.Name, err)
+			} else {
+				logrus.Debugf("unable to capture templates referenced by template %s: %v", tmpl.Name, err)
+			}
+
+			continue
+		}
+
+		local, err = c.addCachedTemplates(ctx, local, nested)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return local, nil
+}
+
+// cachedTemplate returns the remote template from the template cache,
+// fetching it when it is missing, or nil when the compiler should
+// fetch the template instead.
+func (c *Config) cachedTemplate(ctx context.Context, tmpl *internal.RemoteTemplate) (*internal.CachedTemplate, error) {
+	cached, err := internal.GetCachedTemplate(c.TemplateCache, tmpl.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("template %s from %s is not in the template cache, unable to compile offline", tmpl.Name, tmpl.Source)
+		}
+
+		return cached, nil
+	}
+
+	// check if the template is pinned to a commit
+	src, err := internal.ParseTemplateSource(tmpl.Source)
+	pinned := err == nil && src.Pinned()
+
+	if cached != nil && pinned {
+		return cached, nil
+	}
+
+	// leave fetching templates on a branch or tag to the
+	// compiler unless the template cache was set explicitly
+	if !pinned && !c.CacheTemplates {
+		return nil, nil
+	}
+
+	fetched, err := c.cacheTemplate(ctx, tmpl.Source)
+	if err != nil {
+		if cached != nil {
+			logrus.Warnf("unable to fetch template %s, using cached template from %s which may be out of date: %v", tmpl.Name, cached.Created, err)
+
+			return cached, nil
+		}
+
+		logrus.Warnf("unable to cache template %s: %v", tmpl.Name, err)
+
+		return nil, nil
+	}
+
+	return fetched, nil
+}
+
+// cacheTemplate fetches the remote template for the
+// source and stores it in the template cache.
+func (c *Config) cacheTemplate(ctx context.Context, source string) (*internal.CachedTemplate, error) {
+	src, err := internal.ParseTemplateSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	host := internal.GitHubHost(c.GitHubURL)
+
+	if !strings.EqualFold(src.Host, host) {
+		return nil, fmt.Errorf("host %s does not match compiler github url host %s", src.Host, host)
+	}
+
+	client, err := internal.NewGitHubClient(c.GitHubURL, c.GitHubToken)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Tracef("fetching template %s", source)
+
+	data, err := internal.GetGitHubTemplate(ctx, client, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.CacheTemplate(c.TemplateCache, source, data)
+}
+
+// pipelineTemplates captures the remote templates of
+// the pipeline from its path or its contents.
+func pipelineTemplates(source any) ([]*internal.RemoteTemplate, error) {
+	switch v := source.(type) {
+	case []byte:
+		return internal.RemoteTemplates(v)
+	case string:
+		data, err := os.ReadFile(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return internal.RemoteTemplates(data)
+	default:
+		return nil, fmt.Errorf("unsupported pipeline source %T", source)
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/cli/internal"
+)
+
+func TestPipeline_Config_cachedTemplates(t *testing.T) {
+	// setup types
+	commit := "d2a4a1f3d76ff1e1a2e27f4a7e5e3d2b7c1b6a04"
+
+	templates := map[string]string{
+		"go.yml":     "steps: []\n",
+		"docker.yml": "",
+	}
+
+	// setup test server serving template contents
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := templates[strings.TrimPrefix(r.URL.Path, "/api/v3/repos/octocat/templates/contents/")]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		fmt.Fprintf(w, `{"type":"file","encoding":"base64","content":"%s"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	}))
+	defer s.Close()
+
+	host := strings.TrimPrefix(s.URL, "http://")
+
+	// the docker template references a template pinned to a commit
+	templates["docker.yml"] = fmt.Sprintf(`templates:
+  - name: nested
+    source: %s/octocat/templates/nested.yml@%s
+    type: github
+steps: []
+`, host, commit)
+
+	pipeline := []byte(fmt.Sprintf(`version: "1"
+templates:
+  - name: go
+    source: %[1]s/octocat/templates/go.yml@main
+    type: github
+  - name: docker
+    source: %[1]s/octocat/templates/docker.yml@v1
+    type: github
+  - name: local
+    source: templates/local.yml
+    type: file
+  - name: nested
+    source: %[1]s/octocat/templates/nested.yml@%[2]s
+    type: github
+steps:
+  - name: build
+    template:
+      name: go
+`, host, commit))
+
+	dir := t.TempDir()
+
+	// cache an out of date copy of the go template
+	_, err := internal.CacheTemplate(dir, fmt.Sprintf("%s/octocat/templates/go.yml@main", host), []byte("steps: [stale]\n"))
+	if err != nil {
+		t.Fatalf("CacheTemplate returned err: %v", err)
+	}
+
+	// cache the pinned template, which is not served by the test server
+	nested, err := internal.CacheTemplate(dir, fmt.Sprintf("%s/octocat/templates/nested.yml@%s", host, commit), []byte("steps: []\n"))
+	if err != nil {
+		t.Fatalf("CacheTemplate returned err: %v", err)
+	}
+
+	blob := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+
+		return filepath.Join(dir, "blobs", hex.EncodeToString(sum[:]))
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+		want    []string
+	}{
+		{
+			name:    "template cache disabled",
+			failure: false,
+			config:  &Config{TemplateFiles: []string{"go:go.yml"}},
+			want:    []string{"go:go.yml"},
+		},
+		{
+			name:    "local template file",
+			failure: false,
+			config:  &Config{TemplateCache: dir, TemplateFiles: []string{"go:go.yml", "docker:docker.yml"}},
+			want:    []string{"go:go.yml", "docker:docker.yml", "nested:" + nested.Path},
+		},
+		{
+			name:    "offline with missing template",
+			failure: true,
+			config:  &Config{TemplateCache: dir, Offline: true},
+		},
+		{
+			name:    "online with templates on a branch or tag",
+			failure: false,
+			config:  &Config{TemplateCache: dir, GitHubURL: s.URL},
+			want:    []string{"nested:" + nested.Path},
+		},
+		{
+			name:    "fetch unpinned and missing templates",
+			failure: false,
+			config:  &Config{TemplateCache: dir, CacheTemplates: true, GitHubURL: s.URL},
+			want:    []string{"go:" + blob(templates["go.yml"]), "docker:" + blob(templates["docker.yml"]), "nested:" + nested.Path},
+		},
+		{
+			name:    "offline with cached templates",
+			failure: false,
+			config:  &Config{TemplateCache: dir, Offline: true},
+			want:    []string{"go:" + blob(templates["go.yml"]), "docker:" + blob(templates["docker.yml"]), "nested:" + nested.Path},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.config.cachedTemplates(t.Context(), pipeline)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("cachedTemplates should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("cachedTemplates returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("cachedTemplates is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
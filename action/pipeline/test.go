@@ -0,0 +1,588 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal/output"
+	api "github.com/go-vela/server/api/types"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/types/pipeline"
+	pyaml "github.com/go-vela/server/compiler/types/yaml"
+	"github.com/go-vela/server/constants"
+)
+
+const (
+	// testFileSuffix is the suffix of the files with
+	// the scenarios used to test a pipeline.
+	testFileSuffix = ".vela-test.yml"
+
+	// testText outputs the results of the tests as text.
+	testText = "text"
+
+	// testJUnit outputs the results of the tests as a JUnit XML report.
+	testJUnit = "junit"
+)
+
+// testSuite represents a file with the scenarios used to test a pipeline.
+type testSuite struct {
+	Pipeline  string          `yaml:"pipeline"`
+	Scenarios []*testScenario `yaml:"scenarios"`
+}
+
+// testScenario represents the build, template variables and local
+// templates used to compile a pipeline and the expected results.
+type testScenario struct {
+	Name      string                    `yaml:"name"`
+	RuleData  testRuleData              `yaml:"ruledata"`
+	Vars      map[string]map[string]any `yaml:"vars"`
+	Templates []string                  `yaml:"templates"`
+	Assert    testAssertions            `yaml:"assert"`
+}
+
+// testRuleData represents the build used to evaluate the rulesets of a pipeline.
+type testRuleData struct {
+	Branch  string   `yaml:"branch"`
+	Comment string   `yaml:"comment"`
+	Event   string   `yaml:"event"`
+	Path    []string `yaml:"path"`
+	Status  string   `yaml:"status"`
+	Tag     string   `yaml:"tag"`
+	Target  string   `yaml:"target"`
+}
+
+// testAssertions represents the expected results for a scenario.
+type testAssertions struct {
+	Run   []string                       `yaml:"run"`
+	Skip  []string                       `yaml:"skip"`
+	Steps map[string]*testStepAssertions `yaml:"steps"`
+}
+
+// testStepAssertions represents the expected configuration of a step.
+type testStepAssertions struct {
+	Image       string            `yaml:"image"`
+	Environment map[string]string `yaml:"environment"`
+	Secrets     []string          `yaml:"secrets"`
+}
+
+// testResult represents the result of a scenario with
+// the assertions that failed for the compiled pipeline.
+type testResult struct {
+	File     string
+	Scenario string
+	Failures []string
+}
+
+// Test compiles the pipeline for each scenario of the test
+// files, with the local compiler, and reports the results.
+func (c *Config) Test(ctx context.Context, client compiler.Engine) error {
+	logrus.Debug("executing test for local pipeline configuration")
+
+	files, err := c.testFiles()
+	if err != nil {
+		return err
+	}
+
+	results := []*testResult{}
+
+	for _, file := range files {
+		r, err := c.testFile(ctx, client, file)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, r...)
+	}
+
+	err = c.testOutput(results)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, r := range results {
+		if len(r.Failures) > 0 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pipeline test scenarios failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// testFiles returns the provided test files, or the test
+// files in the directory of the pipeline when none are provided.
+func (c *Config) testFiles() ([]string, error) {
+	if len(c.TestFiles) > 0 {
+		return c.TestFiles, nil
+	}
+
+	dir := c.Path
+	if len(dir) == 0 {
+		dir = "."
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+testFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no pipeline test files (*%s) found in %s", testFileSuffix, dir)
+	}
+
+	return files, nil
+}
+
+// testFile compiles the pipeline for each scenario
+// of the provided test file and returns the results.
+func (c *Config) testFile(ctx context.Context, client compiler.Engine, file string) ([]*testResult, error) {
+	logrus.Debugf("running pipeline tests from %s", file)
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pipeline test file %s: %w", file, err)
+	}
+
+	suite := new(testSuite)
+
+	err = yaml.Unmarshal(data, suite)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pipeline test file %s: %w", file, err)
+	}
+
+	if len(suite.Scenarios) == 0 {
+		return nil, fmt.Errorf("no scenarios provided in pipeline test file %s", file)
+	}
+
+	dir := filepath.Dir(file)
+
+	// capture the pipeline relative to the test file, or from the configuration
+	path := filepath.Join(dir, suite.Pipeline)
+	if len(suite.Pipeline) == 0 {
+		_, path, err = c.execPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pipeline %s: %w", path, err)
+	}
+
+	// set pipelineType within client
+	client.WithRepo(&api.Repo{PipelineType: &c.PipelineType})
+
+	results := []*testResult{}
+
+	for i, s := range suite.Scenarios {
+		if len(s.Name) == 0 {
+			s.Name = fmt.Sprintf("scenario %d", i+1)
+		}
+
+		r := &testResult{File: file, Scenario: s.Name}
+
+		p, err := c.testCompile(ctx, client, source, dir, s)
+		if err != nil {
+			r.Failures = append(r.Failures, fmt.Sprintf("unable to compile pipeline %s: %v", path, err))
+		} else {
+			r.Failures = c.testAssert(p, s)
+		}
+
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+// testCompile compiles the pipeline, with the template variables and local
+// templates of the scenario, without ruledata to keep every step.
+func (c *Config) testCompile(ctx context.Context, client compiler.Engine, source []byte, dir string, s *testScenario) (*pyaml.Build, error) {
+	templates := []string{}
+
+	// the local templates of the scenario, relative to the test file,
+	// take precedence over the ones provided for every scenario
+	for _, template := range s.Templates {
+		name, file, ok := strings.Cut(template, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid format for template file: %s (valid format: <name>:<source>)", template)
+		}
+
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+
+		templates = append(templates, fmt.Sprintf("%s:%s", name, file))
+	}
+
+	if len(templates) > 0 {
+		client.WithLocalTemplates(append(templates, c.TemplateFiles...))
+
+		// restore the local templates provided for every scenario
+		defer client.WithLocalTemplates(c.TemplateFiles)
+	}
+
+	if len(s.Vars) > 0 {
+		var err error
+
+		source, err = testVars(source, s.Vars)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// https://pkg.go.dev/github.com/go-vela/server/compiler?tab=doc#Engine.CompileLite
+	p, _, err := client.CompileLite(ctx, source, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// testVars sets the variables of the scenario for the steps
+// of the pipeline that reference the templates by name.
+func testVars(source []byte, vars map[string]map[string]any) ([]byte, error) {
+	root := new(yaml.Node)
+
+	err := yaml.Unmarshal(source, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil, fmt.Errorf("pipeline is empty")
+	}
+
+	// copy the document to set the variables of each step
+	// without changing the nodes shared through anchors
+	doc := yamlCopy(root.Content[0])
+	root.Content[0] = doc
+
+	steps := slices.Clone(formatSequence(yamlMapValue(doc, "steps")))
+
+	stages := yamlMapValue(doc, "stages")
+	if stages != nil && stages.Kind == yaml.MappingNode {
+		for i := 1; i < len(stages.Content); i += 2 {
+			steps = append(steps, formatSequence(yamlMapValue(stages.Content[i], "steps"))...)
+		}
+	}
+
+	for _, step := range steps {
+		template := yamlResolve(yamlMapValue(step, "template"))
+
+		name := yamlMapValue(template, "name")
+		if name == nil {
+			continue
+		}
+
+		values, ok := vars[name.Value]
+		if !ok {
+			continue
+		}
+
+		current := yamlMapValue(template, "vars")
+
+		switch {
+		case current == nil:
+			current = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+			template.Content = append(template.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "vars"}, current)
+		case current.Kind != yaml.MappingNode:
+			// replace empty or invalid variables
+			*current = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			value := new(yaml.Node)
+
+			err = value.Encode(values[key])
+			if err != nil {
+				return nil, err
+			}
+
+			k, v := yamlMapPair(current, key)
+			if k != nil {
+				*v = *value
+
+				continue
+			}
+
+			current.Content = append(current.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+		}
+	}
+
+	return yaml.Marshal(root)
+}
+
+// testAssert returns the assertions of the scenario
+// that failed for the provided compiled pipeline.
+func (c *Config) testAssert(p *pyaml.Build, s *testScenario) []string {
+	failures := []string{}
+
+	data := c.testRuleData(s.RuleData)
+
+	steps, order := diffSteps(p)
+
+	runs := map[string]bool{}
+
+	for _, name := range order {
+		match, err := ruleData(data, steps[name].Environment).Match(*steps[name].Ruleset.ToPipeline())
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("unable to evaluate ruleset for step %s: %v", name, err))
+
+			continue
+		}
+
+		runs[name] = match
+	}
+
+	for _, name := range s.Assert.Run {
+		step, ok := steps[name]
+
+		switch {
+		case !ok:
+			failures = append(failures, fmt.Sprintf("step %s should run but does not exist", name))
+		case !runs[name]:
+			failures = append(failures, fmt.Sprintf("step %s should run but is skipped (%s)", name, failedRules(step.Ruleset.ToPipeline(), data, step.Environment)))
+		}
+	}
+
+	for _, name := range s.Assert.Skip {
+		if runs[name] {
+			failures = append(failures, fmt.Sprintf("step %s should be skipped but runs", name))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.Assert.Steps)) {
+		step, ok := steps[name]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("step %s does not exist", name))
+
+			continue
+		}
+
+		failures = append(failures, testStep(name, step, p.Environment, s.Assert.Steps[name])...)
+	}
+
+	return failures
+}
+
+// testStep returns the assertions that failed for the provided step.
+func testStep(name string, step *pyaml.Step, global map[string]string, want *testStepAssertions) []string {
+	failures := []string{}
+
+	if want == nil {
+		return failures
+	}
+
+	if len(want.Image) > 0 && step.Image != want.Image {
+		failures = append(failures, fmt.Sprintf("step %s image is %s, want %s", name, step.Image, want.Image))
+	}
+
+	// the environment of the step takes precedence over the global environment
+	environment := maps.Clone(global)
+	if environment == nil {
+		environment = map[string]string{}
+	}
+
+	maps.Copy(environment, step.Environment)
+
+	for _, key := range slices.Sorted(maps.Keys(want.Environment)) {
+		value, ok := environment[key]
+
+		switch {
+		case !ok:
+			failures = append(failures, fmt.Sprintf("step %s environment %s is not set, want %q", name, key, want.Environment[key]))
+		case value != want.Environment[key]:
+			failures = append(failures, fmt.Sprintf("step %s environment %s is %q, want %q", name, key, value, want.Environment[key]))
+		}
+	}
+
+	for _, secret := range want.Secrets {
+		found := slices.ContainsFunc(step.Secrets, func(s *pyaml.StepSecret) bool {
+			return strings.EqualFold(s.Source, secret) || strings.EqualFold(s.Target, secret)
+		})
+
+		if !found {
+			failures = append(failures, fmt.Sprintf("step %s secret %s is not provided", name, secret))
+		}
+	}
+
+	return failures
+}
+
+// testRuleData returns the ruledata used to evaluate
+// the rulesets of the pipeline for the scenario.
+func (c *Config) testRuleData(r testRuleData) *pipeline.RuleData {
+	status := r.Status
+	if len(status) == 0 {
+		status = constants.StatusSuccess
+	}
+
+	repo := ""
+	if len(c.Org) > 0 && len(c.Repo) > 0 {
+		repo = fmt.Sprintf("%s/%s", c.Org, c.Repo)
+	}
+
+	return &pipeline.RuleData{
+		Branch:  r.Branch,
+		Comment: r.Comment,
+		Event:   testEvent(r.Event),
+		Path:    r.Path,
+		Repo:    repo,
+		Status:  status,
+		Tag:     strings.TrimPrefix(r.Tag, "refs/tags/"),
+		Target:  r.Target,
+	}
+}
+
+// testEvent returns the event with the default
+// action for the events that require an action.
+func testEvent(event string) string {
+	if strings.Contains(event, ":") {
+		return event
+	}
+
+	switch event {
+	case constants.EventPull, constants.EventPullAlternate:
+		return fmt.Sprintf("%s:%s", constants.EventPull, constants.ActionOpened)
+	case constants.EventComment:
+		return fmt.Sprintf("%s:%s", constants.EventComment, constants.ActionCreated)
+	case constants.EventDeploy, constants.EventDeployAlternate:
+		return fmt.Sprintf("%s:%s", constants.EventDeploy, constants.ActionCreated)
+	default:
+		return event
+	}
+}
+
+// testOutput outputs the results of the tests
+// based off the provided configuration.
+func (c *Config) testOutput(results []*testResult) error {
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case testJUnit:
+		report, err := testJUnitReport(results)
+		if err != nil {
+			return err
+		}
+
+		// output the results in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(report)
+	default:
+		// output the results in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(testTextReport(results))
+	}
+}
+
+// testTextReport returns the results of the tests as text.
+func testTextReport(results []*testResult) string {
+	b := new(strings.Builder)
+
+	passed := 0
+
+	for _, r := range results {
+		if len(r.Failures) == 0 {
+			passed++
+
+			fmt.Fprintf(b, "PASS %s: %s\n", r.File, r.Scenario)
+
+			continue
+		}
+
+		fmt.Fprintf(b, "FAIL %s: %s\n", r.File, r.Scenario)
+
+		for _, failure := range r.Failures {
+			fmt.Fprintf(b, "    %s\n", failure)
+		}
+	}
+
+	fmt.Fprintf(b, "\n%d scenarios, %d passed, %d failed", len(results), passed, len(results)-passed)
+
+	return b.String()
+}
+
+// junitTestSuites represents a JUnit XML report.
+type junitTestSuites struct {
+	XMLName xml.Name          `xml:"testsuites"`
+	Suites  []*junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite represents the scenarios of a test file in a JUnit XML report.
+type junitTestSuite struct {
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Cases    []*junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase represents a scenario in a JUnit XML report.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+// junitFailure represents the failed assertions of a scenario in a JUnit XML report.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// testJUnitReport returns the results of the tests as a JUnit XML report.
+func testJUnitReport(results []*testResult) (string, error) {
+	report := new(junitTestSuites)
+
+	suites := map[string]*junitTestSuite{}
+
+	for _, r := range results {
+		suite, ok := suites[r.File]
+		if !ok {
+			suite = &junitTestSuite{Name: r.File}
+			suites[r.File] = suite
+
+			report.Suites = append(report.Suites, suite)
+		}
+
+		testCase := &junitTestCase{Name: r.Scenario, ClassName: r.File}
+
+		if len(r.Failures) > 0 {
+			suite.Failures++
+
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d assertions failed", len(r.Failures)),
+				Text:    strings.Join(r.Failures, "\n"),
+			}
+		}
+
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(data), nil
+}
@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+	goyaml "go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/server/compiler/native"
+	"github.com/go-vela/server/compiler/types/yaml"
+)
+
+func TestPipeline_Config_Test(t *testing.T) {
+	// setup types
+	cmd := new(cli.Command)
+	cmd.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone-image",
+			Value: "target/vela-git:latest",
+		},
+	}
+
+	client, err := native.FromCLICommand(t.Context(), cmd)
+	if err != nil {
+		t.Errorf("unable to create client: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+	}{
+		{
+			name:    "passing scenarios",
+			failure: false,
+			config:  &Config{Action: "test", File: ".vela.yml", TestFiles: []string{"testdata/test/pipeline.vela-test.yml"}},
+		},
+		{
+			name:    "passing scenarios with junit output",
+			failure: false,
+			config:  &Config{Action: "test", File: ".vela.yml", TestFiles: []string{"testdata/test/pipeline.vela-test.yml"}, Output: "junit"},
+		},
+		{
+			name:    "failing scenarios",
+			failure: true,
+			config:  &Config{Action: "test", File: ".vela.yml", TestFiles: []string{"testdata/test/failing.vela-test.yml"}},
+		},
+		{
+			name:    "discovered test files",
+			failure: true,
+			config:  &Config{Action: "test", File: ".vela.yml", Path: "testdata/test"},
+		},
+		{
+			name:    "missing test files",
+			failure: true,
+			config:  &Config{Action: "test", File: ".vela.yml", Path: "testdata/format"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Test(t.Context(), client.WithLocal(true))
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Test should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Test returned err: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_testVars(t *testing.T) {
+	// setup types
+	source := `version: "1"
+steps:
+  - name: build
+    template:
+      name: go
+      vars:
+        image: golang:1.23
+        lint: false
+stages:
+  test:
+    steps:
+      - name: test
+        template:
+          name: go
+      - name: docs
+        template:
+          name: docs
+`
+
+	want := `version: "1"
+steps:
+  - name: build
+    template:
+      name: go
+      vars:
+        image: golang:1.24
+        lint: true
+stages:
+  test:
+    steps:
+      - name: test
+        template:
+          name: go
+          vars:
+            image: golang:1.24
+            lint: true
+      - name: docs
+        template:
+          name: docs
+`
+
+	got, err := testVars([]byte(source), map[string]map[string]any{
+		"go": {"image": "golang:1.24", "lint": true},
+	})
+	if err != nil {
+		t.Fatalf("testVars returned err: %v", err)
+	}
+
+	gotValue, wantValue := map[string]any{}, map[string]any{}
+
+	err = goyaml.Unmarshal(got, &gotValue)
+	if err != nil {
+		t.Fatalf("unable to parse pipeline: %v", err)
+	}
+
+	err = goyaml.Unmarshal([]byte(want), &wantValue)
+	if err != nil {
+		t.Fatalf("unable to parse pipeline: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("testVars is %s, want %s", got, want)
+	}
+}
+
+func TestPipeline_testVars_Anchors(t *testing.T) {
+	// setup types
+	source := `version: "1"
+x-vars: &vars
+  image: golang:1.23
+steps:
+  - name: build
+    template:
+      name: go
+      vars: *vars
+  - name: docs
+    template:
+      name: docs
+      vars: *vars
+  - name: lint
+    template:
+      name: go
+      vars:
+`
+
+	want := `version: "1"
+x-vars:
+  image: golang:1.23
+steps:
+  - name: build
+    template:
+      name: go
+      vars:
+        image: golang:1.24
+  - name: docs
+    template:
+      name: docs
+      vars:
+        image: golang:1.23
+  - name: lint
+    template:
+      name: go
+      vars:
+        image: golang:1.24
+`
+
+	got, err := testVars([]byte(source), map[string]map[string]any{
+		"go": {"image": "golang:1.24"},
+	})
+	if err != nil {
+		t.Fatalf("testVars returned err: %v", err)
+	}
+
+	gotValue, wantValue := map[string]any{}, map[string]any{}
+
+	err = goyaml.Unmarshal(got, &gotValue)
+	if err != nil {
+		t.Fatalf("unable to parse pipeline: %v", err)
+	}
+
+	err = goyaml.Unmarshal([]byte(want), &wantValue)
+	if err != nil {
+		t.Fatalf("unable to parse pipeline: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("testVars is %s, want %s", got, want)
+	}
+}
+func TestPipeline_Config_testAssert(t *testing.T) {
+	// setup types
+	p := &yaml.Build{
+		Version:     "1",
+		Environment: map[string]string{"CGO_ENABLED": "0"},
+		Steps: yaml.StepSlice{
+			{
+				Name:        "test",
+				Image:       "golang:1.24",
+				Environment: map[string]string{"GOFLAGS": "-mod=vendor"},
+			},
+			{
+				Name:  "publish",
+				Image: "target/vela-docker:latest",
+				Ruleset: yaml.Ruleset{
+					If: yaml.Rules{Branch: []string{"main"}, Event: []string{"push"}},
+				},
+				Secrets: yaml.StepSecretSlice{
+					{Source: "docker_password", Target: "docker_password"},
+				},
+			},
+		},
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		scenario *testScenario
+		want     []string
+	}{
+		{
+			name: "passing",
+			scenario: &testScenario{
+				RuleData: testRuleData{Event: "push", Branch: "main"},
+				Assert: testAssertions{
+					Run: []string{"test", "publish"},
+					Steps: map[string]*testStepAssertions{
+						"test": {
+							Image:       "golang:1.24",
+							Environment: map[string]string{"CGO_ENABLED": "0", "GOFLAGS": "-mod=vendor"},
+						},
+						"publish": {Secrets: []string{"docker_password"}},
+					},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "failing",
+			scenario: &testScenario{
+				RuleData: testRuleData{Event: "pull_request", Branch: "main"},
+				Assert: testAssertions{
+					Run:  []string{"publish", "lint"},
+					Skip: []string{"test"},
+					Steps: map[string]*testStepAssertions{
+						"test": {
+							Image:       "golang:1.23",
+							Environment: map[string]string{"GOFLAGS": "-mod=mod", "GOOS": "linux"},
+							Secrets:     []string{"docker_password"},
+						},
+					},
+				},
+			},
+			want: []string{
+				"step publish should run but is skipped (event)",
+				"step lint should run but does not exist",
+				"step test should be skipped but runs",
+				"step test image is golang:1.24, want golang:1.23",
+				`step test environment GOFLAGS is "-mod=vendor", want "-mod=mod"`,
+				`step test environment GOOS is not set, want "linux"`,
+				"step test secret docker_password is not provided",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := new(Config).testAssert(p, test.scenario)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("testAssert is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_testJUnitReport(t *testing.T) {
+	// setup types
+	results := []*testResult{
+		{File: "push.vela-test.yml", Scenario: "push to main"},
+		{File: "push.vela-test.yml", Scenario: "pull request", Failures: []string{"step publish should be skipped but runs"}},
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="push.vela-test.yml" tests="2" failures="1">
+    <testcase name="push to main" classname="push.vela-test.yml"></testcase>
+    <testcase name="pull request" classname="push.vela-test.yml">
+      <failure message="1 assertions failed">step publish should be skipped but runs</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+	got, err := testJUnitReport(results)
+	if err != nil {
+		t.Fatalf("testJUnitReport returned err: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("testJUnitReport is %s, want %s", got, want)
+	}
+
+	if !strings.Contains(testTextReport(results), "2 scenarios, 1 passed, 1 failed") {
+		t.Errorf("testTextReport is missing the summary: %s", testTextReport(results))
+	}
+}
@@ -4,13 +4,16 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/go-vela/cli/internal"
 	"github.com/go-vela/cli/internal/output"
 	"github.com/go-vela/sdk-go/vela"
 	api "github.com/go-vela/server/api/types"
@@ -24,6 +27,11 @@ import (
 func (c *Config) Validate() error {
 	logrus.Debug("validating pipeline configuration")
 
+	// check if the pipeline is compiled offline without a template cache
+	if c.Offline && len(c.TemplateCache) == 0 {
+		return fmt.Errorf("no template cache provided for offline compilation")
+	}
+
 	// handle the action based off the provided configuration
 	switch c.Action {
 	case "get":
@@ -41,6 +49,48 @@ func (c *Config) Validate() error {
 	case "expand":
 		fallthrough
 	case "view":
+		// check if the pipeline is viewed as a graph
+		if c.Action == internal.ActionView {
+			switch c.Graph {
+			case "", GraphASCII, GraphDOT, GraphMermaid:
+			default:
+				return fmt.Errorf("invalid graph format: %s (valid formats: %s, %s, %s)", c.Graph, GraphASCII, GraphDOT, GraphMermaid)
+			}
+
+			// check if the environment of a step is viewed
+			if c.Env {
+				if !c.Local {
+					return fmt.Errorf("env can only be viewed for a local pipeline")
+				}
+
+				if len(c.Graph) > 0 {
+					return fmt.Errorf("graph and env can not be viewed together")
+				}
+
+				if len(c.Step) == 0 {
+					return fmt.Errorf("no step provided to view the environment of")
+				}
+			}
+
+			if c.Local && len(c.Graph) == 0 && !c.Env {
+				return fmt.Errorf("no graph format provided for local pipeline")
+			}
+		}
+
+		// check if the pipeline is compiled or viewed from a local file
+		if (c.Action == internal.ActionCompile || c.Action == internal.ActionView) && c.Local {
+			// check if pipeline file is set
+			if len(c.File) == 0 {
+				return fmt.Errorf("no pipeline file provided")
+			}
+
+			if strings.EqualFold(c.Event, constants.EventTag) && len(c.Tag) == 0 {
+				return fmt.Errorf("no tag provided for tag event")
+			}
+
+			break
+		}
+
 		// check if pipeline org is set
 		if len(c.Org) == 0 {
 			return fmt.Errorf("no pipeline org provided")
@@ -55,6 +105,55 @@ func (c *Config) Validate() error {
 		if len(c.Ref) == 0 {
 			return fmt.Errorf("no pipeline ref provided")
 		}
+	case "diff":
+		// check if pipeline org is set
+		if len(c.Org) == 0 {
+			return fmt.Errorf("no pipeline org provided")
+		}
+
+		// check if pipeline repo is set
+		if len(c.Repo) == 0 {
+			return fmt.Errorf("no pipeline name provided")
+		}
+
+		// check if the pipeline is compared with a local file
+		if c.Local {
+			// check if pipeline file is set
+			if len(c.File) == 0 {
+				return fmt.Errorf("no pipeline file provided")
+			}
+
+			if len(c.Refs) != 1 {
+				return fmt.Errorf("one pipeline ref must be provided to compare with a local pipeline")
+			}
+		} else if len(c.Refs) != 2 {
+			return fmt.Errorf("two pipeline refs must be provided to compare")
+		}
+
+		switch c.Output {
+		case "", diffText, output.DriverJSON:
+		default:
+			return fmt.Errorf("invalid output format: %s (valid formats: %s, %s)", c.Output, diffText, output.DriverJSON)
+		}
+	case "test":
+		// check if pipeline file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no pipeline file provided")
+		}
+
+		for _, file := range c.TemplateFiles {
+			parts := strings.Split(file, ":")
+
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid format for template file: %s (valid format: <name>:<source>)", file)
+			}
+		}
+
+		switch c.Output {
+		case "", testText, testJUnit:
+		default:
+			return fmt.Errorf("invalid output format: %s (valid formats: %s, %s)", c.Output, testText, testJUnit)
+		}
 	case "generate":
 		fallthrough
 	case "validate":
@@ -72,17 +171,147 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("invalid format for template file: %s (valid format: <name>:<source>)", file)
 			}
 		}
+
+		if c.Explain && c.Remote {
+			return fmt.Errorf("unable to explain a remote pipeline")
+		}
+
+		// check if many pipeline files are validated
+		if len(c.Files) > 0 {
+			if c.Explain {
+				return fmt.Errorf("unable to explain multiple pipeline files")
+			}
+
+			if c.Remote {
+				return fmt.Errorf("unable to validate multiple pipeline files remotely")
+			}
+
+			if c.Concurrency < 0 {
+				return fmt.Errorf("invalid concurrency: %d", c.Concurrency)
+			}
+		}
+
+		// check if the output format is valid for local pipelines
+		if !c.Remote {
+			if c.Explain && len(c.Output) > 0 {
+				return fmt.Errorf("unable to explain a pipeline with output format %s", c.Output)
+			}
+
+			switch c.Output {
+			case "", output.DriverJSON, output.DriverYAML:
+			default:
+				return fmt.Errorf("invalid output format: %s (valid formats: %s, %s)", c.Output, output.DriverJSON, output.DriverYAML)
+			}
+		}
+	case "init":
+		// check if pipeline file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no pipeline file provided")
+		}
+
+		if len(c.Type) > 0 && !slices.Contains(initTypes, c.Type) {
+			return fmt.Errorf("invalid pipeline type: %s (valid types: %s)", c.Type, strings.Join(initTypes, ", "))
+		}
+	case "fmt":
+		// check if pipeline file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no pipeline file provided")
+		}
+
+		if c.Check && c.Write {
+			return fmt.Errorf("unable to check and write a pipeline at the same time")
+		}
+	case "convert":
+		// check if pipeline file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no pipeline file provided")
+		}
+
+		// check if source file is set
+		if len(c.Source) == 0 {
+			return fmt.Errorf("no source file provided")
+		}
+
+		switch c.From {
+		case ConvertDrone, ConvertGitHubActions, ConvertGitLab:
+		default:
+			return fmt.Errorf("invalid source CI system: %s (valid systems: %s, %s, %s)", c.From, ConvertDrone, ConvertGitHubActions, ConvertGitLab)
+		}
+	case "lint":
+		// check if pipeline file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no pipeline file provided")
+		}
+
+		switch c.Output {
+		case "", lintText, output.DriverJSON, lintSARIF:
+		default:
+			return fmt.Errorf("invalid output format: %s (valid formats: %s, %s, %s)", c.Output, lintText, output.DriverJSON, lintSARIF)
+		}
+
+		switch c.FailOn {
+		case "", lintError, lintWarning, lintNote, lintNone:
+		default:
+			return fmt.Errorf("invalid fail on severity: %s (valid severities: %s, %s, %s, %s)", c.FailOn, lintError, lintWarning, lintNote, lintNone)
+		}
 	case "exec":
 		if strings.EqualFold(c.Event, constants.EventTag) && len(c.Tag) == 0 {
 			return fmt.Errorf("no tag provided for tag event")
 		}
+
+		if !c.CleanRoom && (len(c.CleanRoomRef) > 0 || len(c.ExportArtifacts) > 0) {
+			return fmt.Errorf("clean room must be enabled to provide a clean room ref or export artifacts")
+		}
+
+		if !c.Watch && len(c.WatchPaths) > 0 {
+			return fmt.Errorf("watch must be enabled to provide watch paths")
+		}
+
+		// the clean room runs against the committed files, so changes
+		// to the working tree would never reach the watched pipeline
+		if c.Watch && c.CleanRoom {
+			return fmt.Errorf("watch can not be used with clean room")
+		}
+
+		if len(c.Caches) > 0 && (len(c.Org) == 0 || len(c.Repo) == 0) {
+			return fmt.Errorf("no pipeline org or repo provided to scope caches")
+		}
+
+		for _, cache := range c.Caches {
+			_, _, err := internal.ParseCache(cache)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, _, err := parseLimits(c.CPUs, c.Memory)
+		if err != nil {
+			return err
+		}
+
+		if c.Timeout < 0 {
+			return fmt.Errorf("invalid timeout: %s", c.Timeout)
+		}
+
+		_, err = parseStepTimeouts(c.StepTimeouts)
+		if err != nil {
+			return err
+		}
+
+		if c.PrepullLimit < 0 {
+			return fmt.Errorf("invalid prepull limit: %d", c.PrepullLimit)
+		}
+
+		if len(c.Report) > 0 && c.Report != output.DriverJSON {
+			return fmt.Errorf("invalid report format: %s (valid formats: %s)", c.Report, output.DriverJSON)
+		}
 	}
 
 	return nil
 }
 
 // ValidateLocal verifies a local pipeline based off the provided configuration.
-func (c *Config) ValidateLocal(client compiler.Engine) error {
+func (c *Config) ValidateLocal(ctx context.Context, client compiler.Engine) error {
 	logrus.Debug("executing validate for local pipeline configuration")
 
 	// send Filesystem call to capture base directory path
@@ -106,12 +335,128 @@ func (c *Config) ValidateLocal(client compiler.Engine) error {
 		return err
 	}
 
+	p, err := c.validateLocal(ctx, client, path)
+	if err != nil {
+		return err
+	}
+
+	// check to see if locally provided templates were included in compilation
+	for _, tmpl := range c.TemplateFiles {
+		name, _, _ := strings.Cut(tmpl, ":")
+
+		if !includesTemplate(p, name) {
+			return fmt.Errorf("local template with name %s not included in pipeline templates", name)
+		}
+	}
+
+	// output the message in stderr format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+	err = output.Stderr(fmt.Sprintf("%s is valid", path))
+	if err != nil {
+		return err
+	}
+
+	// check if the rulesets of the pipeline should be explained
+	if c.Explain {
+		return c.explain(p)
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverJSON:
+		// output the validated pipeline in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(p, c.Color)
+	default:
+		// output the validated pipeline in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(p, c.Color)
+	}
+}
+
+// validateLocal compiles the local pipeline file at the
+// path to verify it based off the provided configuration.
+//
+// The pipeline is verified against the schema before compiling it to report
+// the location of every error in the pipeline. Those errors only fail the
+// validation when the compiler rejects the pipeline as well, or in strict
+// mode, and are reported as warnings otherwise.
+func (c *Config) validateLocal(ctx context.Context, client compiler.Engine, path string) (*yaml.Build, error) {
+	var schemaErr error
+
+	if !c.NoSchema {
+		schemaErr = c.validateSchema(path)
+		if schemaErr != nil && c.Strict {
+			return nil, schemaErr
+		}
+	}
+
+	p, err := c.compileLite(ctx, client, path)
+	if err != nil {
+		if schemaErr != nil {
+			return nil, errors.Join(schemaErr, err)
+		}
+
+		return nil, err
+	}
+
+	if schemaErr != nil {
+		logrus.Warn(schemaErr)
+	}
+
+	return p, nil
+}
+
+// compileLite compiles the local pipeline file at the path
+// without expanding it based off the provided configuration.
+func (c *Config) compileLite(ctx context.Context, client compiler.Engine, path string) (*yaml.Build, error) {
+	// capture the file changeset from the local git repository
+	err := c.loadGitChangeset(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
 	// set pipelineType within client
 	client.WithRepo(&api.Repo{PipelineType: &c.PipelineType})
 
+	// pin the remote templates to the commits in the lockfile
+	source, err := c.lockedPipeline(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// add the remote templates from the template cache
+	templateFiles, err := c.cachedTemplates(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	client = client.WithLocalTemplates(templateFiles)
+
 	var p *yaml.Build
 
-	if len(c.Branch) > 0 ||
+	// default the branch to explain to the one checked out in the local git repository
+	if c.Explain && len(c.Branch) == 0 {
+		head, err := internal.GetGitHead(filepath.Dir(path))
+		if err == nil {
+			logrus.Debugf("setting branch from git to %s", head.Branch)
+
+			c.Branch = head.Branch
+		}
+	}
+
+	// compile without ruledata when explaining the pipeline to keep every step
+	if c.Explain {
+		logrus.Debugf("compiling pipeline for explaining rulesets")
+
+		p, _, err = client.CompileLite(ctx, source, nil, false)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(c.Branch) > 0 ||
 		len(c.Comment) > 0 ||
 		len(c.Event) > 0 ||
 		len(c.FileChangeset) > 0 ||
@@ -119,6 +464,16 @@ func (c *Config) ValidateLocal(client compiler.Engine) error {
 		len(c.Target) > 0 {
 		logrus.Debugf("compiling pipeline with ruledata")
 
+		// default the branch to the one checked out in the local git repository
+		if len(c.Branch) == 0 {
+			head, err := internal.GetGitHead(filepath.Dir(path))
+			if err == nil {
+				logrus.Debugf("setting branch from git to %s", head.Branch)
+
+				c.Branch = head.Branch
+			}
+		}
+
 		// define ruledata
 		ruleData := &pipeline.RuleData{
 			Branch:  c.Branch,
@@ -146,53 +501,73 @@ func (c *Config) ValidateLocal(client compiler.Engine) error {
 
 				ruleData.Event = fmt.Sprintf("%s:%s", constants.EventDeploy, constants.ActionCreated)
 			case constants.EventDelete:
-				return fmt.Errorf("event %s must supply an action (branch or tag)", c.Event)
+				return nil, fmt.Errorf("event %s must supply an action (branch or tag)", c.Event)
 			}
 		}
 
 		// compile the object into a pipeline with ruledata
-		p, _, err = client.CompileLite(context.Background(), path, ruleData, false)
+		p, _, err = client.CompileLite(ctx, source, ruleData, false)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		logrus.Debugf("compiling pipeline")
 
 		// compile the object into a pipeline without ruledata
-		p, _, err = client.CompileLite(context.Background(), path, nil, false)
+		p, _, err = client.CompileLite(ctx, source, nil, false)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	// check to see if locally provided templates were included in compilation
-	for _, tmpl := range c.TemplateFiles {
-		parts := strings.Split(tmpl, ":")
-		included := false
+	return p, nil
+}
 
-		for _, pTmpl := range p.Templates {
-			if strings.EqualFold(parts[0], pTmpl.Name) {
-				included = true
-			}
-		}
+// validateSchema verifies the local pipeline file at the path against
+// the schema for pipelines, reporting every unknown key, type mismatch
+// and invalid enum value with its location in the file.
+func (c *Config) validateSchema(path string) error {
+	// only yaml pipelines can be verified against the schema
+	if len(c.PipelineType) > 0 && c.PipelineType != constants.PipelineTypeYAML {
+		return nil
+	}
 
-		if !included {
-			return fmt.Errorf("local template with name %s not included in pipeline templates", parts[0])
-		}
+	logrus.Debugf("validating pipeline %s against schema", path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read pipeline %s: %w", path, err)
 	}
 
-	// output the message in stderr format
-	//
-	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
-	err = output.Stderr(fmt.Sprintf("%s is valid", path))
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#ValidateSchema
+	errs, err := internal.ValidateSchema(path, data)
 	if err != nil {
 		return err
 	}
 
-	// output the validated pipeline in stdout format
-	//
-	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
-	return output.YAML(p, c.Color)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	lines := []string{}
+
+	for _, e := range errs {
+		lines = append(lines, e.Error())
+	}
+
+	return fmt.Errorf("pipeline does not match the schema:\n%s", strings.Join(lines, "\n"))
+}
+
+// includesTemplate returns true if the compiled
+// pipeline includes the template with the name.
+func includesTemplate(p *yaml.Build, name string) bool {
+	for _, tmpl := range p.Templates {
+		if strings.EqualFold(name, tmpl.Name) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ValidateRemote validates a remote pipeline based off the provided configuration.
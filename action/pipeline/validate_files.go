@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/constants"
+)
+
+// validateResult represents the result of validating a pipeline file.
+type validateResult struct {
+	File  string `json:"file"            yaml:"file"`
+	Valid bool   `json:"valid"           yaml:"valid"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ValidateFiles verifies many local pipelines, matched by the provided
+// globs and directories, concurrently based off the provided configuration.
+//
+// A compiler is created for each pipeline since the compiler is not safe
+// to share between concurrent compilations.
+func (c *Config) ValidateFiles(ctx context.Context, newClient func() (compiler.Engine, error)) error {
+	logrus.Debug("executing validate for local pipeline files configuration")
+
+	files, err := c.pipelineFiles()
+	if err != nil {
+		return err
+	}
+
+	logrus.Tracef("validating %d pipeline files", len(files))
+
+	results := make([]*validateResult, len(files))
+	templates := make([][]string, len(files))
+
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group
+	g := new(errgroup.Group)
+
+	limit := c.Concurrency
+	if limit == 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+
+	g.SetLimit(limit)
+
+	for i, file := range files {
+		g.Go(func() error {
+			results[i] = &validateResult{File: file, Valid: true}
+
+			// check if the validation was canceled
+			if ctx.Err() != nil {
+				results[i].Valid, results[i].Error = false, ctx.Err().Error()
+
+				return nil
+			}
+
+			client, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			// copy the configuration since validating a pipeline updates it
+			conf := *c
+			conf.FileChangeset = slices.Clone(c.FileChangeset)
+
+			logrus.Tracef("validating pipeline file %s", file)
+
+			p, err := conf.validateLocal(ctx, client, file)
+			if err != nil {
+				results[i].Valid, results[i].Error = false, err.Error()
+
+				return nil
+			}
+
+			for _, tmpl := range p.Templates {
+				templates[i] = append(templates[i], tmpl.Name)
+			}
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+
+	for _, result := range results {
+		if !result.Valid {
+			invalid++
+		}
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverJSON:
+		// output the results in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		err = output.JSON(results, c.Color)
+	case output.DriverYAML:
+		// output the results in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		err = output.YAML(results, c.Color)
+	default:
+		// output the results in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		err = output.Stdout(validateReport(results, invalid))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d pipeline files are invalid", invalid, len(results))
+	}
+
+	included := make(map[string]bool)
+
+	for _, names := range templates {
+		for _, name := range names {
+			included[strings.ToLower(name)] = true
+		}
+	}
+
+	// check to see if locally provided templates were included in any compilation
+	for _, tmpl := range c.TemplateFiles {
+		name, _, _ := strings.Cut(tmpl, ":")
+
+		if !included[strings.ToLower(name)] {
+			return fmt.Errorf("local template with name %s not included in templates of any pipeline", name)
+		}
+	}
+
+	return nil
+}
+
+// validateReport creates the report of the results from validating
+// pipeline files with the status and error of each file.
+func validateReport(results []*validateResult, invalid int) string {
+	b := new(strings.Builder)
+
+	for _, result := range results {
+		if result.Valid {
+			fmt.Fprintf(b, "PASS %s\n", result.File)
+
+			continue
+		}
+
+		fmt.Fprintf(b, "FAIL %s\n", result.File)
+
+		for line := range strings.SplitSeq(strings.TrimSpace(result.Error), "\n") {
+			fmt.Fprintf(b, "    %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(b, "\n%d pipeline files validated, %d valid, %d invalid", len(results), len(results)-invalid, invalid)
+
+	return b.String()
+}
+
+// pipelineFiles captures the pipeline files matched by the globs
+// and directories of the configuration, relative to the path.
+func (c *Config) pipelineFiles() ([]string, error) {
+	files := []string{}
+
+	for _, pattern := range c.Files {
+		// check if custom path was provided for pipeline files
+		if len(c.Path) > 0 && !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(c.Path, pattern)
+		}
+
+		matches, err := c.matchFiles(filepath.Clean(pattern))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no pipeline files found for %s", pattern)
+		}
+
+		files = append(files, matches...)
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+// matchFiles returns the pipeline files for a glob, where a directory
+// matches the pipeline files it contains, including subdirectories.
+func (c *Config) matchFiles(pattern string) ([]string, error) {
+	info, err := os.Stat(pattern)
+	if err == nil && !info.IsDir() {
+		return []string{pattern}, nil
+	}
+
+	// capture the directory to search and the glob for the files within it
+	root, match := pattern, func(string) bool { return true }
+
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		// capture the leading directories of the glob without any wildcards
+		parts := strings.Split(filepath.ToSlash(pattern), "/")
+		prefix := slices.IndexFunc(parts, func(part string) bool {
+			return strings.ContainsAny(part, "*?[")
+		})
+
+		// the pattern is not a glob, so the file does not exist
+		if prefix < 0 {
+			return nil, fmt.Errorf("configuration file of %s does not exist", pattern)
+		}
+
+		root = filepath.FromSlash(strings.Join(parts[:prefix], "/"))
+		if len(root) == 0 {
+			root = "."
+		}
+
+		re := globToRegexp(pattern)
+
+		match = func(path string) bool {
+			return re.MatchString(filepath.ToSlash(path))
+		}
+	}
+
+	files := []string{}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			// skip the git metadata of the repository
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if match(path) && c.isPipelineFile(path) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// isPipelineFile returns true if the file is a pipeline
+// for the pipeline type based off its extension, skipping
+// lockfiles, lint configuration and the scenarios used to
+// test pipelines.
+func (c *Config) isPipelineFile(path string) bool {
+	name := filepath.Base(path)
+
+	if name == internal.LockFile || name == lintConfigFile || strings.HasSuffix(name, testFileSuffix) {
+		return false
+	}
+
+	switch filepath.Ext(name) {
+	case ".yml", ".yaml":
+		return c.PipelineType != constants.PipelineTypeStarlark
+	case ".star", ".py":
+		return c.PipelineType == constants.PipelineTypeStarlark
+	default:
+		return false
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/native"
+)
+
+func TestPipeline_Config_ValidateFiles(t *testing.T) {
+	// setup types
+	cmd := new(cli.Command)
+	cmd.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone-image",
+			Value: "target/vela-git:latest",
+		},
+	}
+
+	newClient := func() (compiler.Engine, error) {
+		client, err := native.FromCLICommand(t.Context(), cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		client.SetTemplateDepth(1)
+
+		return client.WithLocal(true), nil
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+	}{
+		{
+			name:    "glob",
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				Files:  []string{"validate/*.yml"},
+				Path:   "testdata",
+			},
+		},
+		{
+			name:    "recursive glob with ruledata",
+			failure: false,
+			config: &Config{
+				Action:      "validate",
+				Files:       []string{"testdata/validate/**/*.yml"},
+				Event:       "push",
+				Branch:      "main",
+				Concurrency: 1,
+			},
+		},
+		{
+			name:    "json output",
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				Files:  []string{"testdata/validate/build.yml", "testdata/validate/nested/deploy.yml"},
+				Output: "json",
+			},
+		},
+		{
+			name:    "yaml output",
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				Files:  []string{"testdata/validate/build.yml", "testdata/validate/nested/deploy.yml"},
+				Output: "yaml",
+			},
+		},
+		{
+			name:    "directory with invalid pipeline",
+			failure: true,
+			config: &Config{
+				Action: "validate",
+				Files:  []string{"testdata/validate"},
+			},
+		},
+		{
+			name:    "no matching files",
+			failure: true,
+			config: &Config{
+				Action: "validate",
+				Files:  []string{"testdata/validate/*.json"},
+			},
+		},
+		{
+			name:    "unused template file",
+			failure: true,
+			config: &Config{
+				Action:        "validate",
+				Files:         []string{"testdata/validate/*.yml"},
+				TemplateFiles: []string{"unused:testdata/templates/template.yml"},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.ValidateFiles(t.Context(), newClient)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("(%s) ValidateFiles should have returned err", test.name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("(%s) ValidateFiles returned err: %v", test.name, err)
+		}
+	}
+}
+
+func TestPipeline_Config_pipelineFiles(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+		want    []string
+	}{
+		{
+			name: "directory",
+			config: &Config{
+				Files: []string{"testdata/validate"},
+			},
+			want: []string{
+				filepath.Join("testdata", "validate", "build.yml"),
+				filepath.Join("testdata", "validate", "nested", "deploy.yml"),
+				filepath.Join("testdata", "validate", "nested", "invalid.yaml"),
+			},
+		},
+		{
+			name: "glob",
+			config: &Config{
+				Files: []string{"testdata/validate/*/*.yml"},
+			},
+			want: []string{
+				filepath.Join("testdata", "validate", "nested", "deploy.yml"),
+			},
+		},
+		{
+			name: "overlapping globs with path",
+			config: &Config{
+				Files: []string{"validate/**/*.yml", "validate/build.yml"},
+				Path:  "testdata",
+			},
+			want: []string{
+				filepath.Join("testdata", "validate", "build.yml"),
+				filepath.Join("testdata", "validate", "nested", "deploy.yml"),
+			},
+		},
+		{
+			name: "skips lockfiles, lint configuration and test files",
+			config: &Config{
+				Files: []string{"testdata/lock", "testdata/lint", "testdata/test"},
+			},
+			want: []string{
+				filepath.Join("testdata", "lint", ".vela.yml"),
+				filepath.Join("testdata", "lock", ".vela.yml"),
+				filepath.Join("testdata", "test", "pipeline.yml"),
+			},
+		},
+		{
+			name:    "missing file",
+			failure: true,
+			config: &Config{
+				Files: []string{"testdata/validate/missing.yml"},
+			},
+		},
+		{
+			name:    "starlark pipelines",
+			failure: true,
+			config: &Config{
+				Files:        []string{"testdata/validate"},
+				PipelineType: "starlark",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := test.config.pipelineFiles()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("(%s) pipelineFiles should have returned err", test.name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("(%s) pipelineFiles returned err: %v", test.name, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("(%s) pipelineFiles is %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"net/http/httptest"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
@@ -31,6 +34,163 @@ func TestPipeline_Config_Validate(t *testing.T) {
 				Output: "",
 			},
 		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   ".vela.yml",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "compile",
+				Local:  true,
+				File:   "",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "init",
+				File:   ".vela.yml",
+				Type:   "rust",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "init",
+				File:   ".vela.yml",
+				Type:   "cobol",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "fmt",
+				File:   ".vela.yml",
+				Check:  true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "fmt",
+				File:   ".vela.yml",
+				Check:  true,
+				Write:  true,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "convert",
+				File:   ".vela.yml",
+				From:   "gitlab",
+				Source: ".gitlab-ci.yml",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "convert",
+				File:   ".vela.yml",
+				From:   "gitlab",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "convert",
+				File:   ".vela.yml",
+				From:   "jenkins",
+				Source: "Jenkinsfile",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "lint",
+				File:   ".vela.yml",
+				Output: "sarif",
+				FailOn: "warning",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "lint",
+				File:   ".vela.yml",
+				Output: "yaml",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "lint",
+				File:   ".vela.yml",
+				FailOn: "info",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main", "dev"},
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main"},
+				File:   ".vela.yml",
+				Local:  true,
+				Output: "json",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main"},
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "diff",
+				Org:    "github",
+				Repo:   "octocat",
+				Refs:   []string{"main", "dev"},
+				Output: "yaml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:    "test",
+				File:      ".vela.yml",
+				TestFiles: []string{".vela-test.yml"},
+				Output:    "junit",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "test",
+				File:   ".vela.yml",
+				Output: "json",
+			},
+		},
 		{
 			failure: true,
 			config: &Config{
@@ -50,6 +210,121 @@ func TestPipeline_Config_Validate(t *testing.T) {
 				Tag:    "v1.0.0",
 			},
 		},
+		{
+			failure: false,
+			config: &Config{
+				Action:          "exec",
+				Org:             "github",
+				Repo:            "octocat",
+				CleanRoom:       true,
+				CleanRoomRef:    "main",
+				ExportArtifacts: []string{"dist/*"},
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:          "exec",
+				Org:             "github",
+				Repo:            "octocat",
+				ExportArtifacts: []string{"dist/*"},
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:    "exec",
+				Org:       "github",
+				Repo:      "octocat",
+				CleanRoom: true,
+				Watch:     true,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "exec",
+				Org:    "github",
+				Repo:   "octocat",
+				Caches: []string{"gomod:/root/go/pkg/mod"},
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "exec",
+				Caches: []string{"gomod:/root/go/pkg/mod"},
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "exec",
+				Org:    "github",
+				Repo:   "octocat",
+				Caches: []string{"/root/go/pkg/mod"},
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:       "exec",
+				CPUs:         "1.5",
+				Memory:       "512m",
+				Timeout:      30 * time.Minute,
+				StepTimeouts: []string{"5m", "test=15m"},
+				FailFast:     true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "exec",
+				CPUs:   "-1",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "exec",
+				Memory: "512q",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:  "exec",
+				Timeout: -time.Minute,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:       "exec",
+				StepTimeouts: []string{"test=soon"},
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "exec",
+				Report: "json",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "exec",
+				Report: "junit",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:       "exec",
+				PrepullLimit: -1,
+			},
+		},
 		{
 			failure: false,
 			config: &Config{
@@ -98,6 +373,117 @@ func TestPipeline_Config_Validate(t *testing.T) {
 				TemplateFiles: []string{"nottwoelements"},
 			},
 		},
+		{
+			failure: true,
+			config: &Config{
+				Action:  "validate",
+				Org:     "github",
+				Repo:    "octocat",
+				Remote:  true,
+				Explain: true,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:      "validate",
+				File:        ".vela.yml",
+				Files:       []string{"ci/*.yml"},
+				Concurrency: 4,
+				Output:      "json",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:  "validate",
+				File:    ".vela.yml",
+				Files:   []string{"ci/*.yml"},
+				Explain: true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:      "validate",
+				File:        ".vela.yml",
+				Files:       []string{"ci/*.yml"},
+				Concurrency: -1,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				File:   ".vela.yml",
+				Files:  []string{"ci/*.yml"},
+				Output: "yaml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				File:   ".vela.yml",
+				Output: "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				File:   ".vela.yml",
+				Output: "yaml",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "validate",
+				File:   ".vela.yml",
+				Output: "sarif",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:  "validate",
+				File:    ".vela.yml",
+				Explain: true,
+				Output:  "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				File:   ".vela.yml",
+				Org:    "github",
+				Repo:   "octocat",
+				Ref:    "main",
+				Remote: true,
+				Output: "yaml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "validate",
+				File:          "default.yml",
+				Path:          "testdata",
+				TemplateCache: "/tmp/templates",
+				Offline:       true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:  "validate",
+				File:    "default.yml",
+				Path:    "testdata",
+				Offline: true,
+			},
+		},
 		{
 			failure: false,
 			config: &Config{
@@ -146,6 +532,84 @@ func TestPipeline_Config_Validate(t *testing.T) {
 				Output: "",
 			},
 		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "view",
+				Org:    "github",
+				Repo:   "octocat",
+				Ref:    "48afb5bdc41ad69bf22588491333f7cf71135163",
+				Graph:  "mermaid",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "view",
+				File:   ".vela.yml",
+				Local:  true,
+				Graph:  "ascii",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "view",
+				Org:    "github",
+				Repo:   "octocat",
+				Ref:    "48afb5bdc41ad69bf22588491333f7cf71135163",
+				Graph:  "svg",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "view",
+				File:   ".vela.yml",
+				Local:  true,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "view",
+				File:   ".vela.yml",
+				Local:  true,
+				Env:    true,
+				Step:   "test",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "view",
+				File:   ".vela.yml",
+				Local:  true,
+				Env:    true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "view",
+				File:   ".vela.yml",
+				Local:  true,
+				Env:    true,
+				Step:   "test",
+				Graph:  "ascii",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "view",
+				File:   ".vela.yml",
+				Local:  true,
+				Env:    true,
+				Step:   "test",
+				Event:  "tag",
+			},
+		},
 	}
 
 	// run tests
@@ -334,13 +798,57 @@ func TestPipeline_Config_ValidateLocal(t *testing.T) {
 				Tag:    "v1",
 			},
 		},
+		{
+			name:    "pipeline with rulesets - explain",
+			failure: false,
+			config: &Config{
+				Action:  "validate",
+				File:    "ruleset.yml",
+				Path:    "testdata",
+				Type:    "",
+				Branch:  "main,dev",
+				Tag:     "v1",
+				Explain: true,
+			},
+		},
+		{
+			name:    "pipeline not matching schema rejected by the compiler",
+			failure: true,
+			config: &Config{
+				Action: "validate",
+				File:   ".vela.yml",
+				Path:   "testdata/schema",
+				Type:   "",
+			},
+		},
+		{
+			name:    "pipeline not matching schema",
+			failure: false,
+			config: &Config{
+				Action: "validate",
+				File:   "unknown.yml",
+				Path:   "testdata/schema",
+				Type:   "",
+			},
+		},
+		{
+			name:    "pipeline not matching schema - strict",
+			failure: true,
+			config: &Config{
+				Action: "validate",
+				File:   "unknown.yml",
+				Path:   "testdata/schema",
+				Type:   "",
+				Strict: true,
+			},
+		},
 	}
 
 	// run tests
 	for _, test := range tests {
 		isLocal := len(test.config.TemplateFiles) > 0
 
-		err := test.config.ValidateLocal(client.WithLocal(isLocal).WithLocalTemplates(test.config.TemplateFiles))
+		err := test.config.ValidateLocal(t.Context(), client.WithLocal(isLocal).WithLocalTemplates(test.config.TemplateFiles))
 
 		if test.failure {
 			if err == nil {
@@ -356,6 +864,76 @@ func TestPipeline_Config_ValidateLocal(t *testing.T) {
 	}
 }
 
+func TestPipeline_Config_validateSchema(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		config *Config
+		path   string
+		want   string
+	}{
+		{
+			name:   "valid pipeline",
+			config: &Config{},
+			path:   "testdata/default.yml",
+		},
+		{
+			name:   "invalid pipeline",
+			config: &Config{},
+			path:   "testdata/schema/.vela.yml",
+			want:   `testdata/schema/.vela.yml:7:5: unknown key "command" in steps[0]`,
+		},
+		{
+			name:   "starlark pipeline",
+			config: &Config{PipelineType: "starlark"},
+			path:   "testdata/schema/.vela.yml",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.validateSchema(test.path)
+
+			if len(test.want) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.want) {
+					t.Errorf("validateSchema is %v, want %s", err, test.want)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("validateSchema returned err: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_Config_validateSchema_Testdata(t *testing.T) {
+	// setup types
+	c := &Config{}
+
+	files, err := filepath.Glob("testdata/*.yml")
+	if err != nil {
+		t.Fatalf("unable to find testdata pipelines: %v", err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("no testdata pipelines found")
+	}
+
+	// run tests
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			err := c.validateSchema(file)
+			if err != nil {
+				t.Errorf("validateSchema returned err: %v", err)
+			}
+		})
+	}
+}
+
 func TestPipeline_Config_ValidateRemote(t *testing.T) {
 	// setup test server
 	s := httptest.NewServer(server.FakeHandler())
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-vela/cli/internal/output"
 	"github.com/go-vela/sdk-go/vela"
+	"github.com/go-vela/server/compiler"
 )
 
 // View inspects a pipeline based off the provided configuration.
@@ -17,6 +18,16 @@ func (c *Config) View(ctx context.Context, client *vela.Client) error {
 
 	logrus.Tracef("inspecting pipeline %s/%s@%s", c.Org, c.Repo, c.Ref)
 
+	// check if the pipeline should be viewed as a graph
+	if len(c.Graph) > 0 {
+		pipeline, err := c.expandRemote(ctx, client, c.Ref)
+		if err != nil {
+			return err
+		}
+
+		return c.graph(pipeline)
+	}
+
 	// send API call to capture a pipeline
 	//
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#PipelineService.Get
@@ -54,3 +65,16 @@ func (c *Config) View(ctx context.Context, client *vela.Client) error {
 		return output.Stdout(string(pipeline.GetData()))
 	}
 }
+
+// ViewLocal renders the graph of a local pipeline
+// based off the provided configuration.
+func (c *Config) ViewLocal(ctx context.Context, client compiler.Engine) error {
+	logrus.Debug("executing view for local pipeline configuration")
+
+	pipeline, _, err := c.expandLocal(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	return c.graph(pipeline)
+}
@@ -75,6 +75,16 @@ func TestPipeline_Config_View(t *testing.T) {
 				Output: "yaml",
 			},
 		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "view",
+				Org:    "github",
+				Repo:   "octocat",
+				Ref:    "48afb5bdc41ad69bf22588491333f7cf71135163",
+				Graph:  "mermaid",
+			},
+		},
 		{
 			failure: true,
 			config: &Config{
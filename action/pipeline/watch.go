@@ -0,0 +1,437 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/compiler/types/pipeline"
+)
+
+// watchInterval is the duration between polls of the watched files.
+const watchInterval = 250 * time.Millisecond
+
+// watch executes the pipeline and re-runs it every time the
+// pipeline file, local templates or watched paths change.
+func (c *Config) watch(ctx context.Context, client compiler.Engine) error {
+	base, path, err := c.execPath()
+	if err != nil {
+		return err
+	}
+
+	// collect the explicit files to watch
+	files := []string{path}
+
+	for _, file := range c.TemplateFiles {
+		parts := strings.SplitN(file, ":", 2)
+		if len(parts) == 2 {
+			files = append(files, parts[1])
+		}
+	}
+
+	// capture the file changeset from git once, so the changed
+	// files of a re-run are added to the provided changeset
+	err = c.loadGitChangeset(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	c.ChangesetFromGit = ""
+
+	changeset := c.FileChangeset
+
+	w := newWatcher(base, files, c.WatchPaths)
+
+	// capture the initial state of the watched files
+	w.poll()
+
+	changes := make(chan []string)
+	reset := make(chan struct{})
+
+	// spawn go routine to watch for file changes
+	go w.watch(ctx, watchInterval, c.WatchDebounce, changes, reset)
+
+	// the files changed since the previous run
+	var changed []string
+
+	for {
+		// narrow the run to the steps matching the changed files
+		// when requested, unless the pipeline or templates changed
+		narrowed := changed
+		if !c.WatchMatching || w.explicit(changed) {
+			narrowed = nil
+		}
+
+		err = c.run(ctx, client, narrowed)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			logrus.Errorf("pipeline exec failed: %v", err)
+		}
+
+		// ignore the changes made while the pipeline was running,
+		// which includes the files written to the workspace by the
+		// build, to avoid re-running the pipeline in a loop
+		select {
+		case reset <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+
+		logrus.Info("waiting for changes - press Ctrl+C to exit")
+
+		select {
+		case changed = <-changes:
+			logrus.Infof("detected changes to %s - restarting pipeline", strings.Join(changed, ", "))
+		case <-ctx.Done():
+			return nil
+		}
+
+		// add the changed files to the changeset of the next run
+		files := slices.Concat(changeset, changed)
+
+		slices.Sort(files)
+
+		c.FileChangeset = slices.Compact(files)
+	}
+}
+
+// watchedSteps removes the steps without a path ruleset from the
+// pipeline to restrict a re-run to the steps matching the changed
+// files, as the steps with a path ruleset that doesn't match the
+// changed files are already removed when compiling the pipeline.
+//
+// The stages needing a removed stage need the stages it needed
+// instead, so the order of the remaining stages is kept.
+func watchedSteps(p *pipeline.Build, changed []string) error {
+	// counter for steps matching the changed files
+	matched := 0
+
+	// filter the steps, always keeping the steps
+	// injected to initialize and clone the build
+	filter := func(steps pipeline.ContainerSlice) pipeline.ContainerSlice {
+		filtered := steps[:0]
+
+		for _, step := range steps {
+			switch {
+			case len(step.Ruleset.If.Path) > 0:
+				matched++
+			case step.Name != "init" && step.Name != "clone":
+				continue
+			}
+
+			filtered = append(filtered, step)
+		}
+
+		return filtered
+	}
+
+	if len(p.Stages) > 0 {
+		// the needs of the removed stages keyed by name
+		removed := make(map[string][]string)
+
+		stages := p.Stages[:0]
+
+		for _, stage := range p.Stages {
+			stage.Steps = filter(stage.Steps)
+
+			if len(stage.Steps) == 0 {
+				removed[stage.Name] = stage.Needs
+
+				continue
+			}
+
+			stages = append(stages, stage)
+		}
+
+		for _, stage := range stages {
+			stage.Needs = neededStages(stage.Needs, removed)
+		}
+
+		p.Stages = stages
+	} else {
+		p.Steps = filter(p.Steps)
+	}
+
+	if matched == 0 {
+		return fmt.Errorf("no steps with a path ruleset matching changes to %s", strings.Join(changed, ", "))
+	}
+
+	logrus.Tracef("running %d steps matching changes to %s", matched, strings.Join(changed, ", "))
+
+	return nil
+}
+
+// neededStages returns the needs with every removed
+// stage replaced by the stages needed by that stage.
+func neededStages(needs []string, removed map[string][]string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+
+	var add func(names []string)
+
+	add = func(names []string) {
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+
+			seen[name] = true
+
+			if n, ok := removed[name]; ok {
+				add(n)
+
+				continue
+			}
+
+			result = append(result, name)
+		}
+	}
+
+	add(needs)
+
+	return result
+}
+
+// fileState represents the state of a watched file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher polls a set of files and glob patterns for changes.
+type watcher struct {
+	// base is the directory glob patterns are relative to.
+	base string
+	// files are the explicit files to watch.
+	files []string
+	// patterns are the glob patterns to watch within base.
+	patterns []*regexp.Regexp
+	// state is the last captured state of the watched files.
+	state map[string]fileState
+}
+
+// newWatcher creates a watcher for the provided files and patterns.
+func newWatcher(base string, files, patterns []string) *watcher {
+	w := &watcher{
+		base:  base,
+		state: make(map[string]fileState),
+	}
+
+	for _, file := range files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(base, file)
+		}
+
+		w.files = append(w.files, filepath.Clean(file))
+	}
+
+	for _, pattern := range patterns {
+		w.patterns = append(w.patterns, globToRegexp(pattern))
+	}
+
+	return w
+}
+
+// watch polls for changes on the provided interval until the context
+// is canceled. Changes are sent once no further changes are detected
+// for the debounce duration and, on a reset, the current state of
+// the files is captured and any pending changes are discarded.
+func (w *watcher) watch(ctx context.Context, interval, debounce time.Duration, out chan<- []string, reset <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	pending := []string{}
+
+	var last time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reset:
+			w.poll()
+
+			pending = []string{}
+		case now := <-ticker.C:
+			changed := w.poll()
+			if len(changed) > 0 {
+				pending = append(pending, changed...)
+				last = now
+			}
+
+			// wait for changes to settle before sending them
+			if len(pending) == 0 || now.Sub(last) < debounce {
+				continue
+			}
+
+			slices.Sort(pending)
+
+			select {
+			case out <- slices.Compact(pending):
+				pending = []string{}
+			case <-reset:
+				w.poll()
+
+				pending = []string{}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// poll captures the current state of the watched files and
+// returns the paths, relative to base, that changed since the
+// previous poll.
+func (w *watcher) poll() []string {
+	current := make(map[string]fileState)
+
+	for _, file := range w.files {
+		info, err := os.Stat(file)
+		if err == nil {
+			current[file] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	if len(w.patterns) > 0 {
+		_ = filepath.WalkDir(w.base, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if d.IsDir() {
+				if d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			rel, err := filepath.Rel(w.base, path)
+			if err != nil {
+				return nil
+			}
+
+			if !w.match(filepath.ToSlash(rel)) {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err == nil {
+				current[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			}
+
+			return nil
+		})
+	}
+
+	changed := []string{}
+
+	for path, state := range current {
+		prev, ok := w.state[path]
+		if !ok || prev != state {
+			changed = append(changed, w.rel(path))
+		}
+	}
+
+	for path := range w.state {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, w.rel(path))
+		}
+	}
+
+	w.state = current
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+// explicit returns true if a changed path is one of the explicit
+// files watched, which are the pipeline file and local templates.
+func (w *watcher) explicit(changed []string) bool {
+	for _, file := range w.files {
+		if slices.Contains(changed, w.rel(file)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match returns true if the relative path matches a watched pattern.
+func (w *watcher) match(rel string) bool {
+	for _, pattern := range w.patterns {
+		if pattern.MatchString(rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rel returns the path relative to base when it
+// is within base and the full path otherwise.
+func (w *watcher) rel(path string) string {
+	rel, err := filepath.Rel(w.base, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// globToRegexp converts a glob pattern into a regular expression
+// where `**` matches across directories and `*` and `?` match
+// within a single path segment.
+func globToRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+
+	runes := []rune(filepath.ToSlash(pattern))
+
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; ch {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+
+				// consume a trailing separator so `**/` also matches zero directories
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+
+					expr.WriteString("(?:.*/)?")
+				} else {
+					expr.WriteString(".*")
+				}
+
+				continue
+			}
+
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String())
+}
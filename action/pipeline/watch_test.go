@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-vela/server/compiler/types/pipeline"
+)
+
+func TestPipeline_globToRegexp(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.go", path: "main.go", want: true},
+		{pattern: "*.go", path: "cmd/main.go", want: false},
+		{pattern: "**/*.go", path: "main.go", want: true},
+		{pattern: "**/*.go", path: "cmd/vela/main.go", want: true},
+		{pattern: "src/**", path: "src/a/b.txt", want: true},
+		{pattern: "src/**", path: "other/a.txt", want: false},
+		{pattern: "file?.txt", path: "file1.txt", want: true},
+		{pattern: "file?.txt", path: "file10.txt", want: false},
+		{pattern: "docs/*.md", path: "docs/README.md", want: true},
+		{pattern: "dötfile.*", path: "dötfile.yml", want: true},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := globToRegexp(test.pattern).MatchString(test.path)
+
+		if got != test.want {
+			t.Errorf("globToRegexp(%s) match %s is %v, want %v", test.pattern, test.path, got, test.want)
+		}
+	}
+}
+
+func TestPipeline_watcher_poll(t *testing.T) {
+	// setup types
+	base := t.TempDir()
+
+	testWriteFile(t, filepath.Join(base, ".vela.yml"), "version: 1")
+	testWriteFile(t, filepath.Join(base, "src", "main.go"), "package main")
+	testWriteFile(t, filepath.Join(base, "README.md"), "readme")
+
+	w := newWatcher(base, []string{".vela.yml"}, []string{"**/*.go"})
+
+	// run tests
+	got := w.poll()
+
+	want := []string{".vela.yml", "src/main.go"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("initial poll is %v, want %v", got, want)
+	}
+
+	got = w.poll()
+	if len(got) != 0 {
+		t.Errorf("poll without changes is %v", got)
+	}
+
+	testWriteFile(t, filepath.Join(base, "src", "main.go"), "package main\n\nfunc main() {}")
+	testWriteFile(t, filepath.Join(base, "README.md"), "unwatched change")
+	testWriteFile(t, filepath.Join(base, "src", "new.go"), "package main")
+
+	got = w.poll()
+
+	want = []string{"src/main.go", "src/new.go"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("poll after changes is %v, want %v", got, want)
+	}
+
+	err := os.Remove(filepath.Join(base, "src", "new.go"))
+	if err != nil {
+		t.Fatalf("unable to remove file: %v", err)
+	}
+
+	got = w.poll()
+
+	want = []string{"src/new.go"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("poll after removal is %v, want %v", got, want)
+	}
+}
+
+func TestPipeline_watcher_watch(t *testing.T) {
+	// setup types
+	base := t.TempDir()
+
+	testWriteFile(t, filepath.Join(base, ".vela.yml"), "version: 1")
+
+	w := newWatcher(base, []string{".vela.yml"}, nil)
+	w.poll()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan []string)
+
+	reset := make(chan struct{})
+
+	go w.watch(ctx, 10*time.Millisecond, 50*time.Millisecond, changes, reset)
+
+	testWriteFile(t, filepath.Join(base, ".vela.yml"), "version: \"1\"")
+
+	// run test
+	select {
+	case got := <-changes:
+		if !slices.Equal(got, []string{".vela.yml"}) {
+			t.Errorf("watch sent %v, want [.vela.yml]", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("watch did not send changes")
+	}
+}
+
+func TestPipeline_watcher_watch_Reset(t *testing.T) {
+	// setup types
+	base := t.TempDir()
+
+	testWriteFile(t, filepath.Join(base, "dist", "app.go"), "package main")
+
+	w := newWatcher(base, nil, []string{"**/*.go"})
+	w.poll()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan []string)
+	reset := make(chan struct{})
+
+	go w.watch(ctx, 10*time.Millisecond, 50*time.Millisecond, changes, reset)
+
+	// simulate a build writing to a watched file while it is running
+	testWriteFile(t, filepath.Join(base, "dist", "app.go"), "package main\n\nfunc main() {}")
+
+	time.Sleep(30 * time.Millisecond)
+
+	// run test
+	reset <- struct{}{}
+
+	select {
+	case got := <-changes:
+		t.Errorf("watch sent %v for changes made before the reset", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	testWriteFile(t, filepath.Join(base, "main.go"), "package main")
+
+	select {
+	case got := <-changes:
+		if !slices.Equal(got, []string{"main.go"}) {
+			t.Errorf("watch sent %v, want [main.go]", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("watch did not send changes after the reset")
+	}
+}
+
+func TestPipeline_watcher_explicit(t *testing.T) {
+	// setup types
+	base := t.TempDir()
+
+	w := newWatcher(base, []string{".vela.yml", "templates/go.yml"}, []string{"**/*.go"})
+
+	// setup tests
+	tests := []struct {
+		changed []string
+		want    bool
+	}{
+		{changed: []string{".vela.yml"}, want: true},
+		{changed: []string{"main.go", "templates/go.yml"}, want: true},
+		{changed: []string{"main.go"}, want: false},
+		{changed: nil, want: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := w.explicit(test.changed)
+
+		if got != test.want {
+			t.Errorf("explicit(%v) is %v, want %v", test.changed, got, test.want)
+		}
+	}
+}
+
+// testWriteFile writes the content to the file creating any parent directories.
+func testWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	err = os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+}
+
+func TestPipeline_watchedSteps(t *testing.T) {
+	// setup types
+	path := pipeline.Ruleset{If: pipeline.Rules{Path: []string{"**/*.go"}}}
+
+	// setup tests
+	tests := []struct {
+		name       string
+		failure    bool
+		pipeline   *pipeline.Build
+		wantSteps  []string
+		wantStages []string
+		wantNeeds  map[string][]string
+	}{
+		{
+			name:    "steps",
+			failure: false,
+			pipeline: &pipeline.Build{
+				Steps: pipeline.ContainerSlice{
+					{Name: "init"},
+					{Name: "clone"},
+					{Name: "test", Ruleset: path},
+					{Name: "publish"},
+				},
+			},
+			wantSteps: []string{"init", "clone", "test"},
+		},
+		{
+			name:    "stages",
+			failure: false,
+			pipeline: &pipeline.Build{
+				Stages: pipeline.StageSlice{
+					{Name: "init", Steps: pipeline.ContainerSlice{{Name: "init"}}},
+					{Name: "test", Steps: pipeline.ContainerSlice{{Name: "test", Ruleset: path}, {Name: "lint"}}},
+					{Name: "publish", Steps: pipeline.ContainerSlice{{Name: "publish"}}},
+				},
+			},
+			wantSteps:  []string{"init", "test"},
+			wantStages: []string{"init", "test"},
+		},
+		{
+			name:    "stages with needs",
+			failure: false,
+			pipeline: &pipeline.Build{
+				Stages: pipeline.StageSlice{
+					{Name: "init", Steps: pipeline.ContainerSlice{{Name: "init"}}},
+					{Name: "clone", Needs: []string{"init"}, Steps: pipeline.ContainerSlice{{Name: "clone"}}},
+					{Name: "lint", Needs: []string{"clone"}, Steps: pipeline.ContainerSlice{{Name: "lint"}}},
+					{Name: "build", Needs: []string{"lint"}, Steps: pipeline.ContainerSlice{{Name: "build"}}},
+					{Name: "test", Needs: []string{"build", "clone"}, Steps: pipeline.ContainerSlice{{Name: "test", Ruleset: path}}},
+				},
+			},
+			wantSteps:  []string{"init", "clone", "test"},
+			wantStages: []string{"init", "clone", "test"},
+			wantNeeds:  map[string][]string{"init": {}, "clone": {"init"}, "test": {"clone"}},
+		},
+		{
+			name:    "no path rulesets",
+			failure: true,
+			pipeline: &pipeline.Build{
+				Steps: pipeline.ContainerSlice{
+					{Name: "init"},
+					{Name: "publish"},
+				},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := watchedSteps(test.pipeline, []string{"main.go"})
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("watchedSteps should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("watchedSteps returned err: %v", err)
+			}
+
+			gotSteps := []string{}
+			gotStages := []string{}
+
+			for _, step := range test.pipeline.Steps {
+				gotSteps = append(gotSteps, step.Name)
+			}
+
+			for _, stage := range test.pipeline.Stages {
+				gotStages = append(gotStages, stage.Name)
+
+				if want, ok := test.wantNeeds[stage.Name]; ok && !slices.Equal(stage.Needs, want) {
+					t.Errorf("watchedSteps needs of stage %s are %v, want %v", stage.Name, stage.Needs, want)
+				}
+
+				for _, step := range stage.Steps {
+					gotSteps = append(gotSteps, step.Name)
+				}
+			}
+
+			if !slices.Equal(gotSteps, test.wantSteps) {
+				t.Errorf("watchedSteps steps are %v, want %v", gotSteps, test.wantSteps)
+			}
+
+			if !slices.Equal(gotStages, test.wantStages) {
+				t.Errorf("watchedSteps stages are %v, want %v", gotStages, test.wantStages)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import "go.yaml.in/yaml/v3"
+
+// yamlResolve returns the node referenced by an alias.
+func yamlResolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// yamlCopy returns a deep copy of the node where aliases are
+// replaced with copies of the nodes they reference, so the copy
+// can be changed without changing the nodes shared by anchors.
+func yamlCopy(node *yaml.Node) *yaml.Node {
+	node = yamlResolve(node)
+	if node == nil {
+		return nil
+	}
+
+	copied := *node
+	copied.Anchor = ""
+
+	if node.Content != nil {
+		copied.Content = make([]*yaml.Node, 0, len(node.Content))
+
+		for _, n := range node.Content {
+			copied.Content = append(copied.Content, yamlCopy(n))
+		}
+	}
+
+	return &copied
+}
+
+// yamlMapValue returns the value for the key of the mapping node.
+func yamlMapValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := yamlMapPair(node, key)
+
+	return value
+}
+
+// yamlMapPair returns the key and value nodes for the key of the
+// mapping node, including the keys of merged mappings.
+func yamlMapPair(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	node = yamlResolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var merged []*yaml.Node
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case key:
+			return node.Content[i], yamlResolve(node.Content[i+1])
+		case "<<":
+			value := yamlResolve(node.Content[i+1])
+
+			if value.Kind == yaml.SequenceNode {
+				merged = append(merged, value.Content...)
+			} else {
+				merged = append(merged, value)
+			}
+		}
+	}
+
+	for _, m := range merged {
+		k, v := yamlMapPair(m, key)
+		if v != nil {
+			return k, v
+		}
+	}
+
+	return nil, nil
+}
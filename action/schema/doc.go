@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema provides the defined pipeline schema CLI actions for Vela.
+//
+// Usage:
+//
+//	import "github.com/go-vela/cli/action/schema"
+package schema
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// Generate produces the JSON Schema for the Vela YAML
+// pipeline format based off the provided configuration.
+func (c *Config) Generate() error {
+	logrus.Debug("executing generate for schema configuration")
+
+	// create the schema for pipelines
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#PipelineSchema
+	data, err := internal.PipelineSchema()
+	if err != nil {
+		return err
+	}
+
+	// check if the schema should be produced in YAML format
+	if c.Output == output.DriverYAML {
+		logrus.Trace("converting schema to YAML")
+
+		// JSON is valid YAML, so the schema is parsed into a node
+		// to keep the order of its keys in the YAML format
+		node := new(yaml.Node)
+
+		err = yaml.Unmarshal(data, node)
+		if err != nil {
+			return err
+		}
+
+		// clear the flow style of the JSON to produce block style YAML
+		clearStyle(node)
+
+		data, err = yaml.Marshal(node)
+		if err != nil {
+			return err
+		}
+	}
+
+	// check if the schema should be written to a file
+	if len(c.File) == 0 {
+		// output the schema in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		return output.Stdout(string(data))
+	}
+
+	// use custom filesystem which enables us to test
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#Afero
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	logrus.Tracef("creating directory structure to %s", c.File)
+
+	// send Filesystem call to create directory path for schema file
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#OsFs.MkdirAll
+	err = a.MkdirAll(filepath.Dir(c.File), 0777)
+	if err != nil {
+		return err
+	}
+
+	logrus.Tracef("writing schema to %s", c.File)
+
+	// send Filesystem call to create schema file
+	//
+	// https://pkg.go.dev/github.com/spf13/afero?tab=doc#Afero.WriteFile
+	err = a.WriteFile(c.File, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	// output the message in stderr format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stderr
+	return output.Stderr(fmt.Sprintf("created pipeline schema %s", c.File))
+}
+
+// clearStyle removes the style of the node and its content.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, n := range node.Content {
+		clearStyle(n)
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestSchema_Config_Generate(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				Output: "yaml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				File:   "schema/vela.schema.json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				File:   "schema/vela.schema.yml",
+				Output: "yaml",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.Generate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Generate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Generate returned err: %v", err)
+		}
+	}
+
+	a := &afero.Afero{Fs: appFS}
+
+	data, err := a.ReadFile("schema/vela.schema.json")
+	if err != nil {
+		t.Fatalf("unable to read schema: %v", err)
+	}
+
+	jsonSchema := make(map[string]any)
+
+	err = json.Unmarshal(data, &jsonSchema)
+	if err != nil {
+		t.Errorf("Generate produced invalid JSON: %v", err)
+	}
+
+	data, err = a.ReadFile("schema/vela.schema.yml")
+	if err != nil {
+		t.Fatalf("unable to read schema: %v", err)
+	}
+
+	yamlSchema := make(map[string]any)
+
+	err = yaml.Unmarshal(data, &yamlSchema)
+	if err != nil {
+		t.Errorf("Generate produced invalid YAML: %v", err)
+	}
+
+	if len(jsonSchema) == 0 || len(jsonSchema) != len(yamlSchema) {
+		t.Errorf("Generate produced %d keys in JSON and %d keys in YAML", len(jsonSchema), len(yamlSchema))
+	}
+}
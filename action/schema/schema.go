@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"github.com/spf13/afero"
+)
+
+// create filesystem based on the operating system
+//
+// https://godoc.org/github.com/spf13/afero#NewOsFs
+var appFS = afero.NewOsFs()
+
+// Config represents the configuration necessary
+// to perform schema related requests with Vela.
+type Config struct {
+	Action string
+	File   string
+	Output string
+}
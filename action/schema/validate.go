@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal/output"
+)
+
+// Validate verifies the configuration provided.
+func (c *Config) Validate() error {
+	logrus.Debug("validating schema configuration")
+
+	// check if the output format is supported
+	switch c.Output {
+	case "", output.DriverJSON, output.DriverYAML:
+	default:
+		return fmt.Errorf("invalid output for schema: %s (valid outputs: %s, %s)", c.Output, output.DriverJSON, output.DriverYAML)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestSchema_Config_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				File:   "vela.schema.json",
+				Output: "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "generate",
+				Output: "yaml",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "generate",
+				Output: "spew",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// GetCache captures a list of the templates in the
+// template cache based off the provided configuration.
+func (c *Config) GetCache() error {
+	logrus.Debug("executing get cache for template configuration")
+
+	logrus.Tracef("capturing templates in template cache %s", c.TemplateCache)
+
+	// capture a list of the cached templates
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#ListCachedTemplates
+	templates, err := internal.ListCachedTemplates(c.TemplateCache)
+	if err != nil {
+		return err
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverDump:
+		// output the cached templates in dump format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Dump
+		return output.Dump(templates)
+	case output.DriverJSON:
+		// output the cached templates in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(templates, c.Color)
+	case output.DriverSpew:
+		// output the cached templates in spew format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Spew
+		return output.Spew(templates)
+	case "wide":
+		// output the cached templates in wide table format
+		return wideTable(templates)
+	case output.DriverYAML:
+		// output the cached templates in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(templates, c.Color)
+	default:
+		// output the cached templates in table format
+		return table(templates)
+	}
+}
+
+// RemoveCache deletes one or all templates from the
+// template cache based off the provided configuration.
+func (c *Config) RemoveCache() error {
+	logrus.Debug("executing remove cache for template configuration")
+
+	logrus.Tracef("removing templates from template cache %s", c.TemplateCache)
+
+	// remove the cached templates, where no source removes all of them
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal?tab=doc#RemoveCachedTemplates
+	templates, err := internal.RemoveCachedTemplates(c.TemplateCache, c.Source)
+	if err != nil {
+		return err
+	}
+
+	removed := []string{}
+
+	for _, tmpl := range templates {
+		removed = append(removed, fmt.Sprintf("template %s removed from template cache", tmpl.Source))
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverDump:
+		// output the msg in dump format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Dump
+		return output.Dump(removed)
+	case output.DriverJSON:
+		// output the msg in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		return output.JSON(removed, c.Color)
+	case output.DriverSpew:
+		// output the msg in spew format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Spew
+		return output.Spew(removed)
+	case output.DriverYAML:
+		// output the msg in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		return output.YAML(removed, c.Color)
+	default:
+		// check if no templates were found to remove
+		if len(removed) == 0 {
+			return output.Stdout(fmt.Sprintf("no templates found in template cache %s", c.TemplateCache))
+		}
+
+		// output the msg in stdout format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+		for _, msg := range removed {
+			err := output.Stdout(msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"testing"
+
+	"github.com/go-vela/cli/internal"
+)
+
+func TestTemplate_Config_GetCache(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	_, err := internal.CacheTemplate(dir, "github.com/octocat/templates/docker.yml@v1", []byte("steps: []\n"))
+	if err != nil {
+		t.Fatalf("unable to cache template: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: dir,
+				Output:        "",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: dir,
+				Output:        "dump",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: dir,
+				Output:        "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: dir,
+				Output:        "spew",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: dir,
+				Output:        "wide",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: dir,
+				Output:        "yaml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: t.TempDir(),
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.GetCache()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetCache should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetCache returned err: %v", err)
+		}
+	}
+}
+
+func TestTemplate_Config_RemoveCache(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	for _, source := range []string{
+		"github.com/octocat/templates/docker.yml@v1",
+		"github.com/octocat/templates/docker.yml@v2",
+		"github.com/octocat/templates/go.yml@v1",
+	} {
+		_, err := internal.CacheTemplate(dir, source, []byte(source))
+		if err != nil {
+			t.Fatalf("unable to cache template: %v", err)
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: dir,
+				Source:        "github.com/octocat/templates/docker.yml@v1",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: dir,
+				Source:        "github.com/octocat/templates/docker.yml@v2",
+				Output:        "json",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: dir,
+				Source:        "github.com/octocat/templates/docker.yml@v1",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: dir,
+				All:           true,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: dir,
+				All:           true,
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.RemoveCache()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("RemoveCache should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("RemoveCache returned err: %v", err)
+		}
+	}
+
+	templates, err := internal.ListCachedTemplates(dir)
+	if err != nil {
+		t.Fatalf("ListCachedTemplates returned err: %v", err)
+	}
+
+	if len(templates) != 0 {
+		t.Errorf("RemoveCache left %d templates in template cache", len(templates))
+	}
+}
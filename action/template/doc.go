@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package template provides the defined CLI template actions for Vela.
+//
+// Usage:
+//
+//	import "github.com/go-vela/cli/action/template"
+package template
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v84/github"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// Lock pins the remote templates of a pipeline to commits
+// in a lockfile based off the provided configuration.
+func (c *Config) Lock(ctx context.Context) error {
+	logrus.Debug("executing lock for template configuration")
+
+	return c.lock(ctx, false)
+}
+
+// Update resolves the remote templates of a pipeline to their latest
+// commits in the lockfile based off the provided configuration.
+func (c *Config) Update(ctx context.Context) error {
+	logrus.Debug("executing update for template configuration")
+
+	return c.lock(ctx, true)
+}
+
+// lock resolves the remote templates of the pipeline and writes them
+// to the lockfile, keeping existing pins unless they are updated along
+// with the pins of the other pipelines in the directory.
+func (c *Config) lock(ctx context.Context, update bool) error {
+	path := c.File
+
+	// check if custom path was provided for pipeline file
+	if len(c.Path) > 0 {
+		path = filepath.Join(c.Path, c.File)
+	}
+
+	templates, err := remoteTemplates(path)
+	if err != nil {
+		return err
+	}
+
+	dir, file := filepath.Dir(path), filepath.Base(path)
+
+	lockfile, err := internal.ReadLock(dir)
+	if err != nil {
+		return err
+	}
+
+	if lockfile == nil {
+		lockfile = &internal.Lock{Version: "1"}
+	}
+
+	if lockfile.Pipelines == nil {
+		lockfile.Pipelines = make(map[string]*internal.PipelineLock)
+	}
+
+	// capture the existing pins of the pipeline
+	current := lockfile.Pipeline(file)
+	if current == nil {
+		current = new(internal.PipelineLock)
+	}
+
+	client, err := internal.NewGitHubClient(c.GitHubURL, c.GitHubToken)
+	if err != nil {
+		return err
+	}
+
+	pins := &internal.PipelineLock{Templates: []*internal.TemplateLock{}}
+	changes := []string{}
+
+	for _, tmpl := range templates {
+		previous := current.Template(tmpl.Name)
+
+		// keep the existing pin unless the pins are updated or the source changed
+		if !update && previous != nil && previous.Source == tmpl.Source {
+			pins.Templates = append(pins.Templates, previous)
+			changes = append(changes, fmt.Sprintf("%s: %s is pinned to %s", tmpl.Name, tmpl.Source, shortCommit(previous.Commit)))
+
+			continue
+		}
+
+		logrus.Tracef("resolving template %s from %s", tmpl.Name, tmpl.Source)
+
+		commit, err := c.resolve(ctx, client, tmpl.Source)
+		if err != nil {
+			return err
+		}
+
+		pins.Templates = append(pins.Templates, &internal.TemplateLock{
+			Name:   tmpl.Name,
+			Source: tmpl.Source,
+			Commit: commit,
+		})
+
+		switch {
+		case previous == nil || previous.Source != tmpl.Source:
+			changes = append(changes, fmt.Sprintf("%s: pinned %s to %s", tmpl.Name, tmpl.Source, shortCommit(commit)))
+		case previous.Commit != commit:
+			changes = append(changes, fmt.Sprintf("%s: updated %s from %s to %s", tmpl.Name, tmpl.Source, shortCommit(previous.Commit), shortCommit(commit)))
+		default:
+			changes = append(changes, fmt.Sprintf("%s: %s is up to date at %s", tmpl.Name, tmpl.Source, shortCommit(commit)))
+		}
+	}
+
+	// report the pins for templates no longer in the pipeline
+	for _, tmpl := range current.Templates {
+		if pins.Template(tmpl.Name) == nil {
+			changes = append(changes, fmt.Sprintf("%s: removed %s", tmpl.Name, tmpl.Source))
+		}
+	}
+
+	if len(templates) == 0 && len(current.Templates) == 0 {
+		return output.Stdout(fmt.Sprintf("no remote templates found in %s", path))
+	}
+
+	// replace the pins of the pipeline, keeping the pins
+	// of the other pipelines in the directory
+	if len(pins.Templates) == 0 {
+		delete(lockfile.Pipelines, file)
+	} else {
+		lockfile.Pipelines[file] = pins
+	}
+
+	err = internal.WriteLock(dir, lockfile)
+	if err != nil {
+		return err
+	}
+
+	return output.Stdout(strings.Join(changes, "\n"))
+}
+
+// remoteTemplates reads the templates of the pipeline
+// that are sourced from GitHub.
+func remoteTemplates(path string) ([]*internal.RemoteTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pipeline %s: %w", path, err)
+	}
+
+	templates, err := internal.RemoteTemplates(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pipeline %s: %w", path, err)
+	}
+
+	return templates, nil
+}
+
+// resolve resolves the ref of a remote template source to a commit.
+func (c *Config) resolve(ctx context.Context, client *github.Client, source string) (string, error) {
+	src, err := internal.ParseTemplateSource(source)
+	if err != nil {
+		return "", err
+	}
+
+	host := internal.GitHubHost(c.GitHubURL)
+
+	if !strings.EqualFold(src.Host, host) {
+		return "", fmt.Errorf("unable to resolve %s: host %s does not match compiler github url host %s", source, src.Host, host)
+	}
+
+	// check if the source is already pinned to a commit
+	if src.Pinned() {
+		return src.Ref, nil
+	}
+
+	ref := src.Ref
+
+	// templates without a ref use the default branch
+	if len(ref) == 0 {
+		ref = "HEAD"
+	}
+
+	commit, _, err := client.Repositories.GetCommitSHA1(ctx, src.Org, src.Repo, ref, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s to a commit: %w", source, err)
+	}
+
+	return commit, nil
+}
+
+// shortCommit returns the abbreviated form of a commit.
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+
+	return commit
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/cli/internal"
+)
+
+func TestTemplate_Config_Lock(t *testing.T) {
+	// setup types
+	commits := map[string]string{
+		"main": "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		"HEAD": "2f1e9ad6d6c5f5a6b2d1c3e4f5a6b7c8d9e0f1a2",
+	}
+
+	// setup test server resolving refs to commits
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commit, ok := commits[path.Base(r.URL.Path)]
+		if !ok || !strings.HasPrefix(r.URL.Path, "/api/v3/repos/octocat/templates/commits/") {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(commit))
+	}))
+	defer s.Close()
+
+	host := strings.TrimPrefix(s.URL, "http://")
+	dir := t.TempDir()
+
+	pipeline := fmt.Sprintf(`version: "1"
+
+templates:
+  - name: go
+    source: %[1]s/octocat/templates/go.yml@main
+    type: github
+  - name: docker
+    source: %[1]s/octocat/templates/docker.yml
+    type: github
+  - name: local
+    source: templates/local.yml
+    type: file
+
+steps:
+  - name: build
+    template:
+      name: go
+`, host)
+
+	err := os.WriteFile(filepath.Join(dir, ".vela.yml"), []byte(pipeline), 0o600)
+	if err != nil {
+		t.Fatalf("unable to write pipeline: %v", err)
+	}
+
+	config := &Config{Action: "lock", File: ".vela.yml", Path: dir, GitHubURL: s.URL}
+
+	// lock the templates to their current commits
+	err = config.Lock(t.Context())
+	if err != nil {
+		t.Fatalf("Lock returned err: %v", err)
+	}
+
+	lock, err := internal.ReadLock(dir)
+	if err != nil {
+		t.Fatalf("ReadLock returned err: %v", err)
+	}
+
+	if len(lock.Pipeline(".vela.yml").Templates) != 2 {
+		t.Fatalf("Lock pinned %d templates, want 2", len(lock.Pipeline(".vela.yml").Templates))
+	}
+
+	if lock.Pipeline(".vela.yml").Template("go").Commit != commits["main"] {
+		t.Errorf("Lock pinned go to %s, want %s", lock.Pipeline(".vela.yml").Template("go").Commit, commits["main"])
+	}
+
+	if lock.Pipeline(".vela.yml").Template("docker").Commit != commits["HEAD"] {
+		t.Errorf("Lock pinned docker to %s, want %s", lock.Pipeline(".vela.yml").Template("docker").Commit, commits["HEAD"])
+	}
+
+	// lock the templates of another pipeline in the directory
+	other := fmt.Sprintf(`version: "1"
+
+templates:
+  - name: deploy
+    source: %s/octocat/templates/deploy.yml@main
+    type: github
+`, host)
+
+	err = os.WriteFile(filepath.Join(dir, "deploy.yml"), []byte(other), 0o600)
+	if err != nil {
+		t.Fatalf("unable to write pipeline: %v", err)
+	}
+
+	err = (&Config{Action: "lock", File: "deploy.yml", Path: dir, GitHubURL: s.URL}).Lock(t.Context())
+	if err != nil {
+		t.Fatalf("Lock returned err: %v", err)
+	}
+
+	lock, err = internal.ReadLock(dir)
+	if err != nil {
+		t.Fatalf("ReadLock returned err: %v", err)
+	}
+
+	if lock.Pipeline("deploy.yml").Template("deploy").Commit != commits["main"] {
+		t.Errorf("Lock pinned deploy to %s, want %s", lock.Pipeline("deploy.yml").Template("deploy").Commit, commits["main"])
+	}
+
+	if len(lock.Pipeline(".vela.yml").Templates) != 2 {
+		t.Errorf("Lock kept %d templates of .vela.yml, want 2", len(lock.Pipeline(".vela.yml").Templates))
+	}
+
+	// move the branch to a new commit
+	commits["main"] = "9b7b4d4e1c1a3f6e0d2c5b8a7f6e5d4c3b2a1f0e"
+
+	// lock the templates again, keeping the existing pins
+	err = config.Lock(t.Context())
+	if err != nil {
+		t.Fatalf("Lock returned err: %v", err)
+	}
+
+	lock, err = internal.ReadLock(dir)
+	if err != nil {
+		t.Fatalf("ReadLock returned err: %v", err)
+	}
+
+	if lock.Pipeline(".vela.yml").Template("go").Commit != "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d" {
+		t.Errorf("Lock pinned go to %s, want existing pin", lock.Pipeline(".vela.yml").Template("go").Commit)
+	}
+
+	// update the templates to their latest commits
+	config.Action = "update"
+
+	err = config.Update(t.Context())
+	if err != nil {
+		t.Fatalf("Update returned err: %v", err)
+	}
+
+	lock, err = internal.ReadLock(dir)
+	if err != nil {
+		t.Fatalf("ReadLock returned err: %v", err)
+	}
+
+	if lock.Pipeline(".vela.yml").Template("go").Commit != commits["main"] {
+		t.Errorf("Update pinned go to %s, want %s", lock.Pipeline(".vela.yml").Template("go").Commit, commits["main"])
+	}
+
+	// fail to resolve templates from a different host
+	config.GitHubURL = "https://github.com"
+
+	err = config.Update(t.Context())
+	if err == nil {
+		t.Errorf("Update should have returned err")
+	}
+
+	// fail to lock a missing pipeline
+	err = (&Config{Action: "lock", File: "missing.yml", Path: dir, GitHubURL: s.URL}).Lock(t.Context())
+	if err == nil {
+		t.Errorf("Lock should have returned err")
+	}
+}
@@ -0,0 +1,384 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	starlarkjson "go.starlark.net/lib/json"
+	gostarlark "go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler/template/native"
+	"github.com/go-vela/server/compiler/template/starlark"
+	"github.com/go-vela/server/compiler/types/raw"
+	pyaml "github.com/go-vela/server/compiler/types/yaml"
+	"github.com/go-vela/server/constants"
+)
+
+// Render renders a template in isolation based off the provided configuration.
+func (c *Config) Render() error {
+	logrus.Debug("executing render for template configuration")
+
+	logrus.Tracef("reading template file %s", c.File)
+
+	// read the source of the template
+	source, err := os.ReadFile(c.File)
+	if err != nil {
+		return fmt.Errorf("unable to read template file %s: %w", c.File, err)
+	}
+
+	vars, err := c.vars()
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(c.File), filepath.Ext(c.File))
+	format := c.format()
+
+	logrus.Tracef("rendering %s template %s", format, c.File)
+
+	build, warnings, err := c.render(name, string(source), format, vars, 1)
+	if err != nil {
+		// output the lines of the template surrounding the error
+		context := renderContext(name, format, string(source), err)
+		if len(context) > 0 {
+			_ = output.Stderr(context)
+		}
+
+		return err
+	}
+
+	for _, warning := range warnings {
+		logrus.Warn(warning)
+	}
+
+	// handle the output based off the provided configuration
+	switch c.Output {
+	case output.DriverJSON:
+		// output the rendered template in JSON format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#JSON
+		err = output.JSON(build, c.Color)
+	default:
+		// output the rendered template in YAML format
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#YAML
+		err = output.YAML(build, c.Color)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("rendered %d steps from template %s", len(build.Steps), c.File)
+
+	if format == constants.PipelineTypeStarlark {
+		steps, err := c.execSteps(name, string(source), vars)
+		if err != nil {
+			return err
+		}
+
+		summary += fmt.Sprintf(" using approximately %d of %d starlark execution steps", steps, c.StarlarkExecLimit)
+	}
+
+	return output.Stderr(summary)
+}
+
+// vars reads the variables for the template from the vars file.
+func (c *Config) vars() (map[string]any, error) {
+	vars := make(map[string]any)
+
+	if len(c.VarsFile) == 0 {
+		return vars, nil
+	}
+
+	data, err := os.ReadFile(c.VarsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read vars file %s: %w", c.VarsFile, err)
+	}
+
+	err = yaml.Unmarshal(data, &vars)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse vars file %s: %w", c.VarsFile, err)
+	}
+
+	return vars, nil
+}
+
+// format returns the type of the template, using the
+// file extension when no type is provided.
+func (c *Config) format() string {
+	if len(c.Type) > 0 {
+		return c.Type
+	}
+
+	switch filepath.Ext(c.File) {
+	case ".star", ".starlark", ".py":
+		return constants.PipelineTypeStarlark
+	default:
+		return constants.PipelineTypeGo
+	}
+}
+
+// render renders the source of a template with the functions of the compiler
+// and expands any templates it references up to the template depth.
+func (c *Config) render(name, source, format string, vars map[string]any, depth int) (*pyaml.Build, []string, error) {
+	// check if the template depth is exceeded like the compiler
+	if depth > c.TemplateDepth {
+		return nil, nil, fmt.Errorf("max template depth of %d exceeded", c.TemplateDepth)
+	}
+
+	var (
+		build    *pyaml.Build
+		warnings []string
+		err      error
+	)
+
+	switch format {
+	case constants.PipelineTypeStarlark:
+		build, warnings, err = starlark.Render(source, name, name, raw.StringSliceMap{}, vars, c.StarlarkExecLimit)
+	default:
+		build, warnings, err = native.Render(source, name, name, raw.StringSliceMap{}, vars)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	build, nested, err := c.expand(build, depth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return build, append(warnings, nested...), nil
+}
+
+// expand replaces the steps of a rendered template that reference
+// other templates with the steps rendered from those templates.
+func (c *Config) expand(build *pyaml.Build, depth int) (*pyaml.Build, []string, error) {
+	templates := make(map[string]*pyaml.Template)
+
+	for _, tmpl := range build.Templates {
+		templates[tmpl.Name] = tmpl
+	}
+
+	warnings := []string{}
+	steps := pyaml.StepSlice{}
+
+	for _, step := range build.Steps {
+		if len(step.Template.Name) == 0 {
+			steps = append(steps, step)
+
+			continue
+		}
+
+		tmpl, ok := templates[step.Template.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("missing template source for template %s in step %s", step.Template.Name, step.Name)
+		}
+
+		source, err := c.source(tmpl)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rendered, nested, err := c.render(step.Name, source, tmpl.Format, step.Template.Variables, depth+1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to render template %s for step %s: %w", tmpl.Name, step.Name, err)
+		}
+
+		steps = append(steps, rendered.Steps...)
+		build.Secrets = append(build.Secrets, rendered.Secrets...)
+		build.Services = append(build.Services, rendered.Services...)
+		warnings = append(warnings, nested...)
+	}
+
+	build.Steps = steps
+
+	return build, warnings, nil
+}
+
+// source reads the source of a template referenced by a rendered template
+// from the local template files or the source of a file type template.
+func (c *Config) source(tmpl *pyaml.Template) (string, error) {
+	var path string
+
+	for _, file := range c.TemplateFiles {
+		name, source, _ := strings.Cut(file, ":")
+
+		if strings.EqualFold(name, tmpl.Name) {
+			path = source
+
+			break
+		}
+	}
+
+	if len(path) == 0 && strings.EqualFold(tmpl.Type, "file") {
+		path = tmpl.Source
+	}
+
+	if len(path) == 0 {
+		return "", fmt.Errorf("no local template file provided for template %s", tmpl.Name)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read template file %s: %w", path, err)
+	}
+
+	return string(data), nil
+}
+
+// execSteps approximates the starlark execution steps used to render the template.
+//
+// The compiler does not expose the thread it renders with, so this executes
+// the main function of the template again with a context built like the
+// compiler's and reads the steps from its own thread. The count can differ
+// from the compiler's when the platform vars differ, so it is reported as
+// an approximation.
+func (c *Config) execSteps(name, source string, vars map[string]any) (uint64, error) {
+	thread := &gostarlark.Thread{Name: name}
+	thread.SetMaxExecutionSteps(uint64(c.StarlarkExecLimit))
+
+	predeclared := gostarlark.StringDict{"struct": gostarlark.NewBuiltin("struct", starlarkstruct.Make)}
+
+	globals, err := gostarlark.ExecFileOptions(syntax.LegacyFileOptions(), thread, name, source, predeclared)
+	if err != nil {
+		return 0, err
+	}
+
+	main, ok := globals["main"].(gostarlark.Callable)
+	if !ok {
+		return 0, fmt.Errorf("unable to find main function in template %s", name)
+	}
+
+	// load the user provided vars into a starlark type
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	userVars, err := gostarlark.Call(new(gostarlark.Thread), starlarkjson.Module.Members["decode"], gostarlark.Tuple{gostarlark.String(data)}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// load the platform provided vars, which only
+	// include the template name when rendering locally
+	system := gostarlark.NewDict(1)
+
+	err = system.SetKey(gostarlark.String("template_name"), gostarlark.String(name))
+	if err != nil {
+		return 0, err
+	}
+
+	velaVars := gostarlark.NewDict(5)
+
+	for _, key := range []string{"build", "deployment", "repo", "user"} {
+		err = velaVars.SetKey(gostarlark.String(key), gostarlark.NewDict(0))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = velaVars.SetKey(gostarlark.String("system"), system)
+	if err != nil {
+		return 0, err
+	}
+
+	context := gostarlark.NewDict(2)
+
+	err = context.SetKey(gostarlark.String("vela"), velaVars)
+	if err != nil {
+		return 0, err
+	}
+
+	err = context.SetKey(gostarlark.String("vars"), userVars)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = gostarlark.Call(thread, main, gostarlark.Tuple{context}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return thread.ExecutionSteps(), nil
+}
+
+// renderContext creates the lines of the template surrounding
+// the position reported by an error from rendering it.
+func renderContext(name, format, source string, err error) string {
+	line, column := errorPosition(name, format, err)
+
+	lines := strings.Split(source, "\n")
+
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	first, last := max(line-1, 1), min(line+1, len(lines))
+	width := len(strconv.Itoa(last))
+
+	b := new(strings.Builder)
+
+	for n := first; n <= last; n++ {
+		fmt.Fprintf(b, "%*d | %s\n", width, n, lines[n-1])
+
+		if n == line && column > 0 {
+			fmt.Fprintf(b, "%*s | %s^\n", width, "", strings.Repeat(" ", column-1))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// errorPosition captures the line and column of the template
+// reported by an error from rendering it, when available.
+func errorPosition(name, format string, err error) (int, int) {
+	// capture the position of a starlark runtime error from the call stack
+	var evalErr *gostarlark.EvalError
+	if errors.As(err, &evalErr) {
+		for i := len(evalErr.CallStack) - 1; i >= 0; i-- {
+			pos := evalErr.CallStack[i].Pos
+
+			if pos.Filename() == name {
+				return int(pos.Line), int(pos.Col)
+			}
+		}
+	}
+
+	// capture the position from the error message, which is
+	// formatted as <name>:<line>:<column> or <name>:<line>
+	match := regexp.MustCompile(regexp.QuoteMeta(name) + `:(\d+)(?::(\d+))?:`).FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, 0
+	}
+
+	line, _ := strconv.Atoi(match[1])
+
+	if len(match[2]) == 0 {
+		return line, 0
+	}
+
+	column, _ := strconv.Atoi(match[2])
+
+	// go templates report the column as an offset from the start of the line
+	if format != constants.PipelineTypeStarlark {
+		column++
+	}
+
+	return line, column
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-vela/server/constants"
+)
+
+func TestTemplate_Config_Render(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		config  *Config
+	}{
+		{
+			name:    "go template",
+			failure: false,
+			config:  &Config{Action: "render", File: "testdata/go.yml", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "go template with json output",
+			failure: false,
+			config:  &Config{Action: "render", File: "testdata/go.yml", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 7500, Output: "json"},
+		},
+		{
+			name:    "starlark template",
+			failure: false,
+			config:  &Config{Action: "render", File: "testdata/starlark.star", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "nested template",
+			failure: false,
+			config:  &Config{Action: "render", File: "testdata/nested.yml", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "nested template with local template file",
+			failure: false,
+			config:  &Config{Action: "render", File: "testdata/nested.yml", VarsFile: "testdata/vars.yml", TemplateFiles: []string{"go:testdata/go.yml"}, TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "nested template exceeding template depth",
+			failure: true,
+			config:  &Config{Action: "render", File: "testdata/nested.yml", VarsFile: "testdata/vars.yml", TemplateDepth: 1, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "starlark template exceeding exec limit",
+			failure: true,
+			config:  &Config{Action: "render", File: "testdata/starlark.star", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 2},
+		},
+		{
+			name:    "invalid template",
+			failure: true,
+			config:  &Config{Action: "render", File: "testdata/invalid.yml", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "missing template",
+			failure: true,
+			config:  &Config{Action: "render", File: "testdata/missing.yml", TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+		{
+			name:    "missing vars file",
+			failure: true,
+			config:  &Config{Action: "render", File: "testdata/go.yml", VarsFile: "testdata/missing.yml", TemplateDepth: 3, StarlarkExecLimit: 7500},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Render()
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Render should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Render returned err: %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplate_Config_execSteps(t *testing.T) {
+	// setup types
+	c := &Config{Action: "render", File: "testdata/starlark.star", VarsFile: "testdata/vars.yml", TemplateDepth: 3, StarlarkExecLimit: 7500}
+
+	source, err := os.ReadFile(c.File)
+	if err != nil {
+		t.Fatalf("unable to read template file: %v", err)
+	}
+
+	vars, err := c.vars()
+	if err != nil {
+		t.Fatalf("vars returned err: %v", err)
+	}
+
+	// run test
+	steps, err := c.execSteps("starlark", string(source), vars)
+	if err != nil {
+		t.Fatalf("execSteps returned err: %v", err)
+	}
+
+	// without platform vars the approximation matches the compiler, so the
+	// template renders within the steps used, but not within fewer
+	c.StarlarkExecLimit = int64(steps) + 1
+
+	_, _, err = c.render("starlark", string(source), constants.PipelineTypeStarlark, vars, 1)
+	if err != nil {
+		t.Errorf("render with %d steps returned err: %v", c.StarlarkExecLimit, err)
+	}
+
+	c.StarlarkExecLimit = int64(steps)
+
+	_, _, err = c.render("starlark", string(source), constants.PipelineTypeStarlark, vars, 1)
+	if err == nil {
+		t.Errorf("render with %d steps should have returned err", c.StarlarkExecLimit)
+	}
+}
+
+func TestTemplate_renderContext(t *testing.T) {
+	// setup types
+	source := `metadata:
+  template: true
+
+steps:
+  - name: build
+    image: {{ .image | unknown }}`
+
+	// setup tests
+	tests := []struct {
+		name   string
+		format string
+		err    error
+		want   string
+	}{
+		{
+			name:   "go template with column",
+			format: "go",
+			err:    errors.New(`template: go:6:23: executing "go" at <unknown>: error calling unknown`),
+			want: `5 |   - name: build
+6 |     image: {{ .image | unknown }}
+  |                        ^`,
+		},
+		{
+			name:   "go template without column",
+			format: "go",
+			err:    errors.New(`template: go:5: function "unknown" not defined`),
+			want: `4 | steps:
+5 |   - name: build
+6 |     image: {{ .image | unknown }}`,
+		},
+		{
+			name:   "starlark template",
+			format: "starlark",
+			err:    errors.New(`go:6:12: undefined: image`),
+			want: `5 |   - name: build
+6 |     image: {{ .image | unknown }}
+  |            ^`,
+		},
+		{
+			name:   "no position",
+			format: "go",
+			err:    errors.New(`yaml: unmarshal errors`),
+			want:   "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := renderContext("go", test.format, source, test.err)
+
+			if got != test.want {
+				t.Errorf("renderContext is %q, want %q", got, test.want)
+			}
+		})
+	}
+}
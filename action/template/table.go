@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"github.com/gosuri/uitable"
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// table is a helper function to output the
+// provided cached templates in a table format
+// with a specific set of fields displayed.
+func table(templates []*internal.CachedTemplate) error {
+	logrus.Debug("creating table for list of cached templates")
+
+	// create a new table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for table to 50
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 50
+
+	// ensure the table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	logrus.Trace("adding headers to cached template table")
+
+	// set of cached template fields we display in a table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("SOURCE", "SIZE", "CREATED")
+
+	// iterate through all cached templates in the list
+	for _, t := range templates {
+		logrus.Tracef("adding template %s to cached template table", t.Source)
+
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(t.Source, t.Size, t.Created)
+	}
+
+	// output the table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
+
+// wideTable is a helper function to output the
+// provided cached templates in a wide table format
+// with a specific set of fields displayed.
+func wideTable(templates []*internal.CachedTemplate) error {
+	logrus.Debug("creating wide table for list of cached templates")
+
+	// create new wide table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#New
+	table := uitable.New()
+
+	// set column width for wide table to 200
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.MaxColWidth = 200
+
+	// ensure the wide table is always wrapped
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table
+	table.Wrap = true
+
+	logrus.Trace("adding headers to wide cached template table")
+
+	// set of cached template fields we display in a wide table
+	//
+	// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+	table.AddRow("SOURCE", "DIGEST", "SIZE", "PATH", "CREATED")
+
+	// iterate through all cached templates in the list
+	for _, t := range templates {
+		logrus.Tracef("adding template %s to wide cached template table", t.Source)
+
+		// add a row to the table with the specified values
+		//
+		// https://pkg.go.dev/github.com/gosuri/uitable?tab=doc#Table.AddRow
+		table.AddRow(t.Source, t.Digest, t.Size, t.Path, t.Created)
+	}
+
+	// output the wide table in stdout format
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/output?tab=doc#Stdout
+	return output.Stdout(table)
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import "github.com/go-vela/cli/internal/output"
+
+// Config represents the configuration necessary
+// to perform template related requests with Vela.
+type Config struct {
+	Action            string
+	File              string
+	Path              string
+	Type              string
+	VarsFile          string
+	TemplateFiles     []string
+	TemplateDepth     int
+	StarlarkExecLimit int64
+	GitHubURL         string
+	GitHubToken       string
+	TemplateCache     string
+	Source            string
+	All               bool
+	Output            string
+	Color             output.ColorOptions
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/constants"
+)
+
+// Validate verifies the configuration provided.
+func (c *Config) Validate() error {
+	logrus.Debug("validating template configuration")
+
+	// handle the action based off the provided configuration
+	switch c.Action {
+	case internal.ActionLock, internal.ActionUpdate:
+		// check if pipeline file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no pipeline file provided")
+		}
+	case internal.ActionGet:
+		// check if template cache is set
+		if len(c.TemplateCache) == 0 {
+			return fmt.Errorf("no template cache provided")
+		}
+	case internal.ActionRemove:
+		// check if template cache is set
+		if len(c.TemplateCache) == 0 {
+			return fmt.Errorf("no template cache provided")
+		}
+
+		// check if template source or all is set
+		if len(c.Source) == 0 && !c.All {
+			return fmt.Errorf("no template source provided")
+		}
+
+		// check if both template source and all are set
+		if len(c.Source) > 0 && c.All {
+			return fmt.Errorf("template source and all can not both be provided")
+		}
+	case internal.ActionRender:
+		// check if template file is set
+		if len(c.File) == 0 {
+			return fmt.Errorf("no template file provided")
+		}
+
+		switch c.Type {
+		case "", constants.PipelineTypeGo, constants.PipelineTypeStarlark:
+		default:
+			return fmt.Errorf("invalid template type: %s (valid types: %s, %s)", c.Type, constants.PipelineTypeGo, constants.PipelineTypeStarlark)
+		}
+
+		for _, file := range c.TemplateFiles {
+			parts := strings.Split(file, ":")
+
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid format for template file: %s (valid format: <name>:<source>)", file)
+			}
+		}
+
+		// check if template depth is valid
+		if c.TemplateDepth < 1 {
+			return fmt.Errorf("invalid template depth: %d (must be at least 1)", c.TemplateDepth)
+		}
+
+		// check if starlark exec limit is valid
+		if c.StarlarkExecLimit < 1 {
+			return fmt.Errorf("invalid starlark exec limit: %d (must be at least 1)", c.StarlarkExecLimit)
+		}
+
+		switch c.Output {
+		case "", output.DriverJSON, output.DriverYAML:
+		default:
+			return fmt.Errorf("invalid output format: %s (valid formats: %s, %s)", c.Output, output.DriverJSON, output.DriverYAML)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"testing"
+)
+
+func TestTemplate_Config_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  *Config
+	}{
+		{
+			failure: false,
+			config: &Config{
+				Action:            "render",
+				File:              "template.yml",
+				TemplateDepth:     3,
+				StarlarkExecLimit: 7500,
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:            "render",
+				File:              "template.star",
+				Type:              "starlark",
+				TemplateFiles:     []string{"go:go.yml"},
+				TemplateDepth:     3,
+				StarlarkExecLimit: 7500,
+				Output:            "json",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "lock",
+				File:   ".vela.yml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action: "update",
+				File:   ".vela.yml",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "get",
+				TemplateCache: "templates",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: "templates",
+				Source:        "github.com/octocat/templates/docker.yml@v1",
+			},
+		},
+		{
+			failure: false,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: "templates",
+				All:           true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "get",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: "templates",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:        "remove",
+				TemplateCache: "templates",
+				Source:        "github.com/octocat/templates/docker.yml@v1",
+				All:           true,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action: "lock",
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:            "render",
+				TemplateDepth:     3,
+				StarlarkExecLimit: 7500,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:            "render",
+				File:              "template.yml",
+				Type:              "jsonnet",
+				TemplateDepth:     3,
+				StarlarkExecLimit: 7500,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:            "render",
+				File:              "template.yml",
+				TemplateFiles:     []string{"go.yml"},
+				TemplateDepth:     3,
+				StarlarkExecLimit: 7500,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:            "render",
+				File:              "template.yml",
+				StarlarkExecLimit: 7500,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:        "render",
+				File:          "template.yml",
+				TemplateDepth: 3,
+			},
+		},
+		{
+			failure: true,
+			config: &Config{
+				Action:            "render",
+				File:              "template.yml",
+				TemplateDepth:     3,
+				StarlarkExecLimit: 7500,
+				Output:            "table",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.config.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/pipeline"
+)
+
+// convertCmds defines the commands for converting resources.
+var convertCmds = &cli.Command{
+	Name:                   "convert",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to convert a resource for Vela.",
+	Usage:                  "Convert a resource for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for converting a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandConvert
+		pipeline.CommandConvert,
+	},
+}
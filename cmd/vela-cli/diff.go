@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/pipeline"
+)
+
+// diffCmds defines the commands for comparing resources.
+var diffCmds = &cli.Command{
+	Name:                   "diff",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to compare resources for Vela.",
+	Usage:                  "Compare resources for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for comparing pipelines
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandDiff
+		pipeline.CommandDiff,
+	},
+}
@@ -20,5 +20,10 @@ var execCmds = &cli.Command{
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandExec
 		pipeline.CommandExec,
+
+		// add the sub command for executing the services of a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandExecServices
+		pipeline.CommandExecServices,
 	},
 }
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/pipeline"
+)
+
+// fmtCmds defines the commands for formatting resources.
+var fmtCmds = &cli.Command{
+	Name:                   "fmt",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to format a resource for Vela.",
+	Usage:                  "Format a resource for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for formatting a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandFormat
+		pipeline.CommandFormat,
+	},
+}
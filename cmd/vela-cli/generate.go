@@ -9,6 +9,7 @@ import (
 	"github.com/go-vela/cli/command/config"
 	"github.com/go-vela/cli/command/docs"
 	"github.com/go-vela/cli/command/pipeline"
+	"github.com/go-vela/cli/command/schema"
 )
 
 // generateCmds defines the commands for producing resources.
@@ -39,5 +40,10 @@ var generateCmds = &cli.Command{
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandGenerate
 		pipeline.CommandGenerate,
+
+		// add the sub command for producing the pipeline schema
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/schema?tab=doc#CommandGenerate
+		schema.CommandGenerate,
 	},
 }
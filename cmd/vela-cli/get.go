@@ -6,6 +6,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/go-vela/cli/command/build"
+	"github.com/go-vela/cli/command/cache"
 	"github.com/go-vela/cli/command/dashboard"
 	"github.com/go-vela/cli/command/deployment"
 	"github.com/go-vela/cli/command/hook"
@@ -16,6 +17,7 @@ import (
 	"github.com/go-vela/cli/command/secret"
 	"github.com/go-vela/cli/command/service"
 	"github.com/go-vela/cli/command/step"
+	"github.com/go-vela/cli/command/template"
 	"github.com/go-vela/cli/command/worker"
 )
 
@@ -33,6 +35,11 @@ var getCmds = &cli.Command{
 		// https://pkg.go.dev/github.com/go-vela/cli/command/build?tab=doc#CommandGet
 		build.CommandGet,
 
+		// add the sub command for getting a list of caches
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/cache?tab=doc#CommandGet
+		cache.CommandGet,
+
 		// add the sub command for getting a list of user dashboards
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/dashboard?tab=doc#CommandGet
@@ -83,6 +90,11 @@ var getCmds = &cli.Command{
 		// https://pkg.go.dev/github.com/go-vela/cli/command/step?tab=doc#CommandGet
 		step.CommandGet,
 
+		// add the sub command for getting a list of cached templates
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/template?tab=doc#CommandGetCache
+		template.CommandGetCache,
+
 		// add the sub command for getting a list of workers
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/worker?tab=doc#CommandGet
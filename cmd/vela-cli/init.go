@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/pipeline"
+)
+
+// initCmds defines the commands for initializing resources.
+var initCmds = &cli.Command{
+	Name:                   "init",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to initialize a resource for Vela.",
+	Usage:                  "Initialize resources for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for initializing a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandInit
+		pipeline.CommandInit,
+	},
+}
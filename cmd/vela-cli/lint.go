@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/pipeline"
+)
+
+// lintCmds defines the commands for linting resources.
+var lintCmds = &cli.Command{
+	Name:                   "lint",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to lint a resource for Vela.",
+	Usage:                  "Lint a resource for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for linting a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandLint
+		pipeline.CommandLint,
+	},
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/template"
+)
+
+// lockCmds defines the commands for locking resources.
+var lockCmds = &cli.Command{
+	Name:                   "lock",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to lock a resource for Vela.",
+	Usage:                  "Lock resources for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for locking the templates of a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/template?tab=doc#CommandLock
+		template.CommandLock,
+	},
+}
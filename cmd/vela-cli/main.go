@@ -39,14 +39,22 @@ func main() {
 		cancelCmds,
 		chownCmds,
 		compileCmds,
+		convertCmds,
+		diffCmds,
 		execCmds,
 		expandCmds,
+		fmtCmds,
 		generateCmds,
 		getCmds,
+		initCmds,
+		lintCmds,
+		lockCmds,
 		removeCmds,
+		renderCmds,
 		repairCmds,
 		restartCmds,
 		syncCmds,
+		testCmds,
 		updateCmds,
 		validateCmds,
 		viewCmds,
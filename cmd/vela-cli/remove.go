@@ -5,10 +5,12 @@ package main
 import (
 	"github.com/urfave/cli/v3"
 
+	"github.com/go-vela/cli/command/cache"
 	"github.com/go-vela/cli/command/config"
 	"github.com/go-vela/cli/command/repo"
 	"github.com/go-vela/cli/command/schedule"
 	"github.com/go-vela/cli/command/secret"
+	"github.com/go-vela/cli/command/template"
 )
 
 // removeCmds defines the commands for deleting resources.
@@ -20,6 +22,11 @@ var removeCmds = &cli.Command{
 	Usage:                  "Remove a resource for Vela via subcommands",
 	UseShortOptionHandling: true,
 	Commands: []*cli.Command{
+		// add the sub command for remove a cache
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/cache?tab=doc#CommandRemove
+		cache.CommandRemove,
+
 		// add the sub command for remove a config file
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/config?tab=doc#CommandRemove
@@ -39,5 +46,10 @@ var removeCmds = &cli.Command{
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/secret?tab=doc#CommandRemove
 		secret.CommandRemove,
+
+		// add the sub command for remove cached templates
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/template?tab=doc#CommandRemoveCache
+		template.CommandRemoveCache,
 	},
 }
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/template"
+)
+
+// renderCmds defines the commands for rendering resources.
+var renderCmds = &cli.Command{
+	Name:                   "render",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to render a resource for Vela.",
+	Usage:                  "Render resources for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for rendering a template
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/template?tab=doc#CommandRender
+		template.CommandRender,
+	},
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/command/pipeline"
+)
+
+// testCmds defines the commands for testing resources.
+var testCmds = &cli.Command{
+	Name:                   "test",
+	Category:               "Pipeline Management",
+	Description:            "Use this command to test a resource for Vela.",
+	Usage:                  "Test resources for Vela via subcommands",
+	UseShortOptionHandling: true,
+	Commands: []*cli.Command{
+		// add the sub command for testing a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/pipeline?tab=doc#CommandTest
+		pipeline.CommandTest,
+	},
+}
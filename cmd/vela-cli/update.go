@@ -11,6 +11,7 @@ import (
 	"github.com/go-vela/cli/command/schedule"
 	"github.com/go-vela/cli/command/secret"
 	"github.com/go-vela/cli/command/settings"
+	"github.com/go-vela/cli/command/template"
 	"github.com/go-vela/cli/command/user"
 	"github.com/go-vela/cli/command/worker"
 )
@@ -54,6 +55,11 @@ var updateCmds = &cli.Command{
 		// https://pkg.go.dev/github.com/go-vela/cli/command/settings?tab=doc#CommandUpdate
 		settings.CommandUpdate,
 
+		// add the sub command for modifying the pinned templates of a pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/command/template?tab=doc#CommandUpdate
+		template.CommandUpdate,
+
 		// add the sub command for modifying a user
 		//
 		// https://pkg.go.dev/github.com/go-vela/cli/command/user?tab=doc#CommandUpdate
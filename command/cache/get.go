@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/cache"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandGet defines the command for capturing a list of caches.
+var CommandGet = &cli.Command{
+	Name:        "cache",
+	Aliases:     []string{"caches"},
+	Description: "Use this command to get a list of caches used when executing pipelines locally.",
+	Usage:       "Display a list of local pipeline caches",
+	Action:      get,
+	Flags: []cli.Flag{
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "CACHE_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the caches",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "CACHE_REPO"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the caches",
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "CACHE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in json, spew, wide or yaml",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Get caches for all repositories.
+    $ {{.FullName}}
+  2. Get caches for a repository.
+    $ {{.FullName}} --org MyOrg --repo MyRepo
+  3. Get caches for an organization with wide view output.
+    $ {{.FullName}} --org MyOrg --output wide
+  4. Get caches for a repository with json output.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --output json
+  5. Get caches when config or environment variables are set.
+    $ {{.FullName}}
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/cache/get/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to capture a list
+// of caches.
+func get(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the cache configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/cache?tab=doc#Config
+	conf := &cache.Config{
+		Action: internal.ActionGet,
+		Org:    c.String(internal.FlagOrg),
+		Repo:   c.String(internal.FlagRepo),
+		Output: c.String(internal.FlagOutput),
+		Color:  output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate cache configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/cache?tab=doc#Config.Validate
+	err = conf.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the get call for the cache configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/cache?tab=doc#Config.Get
+	return conf.Get(ctx)
+}
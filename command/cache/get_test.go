@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestCache_Get(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	docker := internal.Docker
+
+	t.Cleanup(func() { internal.Docker = docker })
+
+	// fake the docker CLI returning no caches
+	internal.Docker = func(_ context.Context, _ ...string) (string, error) {
+		return "", nil
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, get, CommandGet.Flags),
+			args:    []string{"--org", "github", "--repo", "octocat"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, get, CommandGet.Flags),
+			args:    []string{"--org", "github", "--output", "json"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, get, CommandGet.Flags),
+			args:    []string{"--repo", "octocat"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("get should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("get returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/cache"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandRemove defines the command for deleting a cache.
+var CommandRemove = &cli.Command{
+	Name:        "cache",
+	Aliases:     []string{"caches"},
+	Description: "Use this command to remove a cache used when executing pipelines locally.",
+	Usage:       "Remove the provided local pipeline cache",
+	Action:      remove,
+	Flags: []cli.Flag{
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "CACHE_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the cache",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "CACHE_REPO"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the cache",
+		},
+
+		// Cache Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_NAME", "CACHE_NAME"),
+			Name:    internal.FlagName,
+			Aliases: []string{"n"},
+			Usage:   "provide the name of the cache",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_ALL", "CACHE_ALL"),
+			Name:    "all",
+			Aliases: []string{"a"},
+			Usage:   "remove all caches for the repository",
+			Value:   false,
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "CACHE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in json, spew or yaml",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Remove a cache for a repository.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --name gomod
+  2. Remove all caches for a repository.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --all
+  3. Remove a cache for a repository with json output.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --name gomod --output json
+  4. Remove a cache when config or environment variables are set.
+    $ {{.FullName}} --name gomod
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/cache/remove/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to remove a cache.
+func remove(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the cache configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/cache?tab=doc#Config
+	conf := &cache.Config{
+		Action: internal.ActionRemove,
+		Org:    c.String(internal.FlagOrg),
+		Repo:   c.String(internal.FlagRepo),
+		Name:   c.String(internal.FlagName),
+		All:    c.Bool("all"),
+		Output: c.String(internal.FlagOutput),
+		Color:  output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate cache configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/cache?tab=doc#Config.Validate
+	err = conf.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the remove call for the cache configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/cache?tab=doc#Config.Remove
+	return conf.Remove(ctx)
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestCache_Remove(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	docker := internal.Docker
+
+	t.Cleanup(func() { internal.Docker = docker })
+
+	// fake the docker CLI removing the cache
+	internal.Docker = func(_ context.Context, args ...string) (string, error) {
+		return args[len(args)-1], nil
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, remove, CommandRemove.Flags),
+			args:    []string{"--org", "github", "--repo", "octocat", "--name", "gomod"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, remove, CommandRemove.Flags),
+			args:    []string{"--org", "github", "--repo", "octocat"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, remove, CommandRemove.Flags),
+			args:    []string{"--org", "github", "--repo", "octocat", "--name", "gomod", "--all"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("remove should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("remove returned err: %v", err)
+		}
+	}
+}
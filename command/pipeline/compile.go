@@ -6,6 +6,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/go-vela/cli/internal"
 	"github.com/go-vela/cli/internal/client"
 	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/constants"
 )
 
 // CommandCompile defines the command for compiling a pipeline.
@@ -57,6 +59,136 @@ var CommandCompile = &cli.Command{
 			Usage:   "provide the repository reference for the pipeline",
 			Value:   "main",
 		},
+
+		// Local Flags
+
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_LOCAL", "PIPELINE_LOCAL"),
+			Name:    "local",
+			Aliases: []string{"l"},
+			Usage:   "compile a local pipeline file, as it would be executed, without a server",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the local pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PIPELINE_TYPE", "PIPELINE_TYPE"),
+			Name:    "pipeline-type",
+			Aliases: []string{"pt"},
+			Usage:   "type of pipeline for the compiler to render",
+			Value:   constants.PipelineTypeYAML,
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_SKIP_STEP", "SKIP_STEP"),
+			Name:    "skip-step",
+			Aliases: []string{"sk", "skip"},
+			Usage:   "skip a step in the local pipeline",
+		},
+
+		// Build Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_BRANCH", "PIPELINE_BRANCH", "VELA_BUILD_BRANCH"),
+			Name:    "branch",
+			Aliases: []string{"b"},
+			Usage:   "provide the build branch for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMMENT", "PIPELINE_COMMENT", "VELA_BUILD_COMMENT"),
+			Name:    "comment",
+			Aliases: []string{"c"},
+			Usage:   "provide the build comment for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_EVENT", "PIPELINE_EVENT", "VELA_BUILD_EVENT"),
+			Name:    "event",
+			Aliases: []string{"e"},
+			Usage:   "provide the build event for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TAG", "PIPELINE_TAG", "VELA_BUILD_TAG"),
+			Name:    "tag",
+			Usage:   "provide the build tag for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TARGET", "PIPELINE_TARGET", "VELA_BUILD_TARGET"),
+			Name:    "target",
+			Usage:   "provide the build target for the local pipeline",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_FILE_CHANGESET", "FILE_CHANGESET"),
+			Name:    "file-changeset",
+			Aliases: []string{"fcs"},
+			Usage:   "provide a list of files changed for ruleset matching",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CHANGESET_FROM_GIT", "PIPELINE_CHANGESET_FROM_GIT"),
+			Name:    "changeset-from-git",
+			Usage:   "provide a base git revision to compute the files changed for ruleset matching",
+		},
+
+		// Compiler Template Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_FILE", "PIPELINE_TEMPLATE_FILE"),
+			Name:    "template-file",
+			Aliases: []string{"tf", "tfs", "template-files"},
+			Usage:   "enables using a local template file for expansion in the form <name>:<path>",
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_CACHE", "PIPELINE_TEMPLATE_CACHE"),
+			Name:    "template-cache",
+			Usage:   "provide the directory of the cache for remote templates, reused for templates pinned to a commit or when offline, and for every template when set explicitly (set to an empty value to disable)",
+			Value:   internal.DefaultTemplateCache(),
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_OFFLINE", "PIPELINE_OFFLINE"),
+			Name:    "offline",
+			Usage:   "require remote templates to be in the template cache instead of fetching them",
+			Value:   false,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for compiling starlark pipelines",
+			Value:   7500,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
 	},
 	CustomHelpTemplate: fmt.Sprintf(`%s
 EXAMPLES:
@@ -66,6 +198,14 @@ EXAMPLES:
     $ {{.FullName}} --org MyOrg --repo MyRepo --output json
   3. Compile a pipeline for a repository when config or environment variables are set.
     $ {{.FullName}}
+  4. Compile a local pipeline as it would be executed.
+    $ {{.FullName}} --local
+  5. Compile a local pipeline in a nested directory for a pull request with json output.
+    $ {{.FullName}} --local --path nested/path/to/dir --event pull_request --output json
+  6. Compile a local pipeline with local templates.
+    $ {{.FullName}} --local --template-file <template_name>:<path_to_template>
+  7. Compile a local pipeline using only the remote templates in the template cache.
+    $ {{.FullName}} --local --offline
 
 DOCUMENTATION:
 
@@ -82,6 +222,11 @@ func compile(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
+	// check if the pipeline should be compiled locally
+	if c.Bool("local") {
+		return compileLocal(ctx, c)
+	}
+
 	// parse the Vela client from the context
 	//
 	// https://pkg.go.dev/github.com/go-vela/cli/internal/client?tab=doc#Parse
@@ -115,3 +260,62 @@ func compile(ctx context.Context, c *cli.Command) error {
 	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Compile
 	return p.Compile(ctx, client)
 }
+
+// helper function to capture the provided input and
+// create the object used to compile a local pipeline.
+func compileLocal(ctx context.Context, c *cli.Command) error {
+	// account for users omitting the `refs/tags` prefix of the tag value
+	tag := c.String("tag")
+
+	if len(tag) > 0 && !strings.HasPrefix(tag, "refs/tags/") {
+		tag = "refs/tags/" + tag
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:           internal.ActionCompile,
+		Branch:           c.String("branch"),
+		Comment:          c.String("comment"),
+		Event:            c.String("event"),
+		Tag:              tag,
+		Target:           c.String("target"),
+		Org:              c.String(internal.FlagOrg),
+		Repo:             c.String(internal.FlagRepo),
+		SkipSteps:        c.StringSlice("skip-step"),
+		File:             c.String("file"),
+		FileChangeset:    c.StringSlice("file-changeset"),
+		ChangesetFromGit: c.String("changeset-from-git"),
+		TemplateFiles:    c.StringSlice("template-file"),
+		TemplateCache:    c.String("template-cache"),
+		CacheTemplates:   c.IsSet("template-cache"),
+		Offline:          c.Bool("offline"),
+		GitHubURL:        c.String(internal.FlagCompilerGitHubURL),
+		GitHubToken:      c.String(internal.FlagCompilerGitHubToken),
+		Local:            true,
+		Path:             c.String("path"),
+		PipelineType:     c.String("pipeline-type"),
+		Output:           c.String(internal.FlagOutput),
+		Color:            output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler used for executing the pipeline
+	client, err := execCompiler(ctx, c, p.TemplateFiles)
+	if err != nil {
+		return err
+	}
+
+	// execute the compile local call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.CompileLocal
+	return p.CompileLocal(ctx, client)
+}
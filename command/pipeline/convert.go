@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandConvert defines the command for converting a pipeline.
+var CommandConvert = &cli.Command{
+	Name:        "pipeline",
+	Description: "Use this command to convert a pipeline from another CI system, with TODO comments for what can not be converted.",
+	Usage:       "Convert a Drone, GitHub Actions or GitLab CI pipeline into a Vela pipeline",
+	Action:      convert,
+	Flags: []cli.Flag{
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "REPO_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the keys of the secrets",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "REPO_NAME"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the keys of the secrets",
+		},
+
+		// Convert Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FROM", "PIPELINE_FROM"),
+			Name:    "from",
+			Usage:   "provide the CI system of the pipeline (drone, github-actions or gitlab)",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_SOURCE", "PIPELINE_SOURCE"),
+			Name:    "source",
+			Aliases: []string{"s"},
+			Usage:   "provide the file of the pipeline to convert",
+		},
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the converted pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the converted pipeline",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_FORCE", "PIPELINE_FORCE"),
+			Name:    "force",
+			Usage:   "overwrite an existing pipeline file",
+			Value:   false,
+		},
+
+		// Compiler Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for compiling starlark pipelines",
+			Value:   7500,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Convert a Drone pipeline into a Vela pipeline.
+    $ {{.FullName}} --from drone .drone.yml
+  2. Convert a GitHub Actions workflow into a Vela pipeline.
+    $ {{.FullName}} --from github-actions .github/workflows/ci.yml
+  3. Convert a GitLab CI pipeline into a Vela pipeline with the keys of the secrets for a repository.
+    $ {{.FullName}} --from gitlab --org MyOrg --repo MyRepo .gitlab-ci.yml
+  4. Convert a GitLab CI pipeline into a Vela pipeline in a nested directory.
+    $ {{.FullName}} --from gitlab --path nested/path/to/dir --file .vela.yml .gitlab-ci.yml
+  5. Convert a Drone pipeline into a Vela pipeline, overwriting the existing Vela pipeline.
+    $ {{.FullName}} --from drone --force .drone.yml
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/convert/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to convert a pipeline.
+func convert(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// grab first command line argument, if it exists, and set it as resource
+	err = internal.ProcessArgs(c, "source", "string")
+	if err != nil {
+		return err
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action: internal.ActionConvert,
+		Org:    c.String(internal.FlagOrg),
+		Repo:   c.String(internal.FlagRepo),
+		From:   c.String("from"),
+		Source: c.String("source"),
+		File:   c.String("file"),
+		Path:   c.String("path"),
+		Force:  c.Bool("force"),
+		Color:  output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler used for validating the converted pipeline
+	client, err := execCompiler(ctx, c, nil)
+	if err != nil {
+		return err
+	}
+
+	// execute the convert call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Convert
+	return p.Convert(ctx, client.WithLocal(true))
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_Convert(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	dir := t.TempDir()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, convert, CommandConvert.Flags),
+			args:    []string{"--from", "drone", "--source", "testdata/.drone.yml", "--path", dir},
+		},
+		{
+			// the pipeline is written by the previous test
+			failure: true,
+			cmd:     test.Command(s.URL, convert, CommandConvert.Flags),
+			args:    []string{"--from", "drone", "--source", "testdata/.drone.yml", "--path", dir},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, convert, CommandConvert.Flags),
+			args:    []string{"--from", "drone", "--source", "testdata/.drone.yml", "--path", dir, "--force"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, convert, CommandConvert.Flags),
+			args:    []string{"--from", "jenkins", "--source", "testdata/.drone.yml", "--path", dir},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, convert, CommandConvert.Flags),
+			args:    []string{"--from", "drone", "--source", "empty.yml", "--path", dir, "--force"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, convert, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("convert should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("convert returned err: %v", err)
+		}
+	}
+}
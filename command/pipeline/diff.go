@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/client"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/constants"
+)
+
+// CommandDiff defines the command for comparing pipelines.
+var CommandDiff = &cli.Command{
+	Name:        "pipeline",
+	Description: "Use this command to compare the expanded pipelines for two refs, or a ref and a local pipeline.",
+	Usage:       "Compare the stages, steps, services and secrets of expanded pipelines",
+	Action:      diff,
+	Flags: []cli.Flag{
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "REPO_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "REPO_NAME"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the pipeline",
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "REPO_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in text or json",
+		},
+
+		// Pipeline Flags
+
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_REF", "PIPELINE_REF"),
+			Name:    "ref",
+			Usage:   "provide the repository references for the pipelines to compare",
+		},
+
+		// Local Flags
+
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_LOCAL", "PIPELINE_LOCAL"),
+			Name:    "local",
+			Aliases: []string{"l"},
+			Usage:   "compare the pipeline for the ref with a local pipeline file",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the local pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PIPELINE_TYPE", "PIPELINE_TYPE"),
+			Name:    "pipeline-type",
+			Aliases: []string{"pt"},
+			Usage:   "type of pipeline for the compiler to render",
+			Value:   constants.PipelineTypeYAML,
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_FILE", "PIPELINE_TEMPLATE_FILE"),
+			Name:    "template-file",
+			Usage:   "enables using a local template file for expansion",
+		},
+
+		// Compiler Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for compiling starlark pipelines",
+			Value:   7500,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Compare the pipelines of a repository for two refs.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --ref main --ref MyCommitSHA
+  2. Compare the pipelines of a repository for two refs with json output.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --ref v1.0.0 --ref v1.1.0 --output json
+  3. Compare the pipeline of a repository for a ref with the local pipeline.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --ref main --local
+  4. Compare the pipeline of a repository for a ref with a local pipeline using a local template.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --ref main --local --template-file <template_name>:<path_to_template>
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/diff/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to compare pipelines.
+func diff(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// parse the Vela client from the context
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/internal/client?tab=doc#Parse
+	client, err := client.Parse(c)
+	if err != nil {
+		return err
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:        internal.ActionDiff,
+		Org:           c.String(internal.FlagOrg),
+		Repo:          c.String(internal.FlagRepo),
+		Refs:          c.StringSlice("ref"),
+		Local:         c.Bool("local"),
+		File:          c.String("file"),
+		Path:          c.String("path"),
+		PipelineType:  c.String("pipeline-type"),
+		TemplateFiles: c.StringSlice("template-file"),
+		Output:        c.String(internal.FlagOutput),
+		Color:         output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// check if the pipeline should be compared with a local pipeline
+	if p.Local {
+		// create the compiler used for expanding the local pipeline
+		engine, err := execCompiler(ctx, c, p.TemplateFiles)
+		if err != nil {
+			return err
+		}
+
+		// execute the diff local call for the pipeline configuration
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.DiffLocal
+		return p.DiffLocal(ctx, client, engine.WithLocal(true).WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)))
+	}
+
+	// execute the diff call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Diff
+	return p.Diff(ctx, client)
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_Diff(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1", "--repo", "Repo-1", "--ref", "main", "--ref", "48afb5bdc41ad69bf22588491333f7cf71135163"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1", "--repo", "Repo-1", "--ref", "main", "--ref", "48afb5bdc41ad69bf22588491333f7cf71135163", "--output", "json"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1", "--repo", "Repo-1", "--ref", "main", "--local", "--file", "testdata/.vela.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1", "--repo", "Repo-1", "--ref", "main", "--ref", "0"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1", "--repo", "Repo-1", "--ref", "main"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1", "--repo", "Repo-1", "--ref", "main", "--ref", "48afb5bdc41ad69bf22588491333f7cf71135163", "--output", "yaml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, diff, CommandDiff.Flags),
+			args:    []string{"--org", "Org-1"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, diff, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("diff should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("diff returned err: %v", err)
+		}
+	}
+}
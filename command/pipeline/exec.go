@@ -6,7 +6,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
@@ -15,6 +17,8 @@ import (
 	"github.com/go-vela/cli/action"
 	"github.com/go-vela/cli/action/pipeline"
 	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/compiler"
 	"github.com/go-vela/server/compiler/native"
 	"github.com/go-vela/server/constants"
 )
@@ -62,6 +66,11 @@ var CommandExec = &cli.Command{
 			Aliases: []string{"fcs"},
 			Usage:   "provide a list of files changed for ruleset matching",
 		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CHANGESET_FROM_GIT", "PIPELINE_CHANGESET_FROM_GIT"),
+			Name:    "changeset-from-git",
+			Usage:   "provide a base git revision to compute the files changed for ruleset matching",
+		},
 
 		// Output Flags
 
@@ -72,6 +81,17 @@ var CommandExec = &cli.Command{
 			Usage:   "format the output in json, spew or yaml",
 			Value:   "yaml",
 		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_NO_MASK", "PIPELINE_NO_MASK"),
+			Name:    "no-mask",
+			Usage:   "disable masking of secret values in the build output",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPORT", "PIPELINE_REPORT"),
+			Name:    "report",
+			Usage:   "print a report of the steps and their outputs after the build in the provided format (json)",
+		},
 
 		// Pipeline Flags
 
@@ -89,6 +109,42 @@ var CommandExec = &cli.Command{
 			Usage:   "enables mounting local directory to pipeline",
 			Value:   true,
 		},
+		&cli.GenericFlag{
+			Sources: cli.EnvVars("VELA_CLEAN_ROOM", "PIPELINE_CLEAN_ROOM"),
+			Name:    "clean-room",
+			Usage:   "run the pipeline against a clean export of the committed git tree, optionally of a git revision with --clean-room=<ref> (default: HEAD), instead of the local directory",
+			Value:   new(cleanRoomValue),
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_EXPORT_ARTIFACTS", "PIPELINE_EXPORT_ARTIFACTS"),
+			Name:    "export-artifacts",
+			Usage:   "provide glob patterns for files to copy from the clean room back to the local directory",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_WATCH", "PIPELINE_WATCH"),
+			Name:    "watch",
+			Aliases: []string{"w"},
+			Usage:   "re-run the pipeline when the pipeline file, local templates or watched paths change after a run",
+			Value:   false,
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_WATCH_PATH", "PIPELINE_WATCH_PATH"),
+			Name:    "watch-path",
+			Aliases: []string{"wp"},
+			Usage:   "provide glob patterns, relative to the current directory, for workspace files to watch",
+		},
+		&cli.DurationFlag{
+			Sources: cli.EnvVars("VELA_WATCH_DEBOUNCE", "PIPELINE_WATCH_DEBOUNCE"),
+			Name:    "watch-debounce",
+			Usage:   "set the duration to wait for changes to settle before restarting the pipeline",
+			Value:   500 * time.Millisecond,
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_WATCH_MATCHING", "PIPELINE_WATCH_MATCHING"),
+			Name:    "watch-matching",
+			Usage:   "re-run only the steps with a path ruleset matching the changed workspace files",
+			Value:   false,
+		},
 		&cli.StringFlag{
 			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
 			Name:    "path",
@@ -110,6 +166,38 @@ var CommandExec = &cli.Command{
 			Aliases: []string{"pi"},
 			Usage:   "provide list of pipeline images that will run in privileged mode",
 		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_CACHE", "PIPELINE_CACHE"),
+			Name:    internal.FlagCache,
+			Usage:   "provide list of named caches, persisted between runs for the repo, to mount in the form <name>:<container-path>",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CPUS", "PIPELINE_CPUS"),
+			Name:    "cpus",
+			Usage:   "limit the number of CPUs available to each container (e.g. 1.5)",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_MEMORY", "PIPELINE_MEMORY"),
+			Name:    "memory",
+			Usage:   "limit the memory available to each container (e.g. 512m, 2g)",
+		},
+		&cli.DurationFlag{
+			Sources: cli.EnvVars("VELA_TIMEOUT", "PIPELINE_TIMEOUT"),
+			Name:    "timeout",
+			Usage:   "set the maximum duration for the build before it is canceled (e.g. 30m)",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_NO_PREPULL", "PIPELINE_NO_PREPULL"),
+			Name:    "no-prepull",
+			Usage:   "disable pulling the images for the pipeline before the build starts, which reports when each pull starts and finishes",
+			Value:   false,
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_PREPULL_LIMIT", "PIPELINE_PREPULL_LIMIT"),
+			Name:    "prepull-limit",
+			Usage:   "set the maximum number of images pulled at once before the build starts (0 for no limit)",
+			Value:   4,
+		},
 
 		// Repo Flags
 
@@ -141,6 +229,29 @@ var CommandExec = &cli.Command{
 			Aliases: []string{"sk", "skip"},
 			Usage:   "skip a step in the pipeline",
 		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_STEP_TIMEOUT", "PIPELINE_STEP_TIMEOUT"),
+			Name:    "step-timeout",
+			Usage:   "set the maximum duration for steps in the form <step>=<duration> or <stage>:<step>=<duration>, or <duration> for every step",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_FAIL_FAST", "PIPELINE_FAIL_FAST"),
+			Name:    "fail-fast",
+			Usage:   "cancel the remaining steps and parallel stages as soon as a step fails",
+			Value:   false,
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_DEBUG_ON_FAILURE", "PIPELINE_DEBUG_ON_FAILURE"),
+			Name:    "debug-on-failure",
+			Usage:   "start an interactive shell in a copy of the first failed step before cleaning up the build",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_DEBUG_SHELL", "PIPELINE_DEBUG_SHELL"),
+			Name:    "debug-shell",
+			Usage:   "provide the shell to start in the container when debugging a failed step",
+			Value:   "/bin/sh",
+		},
 
 		// Compiler Template Flags
 
@@ -168,6 +279,18 @@ var CommandExec = &cli.Command{
 			Usage:   "set the maximum depth for nested templates",
 			Value:   3,
 		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_CACHE", "PIPELINE_TEMPLATE_CACHE"),
+			Name:    "template-cache",
+			Usage:   "provide the directory of the cache for remote templates, reused for templates pinned to a commit or when offline, and for every template when set explicitly (set to an empty value to disable)",
+			Value:   internal.DefaultTemplateCache(),
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_OFFLINE", "PIPELINE_OFFLINE"),
+			Name:    "offline",
+			Usage:   "require remote templates to be in the template cache instead of fetching them",
+			Value:   false,
+		},
 		&cli.Int64Flag{
 			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
 			Name:    "compiler-starlark-exec-limit",
@@ -247,6 +370,36 @@ EXAMPLES:
     $ {{.FullName}} --env-file-path <path_to_file>
   14. Execute a local Vela pipeline using remote templates
     $ {{.FullName}} --compiler.github.token <GITHUB_PAT> --compiler.github.url <GITHUB_URL>
+  15. Execute a local Vela pipeline without masking secret values in the output
+    $ {{.FullName}} --no-mask
+  16. Execute a local Vela pipeline with the files changed since main for ruleset matching
+    $ {{.FullName}} --event pull_request --changeset-from-git main
+  17. Execute a local Vela pipeline against a clean checkout of the committed files
+    $ {{.FullName}} --clean-room
+  18. Execute a local Vela pipeline against a clean checkout of a git ref and copy back artifacts
+    $ {{.FullName}} --clean-room=v1.0.0 --export-artifacts 'dist/*'
+  19. Execute a local Vela pipeline and re-run it when the pipeline or go files change
+    $ {{.FullName}} --watch --watch-path '**/*.go'
+  20. Execute a local Vela pipeline and open a shell in the first failed step
+    $ {{.FullName}} --debug-on-failure --debug-shell /bin/bash
+  21. Execute a local Vela pipeline reusing the go module cache between runs
+    $ {{.FullName}} --cache gomod:/go/pkg/mod
+  22. Execute a local Vela pipeline with each container limited to 2 CPUs and 1 GB of memory
+    $ {{.FullName}} --cpus 2 --memory 1g
+  23. Execute a local Vela pipeline that is canceled after 30 minutes
+    $ {{.FullName}} --timeout 30m
+  24. Execute a local Vela pipeline with a 5 minute limit on every step and 15 minutes for the test step
+    $ {{.FullName}} --step-timeout 5m --step-timeout test=15m
+  25. Execute a local Vela pipeline that stops all parallel stages when a step fails
+    $ {{.FullName}} --fail-fast
+  26. Execute a local Vela pipeline and print a JSON report of the step outputs
+    $ {{.FullName}} --report json
+  27. Execute a local Vela pipeline pulling at most 2 images at once before the build starts
+    $ {{.FullName}} --prepull-limit 2
+  28. Execute a local Vela pipeline without pulling the images before the build starts
+    $ {{.FullName}} --no-prepull
+  29. Execute a local Vela pipeline using only the remote templates in the template cache
+    $ {{.FullName}} --offline
 
 DOCUMENTATION:
 
@@ -263,33 +416,8 @@ func exec(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
-	// clear local environment unless told otherwise
-	if !c.Bool("local-env") {
-		os.Clearenv()
-	}
-
-	// iterate through command-based env variables and set them in environment
-	for _, envSet := range c.StringSlice("env-vars") {
-		parts := strings.SplitN(envSet, "=", 2)
-
-		os.Setenv(parts[0], parts[1])
-	}
-
-	// load env file if provided
-	if c.Bool("env-file") || len(c.String("env-file-path")) > 0 {
-		switch len(c.String("env-file-path")) {
-		case 0:
-			err := godotenv.Load()
-			if err != nil {
-				logrus.Fatal("Error loading env file")
-			}
-		default:
-			err := godotenv.Load(c.String("env-file-path"))
-			if err != nil {
-				logrus.Fatal("Error loading env file")
-			}
-		}
-	}
+	// load the environment for executing the pipeline
+	loadExecEnv(c)
 
 	// account for users omitting the `refs/tags` prefix of the tag value
 	tag := c.String("tag")
@@ -301,6 +429,12 @@ func exec(ctx context.Context, c *cli.Command) error {
 		}
 	}
 
+	// capture the clean room and the git revision to export
+	cleanRoom, ok := c.Generic("clean-room").(*cleanRoomValue)
+	if !ok {
+		cleanRoom = new(cleanRoomValue)
+	}
+
 	// create the pipeline configuration
 	//
 	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
@@ -316,13 +450,39 @@ func exec(ctx context.Context, c *cli.Command) error {
 		SkipSteps:        c.StringSlice("skip-step"),
 		File:             c.String("file"),
 		FileChangeset:    c.StringSlice("file-changeset"),
+		ChangesetFromGit: c.String("changeset-from-git"),
 		TemplateFiles:    c.StringSlice("template-file"),
+		TemplateCache:    c.String("template-cache"),
+		CacheTemplates:   c.IsSet("template-cache"),
+		Offline:          c.Bool("offline"),
+		GitHubURL:        c.String(internal.FlagCompilerGitHubURL),
+		GitHubToken:      c.String(internal.FlagCompilerGitHubToken),
 		Local:            c.Bool("local"),
 		Path:             c.String("path"),
 		Volumes:          c.StringSlice("volume"),
+		Caches:           c.StringSlice(internal.FlagCache),
 		PrivilegedImages: c.StringSlice("privileged-images"),
+		CPUs:             c.String("cpus"),
+		Memory:           c.String("memory"),
+		Timeout:          c.Duration("timeout"),
+		StepTimeouts:     c.StringSlice("step-timeout"),
+		FailFast:         c.Bool("fail-fast"),
 		OutputsImage:     c.String("outputs-image"),
+		NoPrepull:        c.Bool("no-prepull"),
+		PrepullLimit:     c.Int("prepull-limit"),
+		Report:           c.String("report"),
+		Color:            output.ColorOptionsFromCLIContext(c),
 		PipelineType:     c.String("pipeline-type"),
+		NoMask:           c.Bool("no-mask"),
+		CleanRoom:        cleanRoom.enabled,
+		CleanRoomRef:     cleanRoom.ref,
+		ExportArtifacts:  c.StringSlice("export-artifacts"),
+		Watch:            c.Bool("watch"),
+		WatchPaths:       c.StringSlice("watch-path"),
+		WatchDebounce:    c.Duration("watch-debounce"),
+		WatchMatching:    c.Bool("watch-matching"),
+		DebugOnFailure:   c.Bool("debug-on-failure"),
+		DebugShell:       c.String("debug-shell"),
 	}
 
 	// validate pipeline configuration
@@ -333,20 +493,68 @@ func exec(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
+	// create the compiler for executing the pipeline
+	client, err := execCompiler(ctx, c, p.TemplateFiles)
+	if err != nil {
+		return err
+	}
+
+	// execute the exec call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Exec
+	//nolint:contextcheck // consider refactor to add context to action
+	return p.Exec(client)
+}
+
+// loadExecEnv is a helper function to load the environment
+// used when executing a pipeline locally.
+func loadExecEnv(c *cli.Command) {
+	// clear local environment unless told otherwise
+	if !c.Bool("local-env") {
+		os.Clearenv()
+	}
+
+	// iterate through command-based env variables and set them in environment
+	for _, envSet := range c.StringSlice("env-vars") {
+		parts := strings.SplitN(envSet, "=", 2)
+
+		os.Setenv(parts[0], parts[1])
+	}
+
+	// load env file if provided
+	if c.Bool("env-file") || len(c.String("env-file-path")) > 0 {
+		switch len(c.String("env-file-path")) {
+		case 0:
+			err := godotenv.Load()
+			if err != nil {
+				logrus.Fatal("Error loading env file")
+			}
+		default:
+			err := godotenv.Load(c.String("env-file-path"))
+			if err != nil {
+				logrus.Fatal("Error loading env file")
+			}
+		}
+	}
+}
+
+// execCompiler is a helper function to create the compiler
+// used when executing a pipeline locally.
+func execCompiler(ctx context.Context, c *cli.Command, templateFiles []string) (compiler.Engine, error) {
 	// create a compiler client
 	//
 	// https://godoc.org/github.com/go-vela/server/compiler/native#New
 	client, err := native.FromCLICommand(ctx, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// set starlark exec limit
 	client.SetStarlarkExecLimit(c.Int64("compiler-starlark-exec-limit"))
 
 	// set when user is sourcing templates from local machine
-	if len(p.TemplateFiles) != 0 {
-		client.WithLocalTemplates(p.TemplateFiles)
+	if len(templateFiles) != 0 {
+		client.WithLocalTemplates(templateFiles)
 		client.SetTemplateDepth(min(c.Int("max-template-depth"), 10))
 	} else {
 		// set max template depth to minimum of 5 and provided value if local templates are not provided.
@@ -355,9 +563,49 @@ func exec(ctx context.Context, c *cli.Command) error {
 		logrus.Debugf("no local template files provided, setting max template depth to %d", client.GetTemplateDepth())
 	}
 
-	// execute the exec call for the pipeline configuration
-	//
-	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Exec
-	//nolint:contextcheck // consider refactor to add context to action
-	return p.Exec(client.WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)))
+	return client.WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)), nil
+}
+
+// cleanRoomValue represents the value of the clean room flag, which
+// is set on its own, like a boolean flag, or to the git revision to
+// export into the clean room.
+type cleanRoomValue struct {
+	enabled bool
+	ref     string
+}
+
+// Set enables the clean room for a boolean value
+// or a git revision, and disables it otherwise.
+func (v *cleanRoomValue) Set(value string) error {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		// the value is the git revision to export
+		v.enabled, v.ref = true, value
+
+		return nil
+	}
+
+	v.enabled, v.ref = enabled, ""
+
+	return nil
+}
+
+// String returns the git revision, or whether
+// the clean room is enabled without a revision.
+func (v *cleanRoomValue) String() string {
+	if len(v.ref) > 0 {
+		return v.ref
+	}
+
+	return strconv.FormatBool(v.enabled)
+}
+
+// Get returns the value of the flag.
+func (v *cleanRoomValue) Get() any {
+	return v
+}
+
+// IsBoolFlag allows the flag to be set without a value.
+func (v *cleanRoomValue) IsBoolFlag() bool {
+	return true
 }
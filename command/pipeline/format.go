@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+)
+
+// CommandFormat defines the command for formatting a pipeline.
+var CommandFormat = &cli.Command{
+	Name:        "pipeline",
+	Description: "Use this command to format a pipeline.",
+	Usage:       "Format a local Vela pipeline into the canonical format",
+	Action:      format,
+	Flags: []cli.Flag{
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the pipeline",
+		},
+
+		// Format Flags
+
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_CHECK", "PIPELINE_CHECK"),
+			Name:    "check",
+			Aliases: []string{"c"},
+			Usage:   "output a diff and exit with an error when the pipeline is not formatted",
+			Value:   false,
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_WRITE", "PIPELINE_WRITE"),
+			Name:    "write",
+			Aliases: []string{"w"},
+			Usage:   "write the formatted pipeline to the file instead of stdout",
+			Value:   false,
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Output a local pipeline in the canonical format.
+    $ {{.FullName}}
+  2. Format a local pipeline in place.
+    $ {{.FullName}} --write
+  3. Check a local pipeline is formatted in CI.
+    $ {{.FullName}} --check
+  4. Format a local pipeline in a nested directory in place.
+    $ {{.FullName}} --path nested/path/to/dir --file .vela.yml --write
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/fmt/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to format a pipeline.
+func format(_ context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action: internal.ActionFormat,
+		File:   c.String("file"),
+		Path:   c.String("path"),
+		Check:  c.Bool("check"),
+		Write:  c.Bool("write"),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the format call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Format
+	return p.Format()
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_Format(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	dir := t.TempDir()
+
+	data, err := os.ReadFile("testdata/.vela.yml")
+	if err != nil {
+		t.Fatalf("unable to read pipeline: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, ".vela.yml"), data, 0644)
+	if err != nil {
+		t.Fatalf("unable to write pipeline: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, format, CommandFormat.Flags),
+			args:    []string{"--file", "testdata/.vela.yml"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, format, CommandFormat.Flags),
+			args:    []string{"--path", dir, "--write"},
+		},
+		{
+			// the pipeline is formatted by the previous test
+			failure: false,
+			cmd:     test.Command(s.URL, format, CommandFormat.Flags),
+			args:    []string{"--path", dir, "--check"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, format, CommandFormat.Flags),
+			args:    []string{"--path", dir, "--check", "--write"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, format, CommandFormat.Flags),
+			args:    []string{"--file", "empty.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, format, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("format should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("format returned err: %v", err)
+		}
+	}
+}
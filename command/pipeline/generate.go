@@ -65,6 +65,8 @@ EXAMPLES:
     $ {{.FullName}} --secret.type java
   7. Generate a node Vela pipeline.
     $ {{.FullName}} --secret.type node
+  8. Generate a docker Vela pipeline.
+    $ {{.FullName}} --type docker
 
 DOCUMENTATION:
 
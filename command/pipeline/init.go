@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+)
+
+// CommandInit defines the command for initializing a pipeline.
+var CommandInit = &cli.Command{
+	Name:        "pipeline",
+	Description: "Use this command to initialize a pipeline for the project in the current directory.",
+	Usage:       "Initialize a Vela pipeline by detecting the project language",
+	Action:      initialize,
+	Flags: []cli.Flag{
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "REPO_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "REPO_NAME"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the pipeline",
+		},
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the project for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TYPE", "PIPELINE_TYPE"),
+			Name:    "type",
+			Aliases: []string{"t"},
+			Usage:   "provide the type of pipeline instead of detecting it (go, java, node, python, rust or docker)",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_DEFAULTS", "PIPELINE_DEFAULTS"),
+			Name:    "defaults",
+			Aliases: []string{"y"},
+			Usage:   "use the detected values without prompting",
+			Value:   false,
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_FORCE", "PIPELINE_FORCE"),
+			Name:    "force",
+			Usage:   "overwrite an existing pipeline file",
+			Value:   false,
+		},
+
+		// Compiler Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Initialize a Vela pipeline for the project in the current directory.
+    $ {{.FullName}}
+  2. Initialize a Vela pipeline for a project in a nested directory.
+    $ {{.FullName}} --path nested/path/to/dir
+  3. Initialize a Vela pipeline with the detected values without prompting.
+    $ {{.FullName}} --defaults
+  4. Initialize a rust Vela pipeline.
+    $ {{.FullName}} --type rust
+  5. Initialize a Vela pipeline overwriting the existing pipeline.
+    $ {{.FullName}} --force
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/init/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to initialize a pipeline.
+func initialize(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:   internal.ActionInit,
+		Org:      c.String(internal.FlagOrg),
+		Repo:     c.String(internal.FlagRepo),
+		File:     c.String("file"),
+		Path:     c.String("path"),
+		Type:     c.String("type"),
+		Defaults: c.Bool("defaults"),
+		Force:    c.Bool("force"),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler used for compiling the initialized pipeline
+	client, err := execCompiler(ctx, c, nil)
+	if err != nil {
+		return err
+	}
+
+	// execute the init call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Init
+	//nolint:contextcheck // consider refactor to add context to action
+	return p.Init(client.WithLocal(true), os.Stdin)
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_Init(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	dir := t.TempDir()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, initialize, CommandInit.Flags),
+			args:    []string{"--path", dir, "--type", "rust", "--defaults"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, initialize, CommandInit.Flags),
+			args:    []string{"--path", dir, "--type", "rust", "--defaults"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, initialize, CommandInit.Flags),
+			args:    []string{"--path", dir, "--type", "python", "--defaults", "--force"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, initialize, CommandInit.Flags),
+			args:    []string{"--path", dir, "--type", "cobol", "--defaults", "--force"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, initialize, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("initialize should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("initialize returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandLint defines the command for linting a pipeline.
+var CommandLint = &cli.Command{
+	Name:        "pipeline",
+	Description: "Use this command to lint a pipeline.",
+	Usage:       "Lint a local Vela pipeline",
+	Action:      lint,
+	Flags: []cli.Flag{
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the pipeline",
+		},
+
+		// Lint Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_LINT_CONFIG", "PIPELINE_LINT_CONFIG"),
+			Name:    "lint-config",
+			Aliases: []string{"lc"},
+			Usage:   "provide the configuration file with the severities and suppressions for the lint rules",
+			Value:   ".vela-lint.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FAIL_ON", "PIPELINE_FAIL_ON"),
+			Name:    "fail-on",
+			Usage:   "exit with an error for findings at or above the severity (error, warning, note or none)",
+			Value:   "error",
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "PIPELINE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in text, json or sarif",
+			Value:   "text",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Lint a local pipeline.
+    $ {{.FullName}}
+  2. Lint a local pipeline in a nested directory.
+    $ {{.FullName}} --path nested/path/to/dir --file .vela.yml
+  3. Lint a local pipeline with a lint configuration file.
+    $ {{.FullName}} --lint-config ci/.vela-lint.yml
+  4. Lint a local pipeline and fail on warnings.
+    $ {{.FullName}} --fail-on warning
+  5. Lint a local pipeline with json output.
+    $ {{.FullName}} --output json
+  6. Lint a local pipeline with sarif output for code scanning.
+    $ {{.FullName}} --output sarif > vela-lint.sarif
+
+CONFIGURATION:
+
+  rules:
+    unpinned-image:
+      severity: note
+    privileged-image:
+      images: [ "target/vela-docker*" ]
+  suppressions:
+    - rule: secret-in-environment
+      step: test
+      reason: test credentials
+
+  Findings can also be suppressed with a "# vela-lint-ignore <rule>" comment on the same line.
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/lint/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to lint a pipeline.
+func lint(_ context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:     internal.ActionLint,
+		File:       c.String("file"),
+		Path:       c.String("path"),
+		LintConfig: c.String("lint-config"),
+		FailOn:     c.String("fail-on"),
+		Output:     c.String(internal.FlagOutput),
+		Color:      output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the lint call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Lint
+	return p.Lint()
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/server/constants"
+)
+
+// CommandExecServices defines the command for executing the services of a pipeline.
+var CommandExecServices = &cli.Command{
+	Name:        "services",
+	Aliases:     []string{"service"},
+	Description: "Use this command to start only the services of a pipeline locally, using the same network and aliases as the pipeline, until interrupted.",
+	Usage:       "Execute the services of the provided pipeline locally",
+	Action:      execServices,
+	Flags: []cli.Flag{
+		// Build Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_BRANCH", "PIPELINE_BRANCH", "VELA_BUILD_BRANCH"),
+			Name:    "branch",
+			Aliases: []string{"b"},
+			Usage:   "provide the build branch for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_EVENT", "PIPELINE_EVENT", "VELA_BUILD_EVENT"),
+			Name:    "event",
+			Aliases: []string{"e"},
+			Usage:   "provide the build event for the pipeline",
+		},
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the pipeline",
+		},
+
+		// Runtime Flags
+
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_VOLUMES", "PIPELINE_VOLUMES"),
+			Name:    "volume",
+			Aliases: []string{"v"},
+			Usage:   "provide list of local volumes to mount",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_PRIVILEGED_IMAGES", "PIPELINE_PRIVILEGED_IMAGES"),
+			Name:    "privileged-images",
+			Aliases: []string{"pi"},
+			Usage:   "provide list of pipeline images that will run in privileged mode",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_CACHE", "PIPELINE_CACHE"),
+			Name:    internal.FlagCache,
+			Usage:   "provide list of named caches, persisted between runs for the repo, to mount in the form <name>:<container-path>",
+		},
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "PIPELINE_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "PIPELINE_REPO"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PIPELINE_TYPE", "PIPELINE_TYPE"),
+			Name:    "pipeline-type",
+			Aliases: []string{"pt"},
+			Usage:   "type of pipeline for the compiler to render",
+			Value:   constants.PipelineTypeYAML,
+		},
+
+		// Compiler Template Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_FILE", "PIPELINE_TEMPLATE_FILE"),
+			Name:    "template-file",
+			Aliases: []string{"tf", "tfs", "template-files"},
+			Usage:   "enables using a local template file for expansion in the form <name>:<path>",
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for compiling starlark pipelines",
+			Value:   7500,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
+
+		// Environment Flags
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_ENV_FILE", "ENV_FILE"),
+			Name:    "env-file",
+			Aliases: []string{"ef"},
+			Usage:   "load environment variables from a .env file",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ENV_FILE_PATH", "ENV_FILE_PATH"),
+			Name:    "env-file-path",
+			Aliases: []string{"efp"},
+			Usage:   "provide the path to the file for the environment",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("ONBOARD_LOCAL_ENV", "LOCAL_ENV"),
+			Name:    "local-env",
+			Usage:   "load environment variables from local environment",
+			Value:   false,
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_ENV_VARS"),
+			Name:    "env-vars",
+			Aliases: []string{"env"},
+			Usage:   "load a set of environment variables in the form of KEY1=VAL1,KEY2=VAL2",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Start the services of a local Vela pipeline.
+    $ {{.FullName}}
+  2. Start the services of a local Vela pipeline in a nested directory.
+    $ {{.FullName}} --path nested/path/to/dir --file .vela.local.yml
+  3. Start the services of a local Vela pipeline with specific environment variables.
+    $ {{.FullName}} --env POSTGRES_PASSWORD=secret
+  4. Start the services of a local Vela pipeline with a persisted cache for the database.
+    $ {{.FullName}} --cache pgdata:/var/lib/postgresql/data
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/exec/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input and create
+// the object used to execute the services of a pipeline.
+func execServices(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// load the environment for executing the pipeline
+	loadExecEnv(c)
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:           internal.ActionExec,
+		Branch:           c.String("branch"),
+		Event:            c.String("event"),
+		Org:              c.String(internal.FlagOrg),
+		Repo:             c.String(internal.FlagRepo),
+		File:             c.String("file"),
+		TemplateFiles:    c.StringSlice("template-file"),
+		Local:            true,
+		Path:             c.String("path"),
+		Volumes:          c.StringSlice("volume"),
+		Caches:           c.StringSlice(internal.FlagCache),
+		PrivilegedImages: c.StringSlice("privileged-images"),
+		PipelineType:     c.String("pipeline-type"),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler for executing the pipeline
+	client, err := execCompiler(ctx, c, p.TemplateFiles)
+	if err != nil {
+		return err
+	}
+
+	// execute the exec services call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.ExecServices
+	//nolint:contextcheck // consider refactor to add context to action
+	return p.ExecServices(client)
+}
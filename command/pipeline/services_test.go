@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_ExecServices(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			// the pipeline does not define any services to start
+			failure: true,
+			cmd:     test.Command(s.URL, execServices, CommandExecServices.Flags),
+			args:    []string{"--file", "testdata/.vela.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, execServices, CommandExecServices.Flags),
+			args:    []string{"--file", "empty.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, execServices, CommandExecServices.Flags),
+			args:    []string{"--file", "testdata/.vela.yml", "--event", "tag"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, execServices, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("execServices should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("execServices returned err: %v", err)
+		}
+	}
+}
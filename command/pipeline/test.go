@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/pipeline"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/constants"
+)
+
+// CommandTest defines the command for testing a pipeline.
+var CommandTest = &cli.Command{
+	Name:        "pipeline",
+	Description: "Use this command to test a local pipeline against the scenarios in assertion files.",
+	Usage:       "Test which steps of a pipeline run for a set of scenarios",
+	Action:      testPipeline,
+	Flags: []cli.Flag{
+
+		// Repo Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_ORG", "REPO_ORG"),
+			Name:    internal.FlagOrg,
+			Aliases: []string{"o"},
+			Usage:   "provide the organization for the pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_REPO", "REPO_NAME"),
+			Name:    internal.FlagRepo,
+			Aliases: []string{"r"},
+			Usage:   "provide the repository for the pipeline",
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "PIPELINE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in text or junit",
+		},
+
+		// Test Flags
+
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEST_FILE", "PIPELINE_TEST_FILE"),
+			Name:    "test-file",
+			Aliases: []string{"t"},
+			Usage:   "provide the assertion files with the scenarios to test",
+		},
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the pipeline and assertion files",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PIPELINE_TYPE", "PIPELINE_TYPE"),
+			Name:    "pipeline-type",
+			Aliases: []string{"pt"},
+			Usage:   "type of pipeline for the compiler to render",
+			Value:   constants.PipelineTypeYAML,
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_FILE", "PIPELINE_TEMPLATE_FILE"),
+			Name:    "template-file",
+			Usage:   "enables using a local template file for expansion",
+		},
+
+		// Compiler Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for compiling starlark pipelines",
+			Value:   7500,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Test a local pipeline with the assertion files in the current directory.
+    $ {{.FullName}}
+  2. Test a local pipeline with a specific assertion file.
+    $ {{.FullName}} --test-file push.vela-test.yml
+  3. Test a local pipeline with assertion files from a different path.
+    $ {{.FullName}} --path /path/to/repo
+  4. Test a local pipeline and report the results in junit format.
+    $ {{.FullName}} --output junit > report.xml
+  5. Test a local pipeline using a local template.
+    $ {{.FullName}} --template-file <template_name>:<path_to_template>
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/pipeline/test/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to test a pipeline.
+func testPipeline(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:        internal.ActionTest,
+		Org:           c.String(internal.FlagOrg),
+		Repo:          c.String(internal.FlagRepo),
+		File:          c.String("file"),
+		Path:          c.String("path"),
+		PipelineType:  c.String("pipeline-type"),
+		TemplateFiles: c.StringSlice("template-file"),
+		TestFiles:     append(c.StringSlice("test-file"), c.Args().Slice()...),
+		Output:        c.String(internal.FlagOutput),
+		Color:         output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler used for compiling the pipeline
+	client, err := execCompiler(ctx, c, p.TemplateFiles)
+	if err != nil {
+		return err
+	}
+
+	// execute the test call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Test
+	return p.Test(ctx, client.WithLocal(true).WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)))
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestPipeline_Test(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, testPipeline, CommandTest.Flags),
+			args:    []string{"testdata/hello.vela-test.yml"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, testPipeline, CommandTest.Flags),
+			args:    []string{"--test-file", "testdata/hello.vela-test.yml", "--output", "junit"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, testPipeline, CommandTest.Flags),
+			args:    []string{"testdata/failing.vela-test.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, testPipeline, CommandTest.Flags),
+			args:    []string{"--output", "yaml", "testdata/hello.vela-test.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, testPipeline, CommandTest.Flags),
+			args:    []string{"testdata/missing.vela-test.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, testPipeline, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("test should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("test returned err: %v", err)
+		}
+	}
+}
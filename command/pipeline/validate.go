@@ -13,6 +13,7 @@ import (
 	"github.com/go-vela/cli/action/pipeline"
 	"github.com/go-vela/cli/internal"
 	"github.com/go-vela/cli/internal/client"
+	"github.com/go-vela/server/compiler"
 	"github.com/go-vela/server/compiler/native"
 	"github.com/go-vela/server/constants"
 )
@@ -66,6 +67,25 @@ var CommandValidate = &cli.Command{
 			Usage:    "provide the path to the file for the pipeline",
 			Category: "2. Pipeline:",
 		},
+		&cli.StringSliceFlag{
+			Sources:  cli.EnvVars("VELA_FILES", "PIPELINE_FILES"),
+			Name:     "files",
+			Usage:    "provide globs or directories of pipeline files to validate together",
+			Category: "2. Pipeline:",
+		},
+		&cli.IntFlag{
+			Sources:  cli.EnvVars("VELA_CONCURRENCY", "PIPELINE_CONCURRENCY"),
+			Name:     "concurrency",
+			Usage:    "set the number of pipeline files validated at once (defaults to the number of CPUs)",
+			Category: "2. Pipeline:",
+		},
+		&cli.StringFlag{
+			Sources:  cli.EnvVars("VELA_OUTPUT", "PIPELINE_OUTPUT"),
+			Name:     internal.FlagOutput,
+			Aliases:  []string{"op"},
+			Usage:    "format the output of the validated pipeline or of validating many pipeline files in json or yaml",
+			Category: "2. Pipeline:",
+		},
 		&cli.StringFlag{
 			Sources:  cli.EnvVars("VELA_REF", "PIPELINE_REF"),
 			Name:     "ref",
@@ -92,6 +112,34 @@ var CommandValidate = &cli.Command{
 			Value:    3,
 			Category: "2. Pipeline:",
 		},
+		&cli.StringFlag{
+			Sources:  cli.EnvVars("VELA_TEMPLATE_CACHE", "PIPELINE_TEMPLATE_CACHE"),
+			Name:     "template-cache",
+			Usage:    "provide the directory of the cache for remote templates, reused for templates pinned to a commit or when offline, and for every template when set explicitly (set to an empty value to disable)",
+			Value:    internal.DefaultTemplateCache(),
+			Category: "2. Pipeline:",
+		},
+		&cli.BoolFlag{
+			Sources:  cli.EnvVars("VELA_OFFLINE", "PIPELINE_OFFLINE"),
+			Name:     "offline",
+			Usage:    "require remote templates to be in the template cache instead of fetching them",
+			Value:    false,
+			Category: "2. Pipeline:",
+		},
+		&cli.BoolFlag{
+			Sources:  cli.EnvVars("VELA_NO_SCHEMA", "PIPELINE_NO_SCHEMA"),
+			Name:     "no-schema",
+			Usage:    "skip verifying local pipelines against the pipeline schema before compiling them",
+			Value:    false,
+			Category: "2. Pipeline:",
+		},
+		&cli.BoolFlag{
+			Sources:  cli.EnvVars("VELA_STRICT", "PIPELINE_STRICT"),
+			Name:     "strict",
+			Usage:    "fail validating local pipelines that do not match the pipeline schema, even when they compile",
+			Value:    false,
+			Category: "2. Pipeline:",
+		},
 		&cli.Int64Flag{
 			Sources:  cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
 			Name:     "compiler-starlark-exec-limit",
@@ -156,6 +204,19 @@ var CommandValidate = &cli.Command{
 			Usage:    "provide a list of files changed for ruleset matching",
 			Category: "3. Ruleset:",
 		},
+		&cli.StringFlag{
+			Sources:  cli.EnvVars("VELA_CHANGESET_FROM_GIT", "PIPELINE_CHANGESET_FROM_GIT"),
+			Name:     "changeset-from-git",
+			Usage:    "provide a base git revision to compute the files changed for ruleset matching",
+			Category: "3. Ruleset:",
+		},
+		&cli.BoolFlag{
+			Sources:  cli.EnvVars("VELA_EXPLAIN", "PIPELINE_EXPLAIN"),
+			Name:     "explain",
+			Usage:    "explain which steps run for each event and the comma separated branches, tags and targets provided",
+			Value:    false,
+			Category: "3. Ruleset:",
+		},
 
 		// Compiler Flags
 
@@ -200,6 +261,22 @@ EXAMPLES:
     $ {{.FullName}} --template-file name:/path/to/file
   9. Validate a local, nested template pipeline with custom template depth.
     $ {{.FullName}} --template-file name:/path/to/file name:/path/to/file --max-template-depth 2
+  10. Validate a pipeline with the files changed since main for ruleset matching
+    $ {{.FullName}} --event pull_request --changeset-from-git main
+  11. Explain which steps run for each event on the main and dev branches.
+    $ {{.FullName}} --explain --branch main,dev
+  12. Explain which steps run for each event with a tag, deployment target and changed files.
+    $ {{.FullName}} --explain --tag v1.0.0 --target staging --file-changeset docs/README.md
+  13. Validate a template pipeline using only the templates in the template cache.
+    $ {{.FullName}} --offline
+  14. Validate every pipeline file in a directory and matching a glob.
+    $ {{.FullName}} ci/ 'services/**/.vela.yml'
+  15. Validate many pipeline files with ruleset data and json output.
+    $ {{.FullName}} --files 'ci/*.yml' --event push --output json
+  16. Validate a local Vela pipeline with only the compiler, skipping the schema.
+    $ {{.FullName}} --no-schema
+  17. Validate a local Vela pipeline, failing on keys and values the schema does not allow.
+    $ {{.FullName}} --strict
 DOCUMENTATION:
 
   https://go-vela.github.io/docs/reference/cli/pipeline/validate/
@@ -223,22 +300,34 @@ func validate(ctx context.Context, c *cli.Command) error {
 	//
 	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
 	p := &pipeline.Config{
-		Action:        internal.ActionValidate,
-		Org:           c.String(internal.FlagOrg),
-		Repo:          c.String(internal.FlagRepo),
-		File:          c.String("file"),
-		Path:          c.String("path"),
-		Ref:           c.String("ref"),
-		TemplateFiles: c.StringSlice("template-file"),
-		Remote:        c.Bool("remote"),
-		PipelineType:  c.String("pipeline-type"),
-		Branch:        c.String("branch"),
-		Comment:       c.String("comment"),
-		Event:         c.String("event"),
-		FileChangeset: c.StringSlice("file-changeset"),
-		Status:        c.String("status"),
-		Tag:           c.String("tag"),
-		Target:        c.String("target"),
+		Action:           internal.ActionValidate,
+		Org:              c.String(internal.FlagOrg),
+		Repo:             c.String(internal.FlagRepo),
+		File:             c.String("file"),
+		Files:            append(c.StringSlice("files"), c.Args().Slice()...),
+		Concurrency:      c.Int("concurrency"),
+		Path:             c.String("path"),
+		Ref:              c.String("ref"),
+		TemplateFiles:    c.StringSlice("template-file"),
+		TemplateCache:    c.String("template-cache"),
+		CacheTemplates:   c.IsSet("template-cache"),
+		Offline:          c.Bool("offline"),
+		GitHubURL:        c.String(internal.FlagCompilerGitHubURL),
+		GitHubToken:      c.String(internal.FlagCompilerGitHubToken),
+		Remote:           c.Bool("remote"),
+		PipelineType:     c.String("pipeline-type"),
+		Branch:           c.String("branch"),
+		Comment:          c.String("comment"),
+		Event:            c.String("event"),
+		FileChangeset:    c.StringSlice("file-changeset"),
+		ChangesetFromGit: c.String("changeset-from-git"),
+		Status:           c.String("status"),
+		Tag:              c.String("tag"),
+		Target:           c.String("target"),
+		Explain:          c.Bool("explain"),
+		NoSchema:         c.Bool("no-schema"),
+		Strict:           c.Bool("strict"),
+		Output:           c.String(internal.FlagOutput),
 	}
 
 	// validate pipeline configuration
@@ -265,31 +354,48 @@ func validate(ctx context.Context, c *cli.Command) error {
 		return p.ValidateRemote(ctx, client)
 	}
 
-	// create a compiler client
-	//
-	// https://godoc.org/github.com/go-vela/server/compiler/native#New
-	client, err := native.FromCLICommand(ctx, c)
-	if err != nil {
-		return err
+	// create a compiler client for the pipeline
+	newClient := func() (compiler.Engine, error) {
+		// create a compiler client
+		//
+		// https://godoc.org/github.com/go-vela/server/compiler/native#New
+		client, err := native.FromCLICommand(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		// set starlark exec limit
+		client.SetStarlarkExecLimit(c.Int64("compiler-starlark-exec-limit"))
+
+		// set when user is sourcing templates from local machine
+		if len(p.TemplateFiles) != 0 {
+			client.WithLocalTemplates(p.TemplateFiles)
+			client.SetTemplateDepth(c.Int("max-template-depth"))
+		} else {
+			// set max template depth to minimum of 5 and provided value if local templates are not provided.
+			// This prevents users from spamming SCM
+			client.SetTemplateDepth(min(c.Int("max-template-depth"), 5))
+			logrus.Debugf("no local template files provided, setting max template depth to %d", client.GetTemplateDepth())
+		}
+
+		return client.WithLocal(true).WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)), nil
 	}
 
-	// set starlark exec limit
-	client.SetStarlarkExecLimit(c.Int64("compiler-starlark-exec-limit"))
+	// check if many pipeline files are provided
+	if len(p.Files) > 0 {
+		// execute the validate files call for the pipeline configuration
+		//
+		// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.ValidateFiles
+		return p.ValidateFiles(ctx, newClient)
+	}
 
-	// set when user is sourcing templates from local machine
-	if len(p.TemplateFiles) != 0 {
-		client.WithLocalTemplates(p.TemplateFiles)
-		client.SetTemplateDepth(c.Int("max-template-depth"))
-	} else {
-		// set max template depth to minimum of 5 and provided value if local templates are not provided.
-		// This prevents users from spamming SCM
-		client.SetTemplateDepth(min(c.Int("max-template-depth"), 5))
-		logrus.Debugf("no local template files provided, setting max template depth to %d", client.GetTemplateDepth())
+	client, err := newClient()
+	if err != nil {
+		return err
 	}
 
 	// execute the validate local call for the pipeline configuration
 	//
 	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.ValidateLocal
-	//nolint:contextcheck // consider refactor to add context to action
-	return p.ValidateLocal(client.WithLocal(true).WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)))
+	return p.ValidateLocal(ctx, client)
 }
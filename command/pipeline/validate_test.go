@@ -27,11 +27,51 @@ func TestPipeline_Validate(t *testing.T) {
 			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
 			args:    []string{"--file", "testdata/.vela.yml"},
 		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--file", "testdata/.vela.yml", "--no-schema"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--file", "testdata/.vela.yml", "--strict"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--file", "testdata/.vela.yml", "--output", "json"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--file", "testdata/.vela.yml", "--output", "yaml"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--output", "yaml", "testdata/.vela.yml"},
+		},
 		{
 			failure: true,
 			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
 			args:    []string{"--file", "empty.yml"},
 		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--files", "testdata/.vela.yml"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--output", "json", "testdata/.vela.yml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, validate, CommandValidate.Flags),
+			args:    []string{"--files", "testdata/missing/*.yml"},
+		},
 		{
 			failure: true,
 			cmd:     test.Command(s.URL, validate, nil),
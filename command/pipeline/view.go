@@ -6,7 +6,9 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
 
 	"github.com/go-vela/cli/action"
@@ -14,6 +16,7 @@ import (
 	"github.com/go-vela/cli/internal"
 	"github.com/go-vela/cli/internal/client"
 	"github.com/go-vela/cli/internal/output"
+	"github.com/go-vela/server/constants"
 )
 
 // CommandView defines the command for inspecting a pipeline.
@@ -47,6 +50,25 @@ var CommandView = &cli.Command{
 			Aliases: []string{"op"},
 			Usage:   "format the output in json, spew or yaml",
 		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_GRAPH", "PIPELINE_GRAPH"),
+			Name:    "graph",
+			Aliases: []string{"g"},
+			Usage:   "render the stages and steps of the pipeline as a graph in ascii, dot or mermaid",
+		},
+
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_ENV", "PIPELINE_ENV"),
+			Name:    "env",
+			Usage:   "view the environment variables injected into a step of a local pipeline",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_STEP", "PIPELINE_STEP"),
+			Name:    "step",
+			Aliases: []string{"s"},
+			Usage:   "provide the step, as <step> or <stage>:<step>, to view the environment of",
+		},
 
 		// Pipeline Flags
 
@@ -55,6 +77,109 @@ var CommandView = &cli.Command{
 			Name:    "ref",
 			Usage:   "provide the repository reference for the pipeline",
 		},
+
+		// Local Flags
+
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_LOCAL", "PIPELINE_LOCAL"),
+			Name:    "local",
+			Aliases: []string{"l"},
+			Usage:   "view the graph of a local pipeline file without a server",
+			Value:   false,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the local pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the local pipeline",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PIPELINE_TYPE", "PIPELINE_TYPE"),
+			Name:    "pipeline-type",
+			Aliases: []string{"pt"},
+			Usage:   "type of pipeline for the compiler to render",
+			Value:   constants.PipelineTypeYAML,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_BRANCH", "PIPELINE_BRANCH", "VELA_BUILD_BRANCH"),
+			Name:    "branch",
+			Aliases: []string{"b"},
+			Usage:   "provide the build branch to simulate for the environment",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMMENT", "PIPELINE_COMMENT", "VELA_BUILD_COMMENT"),
+			Name:    "comment",
+			Aliases: []string{"c"},
+			Usage:   "provide the build comment to simulate for the environment",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_EVENT", "PIPELINE_EVENT", "VELA_BUILD_EVENT"),
+			Name:    "event",
+			Aliases: []string{"e"},
+			Usage:   "provide the build event to simulate for the environment",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TAG", "PIPELINE_TAG", "VELA_BUILD_TAG"),
+			Name:    "tag",
+			Usage:   "provide the build tag to simulate for the environment",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TARGET", "PIPELINE_TARGET", "VELA_BUILD_TARGET"),
+			Name:    "target",
+			Usage:   "provide the build target to simulate for the environment",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_FILE_CHANGESET", "FILE_CHANGESET"),
+			Name:    "file-changeset",
+			Aliases: []string{"fcs"},
+			Usage:   "provide a list of files changed to simulate for the environment",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_FILE", "PIPELINE_TEMPLATE_FILE"),
+			Name:    "template-file",
+			Usage:   "enables using a local template file for expansion",
+		},
+
+		// Compiler Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for compiling starlark pipelines",
+			Value:   7500,
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"),
+			Name:    "clone-image",
+			Usage:   "the clone image to use for the injected clone step",
+			Value:   "docker.io/target/vela-git-slim:v0.14.0@sha256:592b6f0607912380ed61c79dcfca8145509a7d0f49b0839d9132095f5797668c", // renovate: container
+		},
 	},
 	CustomHelpTemplate: fmt.Sprintf(`%s
 EXAMPLES:
@@ -64,6 +189,18 @@ EXAMPLES:
     $ {{.FullName}} --org MyOrg --repo MyRepo --ref MyCommitSHA
   3. View details of a pipeline for a repository when config or environment variables are set.
     $ {{.FullName}}
+  4. View the stages and steps of a pipeline for a repository as a Mermaid flowchart.
+    $ {{.FullName}} --org MyOrg --repo MyRepo --ref MyCommitSHA --graph mermaid
+  5. View the stages and steps of a local pipeline as plain text.
+    $ {{.FullName}} --local --graph ascii
+  6. View the stages and steps of a local pipeline in a nested directory as a Graphviz graph.
+    $ {{.FullName}} --local --path nested/path/to/dir --graph dot | dot -Tsvg -o pipeline.svg
+  7. View the environment variables injected into a step of a local pipeline.
+    $ {{.FullName}} --env --step test
+  8. View the environment variables injected into a step of a local pipeline for a simulated tag event.
+    $ {{.FullName}} --env --step publish --event tag --tag v1.0.0
+  9. View the environment variables injected into a step of a stage of a local pipeline with json output.
+    $ {{.FullName}} --env --step build:test --branch main --event push --output json
 
 DOCUMENTATION:
 
@@ -80,6 +217,16 @@ func view(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
+	// check if the environment of a step should be viewed
+	if c.Bool("env") {
+		return viewEnv(ctx, c)
+	}
+
+	// check if the pipeline should be viewed locally
+	if c.Bool("local") {
+		return viewLocal(ctx, c)
+	}
+
 	// parse the Vela client from the context
 	//
 	// https://pkg.go.dev/github.com/go-vela/cli/internal/client?tab=doc#Parse
@@ -98,6 +245,7 @@ func view(ctx context.Context, c *cli.Command) error {
 		Output: c.String(internal.FlagOutput),
 		Color:  output.ColorOptionsFromCLIContext(c),
 		Ref:    c.String("ref"),
+		Graph:  c.String("graph"),
 	}
 
 	// validate pipeline configuration
@@ -113,3 +261,95 @@ func view(ctx context.Context, c *cli.Command) error {
 	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.View
 	return p.View(ctx, client)
 }
+
+// helper function to capture the provided input and
+// create the object used to view a local pipeline.
+func viewLocal(ctx context.Context, c *cli.Command) error {
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:        internal.ActionView,
+		File:          c.String("file"),
+		Path:          c.String("path"),
+		PipelineType:  c.String("pipeline-type"),
+		TemplateFiles: c.StringSlice("template-file"),
+		Local:         true,
+		Graph:         c.String("graph"),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler used for expanding the pipeline
+	client, err := execCompiler(ctx, c, p.TemplateFiles)
+	if err != nil {
+		return err
+	}
+
+	// execute the view local call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.ViewLocal
+	return p.ViewLocal(ctx, client.WithLocal(true).WithPrivateGitHub(ctx, c.String(internal.FlagCompilerGitHubURL), c.String(internal.FlagCompilerGitHubToken)))
+}
+
+// helper function to capture the provided input and create the
+// object used to view the environment of a step in a local pipeline.
+func viewEnv(ctx context.Context, c *cli.Command) error {
+	tag := c.String("tag")
+
+	if len(tag) > 0 && !strings.HasPrefix(tag, "refs/tags/") {
+		logrus.Debugf("setting tag value to refs/tags/%s", tag)
+
+		tag = "refs/tags/" + tag
+	}
+
+	// create the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config
+	p := &pipeline.Config{
+		Action:        internal.ActionView,
+		Branch:        c.String("branch"),
+		Comment:       c.String("comment"),
+		Event:         c.String("event"),
+		Tag:           tag,
+		Target:        c.String("target"),
+		Org:           c.String(internal.FlagOrg),
+		Repo:          c.String(internal.FlagRepo),
+		File:          c.String("file"),
+		FileChangeset: c.StringSlice("file-changeset"),
+		Path:          c.String("path"),
+		PipelineType:  c.String("pipeline-type"),
+		TemplateFiles: c.StringSlice("template-file"),
+		Local:         true,
+		Graph:         c.String("graph"),
+		Env:           true,
+		Step:          c.String("step"),
+		Output:        c.String(internal.FlagOutput),
+		Color:         output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.Validate
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// create the compiler used for compiling the pipeline
+	client, err := execCompiler(ctx, c, p.TemplateFiles)
+	if err != nil {
+		return err
+	}
+
+	// execute the view env call for the pipeline configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/pipeline?tab=doc#Config.ViewEnv
+	return p.ViewEnv(ctx, client)
+}
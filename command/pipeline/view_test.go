@@ -32,6 +32,16 @@ func TestPipeline_View(t *testing.T) {
 			cmd:     test.Command(s.URL, view, CommandView.Flags),
 			args:    []string{"--org", "Org-1", "--repo", "Repo-1"},
 		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, view, CommandView.Flags),
+			args:    []string{"--env", "--step", "test", "--path", "../../action/pipeline/testdata/env", "--event", "push", "--branch", "main"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, view, CommandView.Flags),
+			args:    []string{"--env", "--path", "../../action/pipeline/testdata/env"},
+		},
 		{
 			failure: true,
 			cmd:     test.Command(s.URL, view, nil),
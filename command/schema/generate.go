@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/schema"
+	"github.com/go-vela/cli/internal"
+)
+
+// CommandGenerate defines the command for producing the pipeline schema.
+var CommandGenerate = &cli.Command{
+	Name:        "schema",
+	Description: "Use this command to generate the JSON Schema for Vela pipelines.",
+	Usage:       "Generate the JSON Schema for the Vela YAML pipeline format",
+	Action:      generate,
+	Flags: []cli.Flag{
+
+		// Schema Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_SCHEMA_FILE", "SCHEMA_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file to write the schema to instead of stdout",
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "SCHEMA_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the schema in json or yaml",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Generate the JSON Schema for Vela pipelines.
+    $ {{.FullName}}
+  2. Generate the JSON Schema for Vela pipelines into a file for an editor.
+    $ {{.FullName}} --file .vela/schema.json
+  3. Generate the JSON Schema for Vela pipelines in YAML format.
+    $ {{.FullName}} --output yaml
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/schema/generate/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to produce the schema.
+func generate(_ context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the schema configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/schema?tab=doc#Config
+	s := &schema.Config{
+		Action: internal.ActionGenerate,
+		File:   c.String("file"),
+		Output: c.String(internal.FlagOutput),
+	}
+
+	// validate schema configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/schema?tab=doc#Config.Validate
+	err = s.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the generate call for the schema configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/schema?tab=doc#Config.Generate
+	return s.Generate()
+}
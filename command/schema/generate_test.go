@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestSchema_Generate(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, generate, CommandGenerate.Flags),
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, generate, CommandGenerate.Flags),
+			args:    []string{"--file", filepath.Join(t.TempDir(), "vela.schema.json")},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, generate, CommandGenerate.Flags),
+			args:    []string{"--output", "yaml"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, generate, CommandGenerate.Flags),
+			args:    []string{"--output", "spew"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("generate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("generate returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/template"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandGetCache defines the command for capturing a list of the templates in the template cache.
+var CommandGetCache = &cli.Command{
+	Name:        "template-cache",
+	Description: "Use this command to get a list of the remote templates cached when compiling pipelines locally.",
+	Usage:       "Display a list of cached remote templates",
+	Action:      getCache,
+	Flags: []cli.Flag{
+
+		// Cache Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_CACHE", "PIPELINE_TEMPLATE_CACHE"),
+			Name:    "template-cache",
+			Usage:   "provide the directory of the cache for remote templates",
+			Value:   internal.DefaultTemplateCache(),
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "TEMPLATE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in json, spew, wide or yaml",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Get the cached remote templates.
+    $ {{.FullName}}
+  2. Get the cached remote templates with wide view output.
+    $ {{.FullName}} --output wide
+  3. Get the cached remote templates with json output.
+    $ {{.FullName}} --output json
+  4. Get the cached remote templates from a different template cache.
+    $ {{.FullName}} --template-cache /path/to/cache
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/template/cache/get/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to capture a list
+// of the templates in the template cache.
+func getCache(_ context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config
+	t := &template.Config{
+		Action:        internal.ActionGet,
+		TemplateCache: c.String("template-cache"),
+		Output:        c.String(internal.FlagOutput),
+		Color:         output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.Validate
+	err = t.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the get cache call for the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.GetCache
+	return t.GetCache()
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestTemplate_GetCache(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	dir := t.TempDir()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, getCache, CommandGetCache.Flags),
+			args:    []string{"--template-cache", dir},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, getCache, CommandGetCache.Flags),
+			args:    []string{"--template-cache", dir, "--output", "json"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, getCache, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("getCache should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("getCache returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/template"
+	"github.com/go-vela/cli/internal"
+)
+
+// CommandLock defines the command for locking the templates of a pipeline.
+var CommandLock = &cli.Command{
+	Name:        "templates",
+	Description: "Use this command to pin the remote templates of a pipeline to commits in a lockfile.",
+	Usage:       "Pin the remote templates of a pipeline to commits",
+	Action:      lock,
+	Flags: []cli.Flag{
+
+		// Pipeline Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "PIPELINE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file name for the pipeline",
+			Value:   ".vela.yml",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_PATH", "PIPELINE_PATH"),
+			Name:    "path",
+			Aliases: []string{"p"},
+			Usage:   "provide the path to the file for the pipeline",
+		},
+
+		// Compiler Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_TOKEN", "COMPILER_GITHUB_TOKEN"),
+			Name:    internal.FlagCompilerGitHubToken,
+			Aliases: []string{"ct"},
+			Usage:   "github compiler token",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_COMPILER_GITHUB_URL", "COMPILER_GITHUB_URL"),
+			Name:    internal.FlagCompilerGitHubURL,
+			Aliases: []string{"cgu"},
+			Usage:   "github url, used by compiler, for pulling registry templates",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Pin the remote templates of the pipeline in the current directory.
+    $ {{.FullName}}
+  2. Pin the remote templates of a pipeline in a different path.
+    $ {{.FullName}} --path /path/to/repo
+  3. Pin the remote templates of a pipeline with a different file name.
+    $ {{.FullName}} --file .vela.yaml
+  4. Pin the remote templates of a pipeline from a GitHub Enterprise instance.
+    $ {{.FullName}} --compiler.github.url https://git.example.com --compiler.github.token <token>
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/template/lock/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to lock the templates of a pipeline.
+func lock(ctx context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config
+	t := &template.Config{
+		Action:      internal.ActionLock,
+		File:        c.String("file"),
+		Path:        c.String("path"),
+		GitHubURL:   c.String(internal.FlagCompilerGitHubURL),
+		GitHubToken: c.String(internal.FlagCompilerGitHubToken),
+	}
+
+	// validate template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.Validate
+	err = t.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the lock call for the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.Lock
+	return t.Lock(ctx)
+}
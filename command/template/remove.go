@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/template"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandRemoveCache defines the command for deleting templates from the template cache.
+var CommandRemoveCache = &cli.Command{
+	Name:        "template-cache",
+	Description: "Use this command to remove remote templates cached when compiling pipelines locally.",
+	Usage:       "Remove the provided cached remote templates",
+	Action:      removeCache,
+	Flags: []cli.Flag{
+
+		// Cache Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_CACHE", "PIPELINE_TEMPLATE_CACHE"),
+			Name:    "template-cache",
+			Usage:   "provide the directory of the cache for remote templates",
+			Value:   internal.DefaultTemplateCache(),
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_SOURCE", "TEMPLATE_SOURCE"),
+			Name:    "source",
+			Aliases: []string{"s"},
+			Usage:   "provide the source of the cached template",
+		},
+		&cli.BoolFlag{
+			Sources: cli.EnvVars("VELA_ALL", "TEMPLATE_ALL"),
+			Name:    "all",
+			Aliases: []string{"a"},
+			Usage:   "remove all templates from the template cache",
+			Value:   false,
+		},
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "TEMPLATE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in json, spew or yaml",
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Remove a cached remote template.
+    $ {{.FullName}} --source github.com/octocat/templates/docker.yml@v1
+  2. Remove all cached remote templates.
+    $ {{.FullName}} --all
+  3. Remove a cached remote template with json output.
+    $ {{.FullName}} --source github.com/octocat/templates/docker.yml@v1 --output json
+  4. Remove all cached remote templates from a different template cache.
+    $ {{.FullName}} --template-cache /path/to/cache --all
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/template/cache/remove/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to remove templates
+// from the template cache.
+func removeCache(_ context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// create the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config
+	t := &template.Config{
+		Action:        internal.ActionRemove,
+		TemplateCache: c.String("template-cache"),
+		Source:        c.String("source"),
+		All:           c.Bool("all"),
+		Output:        c.String(internal.FlagOutput),
+		Color:         output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.Validate
+	err = t.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the remove cache call for the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.RemoveCache
+	return t.RemoveCache()
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestTemplate_RemoveCache(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup types
+	dir := t.TempDir()
+
+	_, err := internal.CacheTemplate(dir, "github.com/octocat/templates/docker.yml@v1", []byte("steps: []\n"))
+	if err != nil {
+		t.Fatalf("unable to cache template: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, removeCache, CommandRemoveCache.Flags),
+			args:    []string{"--template-cache", dir, "--source", "github.com/octocat/templates/docker.yml@v1"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, removeCache, CommandRemoveCache.Flags),
+			args:    []string{"--template-cache", dir, "--all"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, removeCache, CommandRemoveCache.Flags),
+			args:    []string{"--template-cache", dir},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, removeCache, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("removeCache should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("removeCache returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/action"
+	"github.com/go-vela/cli/action/template"
+	"github.com/go-vela/cli/internal"
+	"github.com/go-vela/cli/internal/output"
+)
+
+// CommandRender defines the command for rendering a template.
+var CommandRender = &cli.Command{
+	Name:        "template",
+	Description: "Use this command to render a template in isolation.",
+	Usage:       "Render the steps of a Go or Starlark template with a set of variables",
+	Action:      render,
+	Flags: []cli.Flag{
+
+		// Output Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_OUTPUT", "TEMPLATE_OUTPUT"),
+			Name:    internal.FlagOutput,
+			Aliases: []string{"op"},
+			Usage:   "format the output in json or yaml",
+		},
+
+		// Template Flags
+
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_FILE", "TEMPLATE_FILE"),
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "provide the file for the template",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_TYPE", "TEMPLATE_TYPE"),
+			Name:    "type",
+			Aliases: []string{"t"},
+			Usage:   "type of template to render (go or starlark), detected from the file extension by default",
+		},
+		&cli.StringFlag{
+			Sources: cli.EnvVars("VELA_VARS", "TEMPLATE_VARS"),
+			Name:    "vars",
+			Usage:   "provide the file with the variables for the template",
+		},
+		&cli.StringSliceFlag{
+			Sources: cli.EnvVars("VELA_TEMPLATE_FILE", "TEMPLATE_TEMPLATE_FILE"),
+			Name:    "template-file",
+			Usage:   "enables using a local template file for nested templates",
+		},
+
+		// Compiler Flags
+
+		&cli.IntFlag{
+			Sources: cli.EnvVars("VELA_MAX_TEMPLATE_DEPTH", "MAX_TEMPLATE_DEPTH"),
+			Name:    "max-template-depth",
+			Usage:   "set the maximum depth for nested templates",
+			Value:   3,
+		},
+		&cli.Int64Flag{
+			Sources: cli.EnvVars("VELA_COMPILER_STARLARK_EXEC_LIMIT", "COMPILER_STARLARK_EXEC_LIMIT"),
+			Name:    "compiler-starlark-exec-limit",
+			Aliases: []string{"starlark-exec-limit", "sel"},
+			Usage:   "set the starlark execution step limit for rendering starlark templates",
+			Value:   7500,
+		},
+	},
+	CustomHelpTemplate: fmt.Sprintf(`%s
+EXAMPLES:
+  1. Render a Go template.
+    $ {{.FullName}} template.yml
+  2. Render a Go template with variables.
+    $ {{.FullName}} template.yml --vars vars.yml
+  3. Render a Starlark template with variables.
+    $ {{.FullName}} template.star --type starlark --vars vars.yml
+  4. Render a Starlark template with a lower execution step limit.
+    $ {{.FullName}} template.star --vars vars.yml --starlark-exec-limit 1000
+  5. Render a template using a local file for a nested template.
+    $ {{.FullName}} template.yml --template-file <template_name>:<path_to_template>
+  6. Render a template with json output.
+    $ {{.FullName}} template.yml --output json
+
+DOCUMENTATION:
+
+  https://go-vela.github.io/docs/reference/cli/template/render/
+`, cli.CommandHelpTemplate),
+}
+
+// helper function to capture the provided input
+// and create the object used to render a template.
+func render(_ context.Context, c *cli.Command) error {
+	// load variables from the config file
+	err := action.Load(c)
+	if err != nil {
+		return err
+	}
+
+	// capture the template file from the arguments
+	err = internal.ProcessArgs(c, "file", "string")
+	if err != nil {
+		return err
+	}
+
+	// create the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config
+	t := &template.Config{
+		Action:            internal.ActionRender,
+		File:              c.String("file"),
+		Type:              c.String("type"),
+		VarsFile:          c.String("vars"),
+		TemplateFiles:     c.StringSlice("template-file"),
+		TemplateDepth:     min(c.Int("max-template-depth"), 10),
+		StarlarkExecLimit: c.Int64("compiler-starlark-exec-limit"),
+		Output:            c.String(internal.FlagOutput),
+		Color:             output.ColorOptionsFromCLIContext(c),
+	}
+
+	// validate template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.Validate
+	err = t.Validate()
+	if err != nil {
+		return err
+	}
+
+	// execute the render call for the template configuration
+	//
+	// https://pkg.go.dev/github.com/go-vela/cli/action/template?tab=doc#Config.Render
+	return t.Render()
+}
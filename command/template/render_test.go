@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/go-vela/cli/test"
+	"github.com/go-vela/server/mock/server"
+)
+
+func TestTemplate_Render(t *testing.T) {
+	// setup test server
+	s := httptest.NewServer(server.FakeHandler())
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		cmd     *cli.Command
+		args    []string
+	}{
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, render, CommandRender.Flags),
+			args:    []string{"testdata/go.yml", "--vars", "testdata/vars.yml"},
+		},
+		{
+			failure: false,
+			cmd:     test.Command(s.URL, render, CommandRender.Flags),
+			args:    []string{"--file", "testdata/go.yml", "--vars", "testdata/vars.yml", "--output", "json"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, render, CommandRender.Flags),
+			args:    []string{"testdata/go.yml", "--type", "jsonnet"},
+		},
+		{
+			failure: true,
+			cmd:     test.Command(s.URL, render, nil),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.cmd.Run(t.Context(), append([]string{"test"}, test.args...))
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("render should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("render returned err: %v", err)
+		}
+	}
+}
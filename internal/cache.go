@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// list of labels applied to cache volumes.
+const (
+	// CacheLabelName defines the label for the name of a cache volume.
+	CacheLabelName = "io.vela.cache.name"
+
+	// CacheLabelOrg defines the label for the org a cache volume is scoped to.
+	CacheLabelOrg = "io.vela.cache.org"
+
+	// CacheLabelRepo defines the label for the repo a cache volume is scoped to.
+	CacheLabelRepo = "io.vela.cache.repo"
+)
+
+// cacheName matches the supported names for a cache.
+var cacheName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Cache represents a named Docker volume used to persist
+// files between local executions of a pipeline.
+type Cache struct {
+	Name       string `json:"name" yaml:"name"`
+	Org        string `json:"org" yaml:"org"`
+	Repo       string `json:"repo" yaml:"repo"`
+	Volume     string `json:"volume" yaml:"volume"`
+	Mountpoint string `json:"mountpoint" yaml:"mountpoint"`
+	Created    string `json:"created" yaml:"created"`
+}
+
+// ParseCache splits a cache provided in the form <name>:<container-path>.
+func ParseCache(cache string) (string, string, error) {
+	name, target, ok := strings.Cut(cache, ":")
+	if !ok || len(name) == 0 || len(target) == 0 {
+		return "", "", fmt.Errorf("invalid format for cache: %s (valid format: <name>:<container-path>)", cache)
+	}
+
+	if !cacheName.MatchString(name) {
+		return "", "", fmt.Errorf("invalid name for cache: %s (must only contain letters, numbers, '_', '.' or '-')", name)
+	}
+
+	if !path.IsAbs(target) {
+		return "", "", fmt.Errorf("invalid container path for cache %s: %s must be absolute", name, target)
+	}
+
+	return name, target, nil
+}
+
+// CacheVolume returns the name of the Docker volume
+// for the cache scoped to the provided org and repo.
+func CacheVolume(org, repo, name string) string {
+	return fmt.Sprintf("vela-cache_%s_%s_%s", escapeVolume(org), escapeVolume(repo), escapeVolume(name))
+}
+
+// escapeVolume escapes a part of the name of a cache volume, replacing
+// '_', which separates the parts, '.', which starts an escape, and the
+// characters not supported in a volume name with '.' and their hex code,
+// so caches of different repos never share a volume.
+func escapeVolume(s string) string {
+	var b strings.Builder
+
+	for _, c := range []byte(s) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, ".%02x", c)
+		}
+	}
+
+	return b.String()
+}
+
+// CreateCache creates the Docker volume for the cache scoped to the
+// provided org and repo, reusing the volume if it already exists.
+func CreateCache(ctx context.Context, org, repo, name string) (*Cache, error) {
+	volume := CacheVolume(org, repo, name)
+
+	// creating a volume that already exists is a no-op
+	_, err := Docker(ctx, "volume", "create",
+		"--label", fmt.Sprintf("%s=%s", CacheLabelName, name),
+		"--label", fmt.Sprintf("%s=%s", CacheLabelOrg, org),
+		"--label", fmt.Sprintf("%s=%s", CacheLabelRepo, repo),
+		volume,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cache %s: %w", name, err)
+	}
+
+	caches, err := inspectCaches(ctx, volume)
+	if err != nil {
+		return nil, err
+	}
+
+	return caches[0], nil
+}
+
+// ListCaches returns the caches scoped to the provided org and
+// repo, returning all caches when the org or repo is empty.
+func ListCaches(ctx context.Context, org, repo string) ([]*Cache, error) {
+	args := []string{"volume", "ls", "--quiet", "--filter", "label=" + CacheLabelName}
+
+	if len(org) > 0 {
+		args = append(args, "--filter", fmt.Sprintf("label=%s=%s", CacheLabelOrg, org))
+	}
+
+	if len(repo) > 0 {
+		args = append(args, "--filter", fmt.Sprintf("label=%s=%s", CacheLabelRepo, repo))
+	}
+
+	out, err := Docker(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list caches: %w", err)
+	}
+
+	volumes := strings.Fields(out)
+	if len(volumes) == 0 {
+		return []*Cache{}, nil
+	}
+
+	caches, err := inspectCaches(ctx, volumes...)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(caches, func(a, b *Cache) int {
+		return strings.Compare(a.Volume, b.Volume)
+	})
+
+	return caches, nil
+}
+
+// RemoveCache removes the Docker volume for the cache
+// scoped to the provided org and repo.
+func RemoveCache(ctx context.Context, org, repo, name string) error {
+	_, err := Docker(ctx, "volume", "rm", CacheVolume(org, repo, name))
+	if err != nil {
+		return fmt.Errorf("unable to remove cache %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// inspectCaches captures the caches for the provided Docker volumes.
+func inspectCaches(ctx context.Context, volumes ...string) ([]*Cache, error) {
+	out, err := Docker(ctx, append([]string{"volume", "inspect"}, volumes...)...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to inspect caches: %w", err)
+	}
+
+	var inspected []struct {
+		Name       string
+		Mountpoint string
+		CreatedAt  string
+		Labels     map[string]string
+	}
+
+	err = json.Unmarshal([]byte(out), &inspected)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse caches: %w", err)
+	}
+
+	if len(inspected) == 0 {
+		return nil, fmt.Errorf("no caches found for volumes %s", strings.Join(volumes, ", "))
+	}
+
+	caches := []*Cache{}
+
+	for _, v := range inspected {
+		caches = append(caches, &Cache{
+			Name:       v.Labels[CacheLabelName],
+			Org:        v.Labels[CacheLabelOrg],
+			Repo:       v.Labels[CacheLabelRepo],
+			Volume:     v.Name,
+			Mountpoint: v.Mountpoint,
+			Created:    v.CreatedAt,
+		})
+	}
+
+	return caches, nil
+}
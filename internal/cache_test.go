@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testVolumes is the output of inspecting the cache volumes used in tests.
+const testVolumes = `[
+  {
+    "CreatedAt": "2024-01-01T00:00:00Z",
+    "Labels": {"io.vela.cache.name": "gomod", "io.vela.cache.org": "github", "io.vela.cache.repo": "octocat"},
+    "Mountpoint": "/var/lib/docker/volumes/vela-cache_github_octocat_gomod/_data",
+    "Name": "vela-cache_github_octocat_gomod"
+  },
+  {
+    "CreatedAt": "2024-01-02T00:00:00Z",
+    "Labels": {"io.vela.cache.name": "npm", "io.vela.cache.org": "github", "io.vela.cache.repo": "hello-world"},
+    "Mountpoint": "/var/lib/docker/volumes/vela-cache_github_hello-world_npm/_data",
+    "Name": "vela-cache_github_hello-world_npm"
+  }
+]`
+
+// testDocker replaces the docker CLI with a fake recording the
+// commands run and returning the output for each subcommand.
+func testDocker(t *testing.T, outputs map[string]string) *[][]string {
+	t.Helper()
+
+	docker := Docker
+
+	t.Cleanup(func() { Docker = docker })
+
+	calls := [][]string{}
+
+	Docker = func(_ context.Context, args ...string) (string, error) {
+		calls = append(calls, args)
+
+		out, ok := outputs[strings.Join(args[:2], " ")]
+		if !ok {
+			return "", errors.New("unexpected docker command")
+		}
+
+		return out, nil
+	}
+
+	return &calls
+}
+
+func TestInternal_ParseCache(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		cache   string
+		name    string
+		target  string
+	}{
+		{
+			failure: false,
+			cache:   "gomod:/root/go/pkg/mod",
+			name:    "gomod",
+			target:  "/root/go/pkg/mod",
+		},
+		{
+			failure: false,
+			cache:   "maven.repo:/root/.m2",
+			name:    "maven.repo",
+			target:  "/root/.m2",
+		},
+		{
+			failure: true,
+			cache:   "gomod",
+		},
+		{
+			failure: true,
+			cache:   ":/root/go/pkg/mod",
+		},
+		{
+			failure: true,
+			cache:   "go mod:/root/go/pkg/mod",
+		},
+		{
+			failure: true,
+			cache:   "gomod:go/pkg/mod",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		name, target, err := ParseCache(test.cache)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("ParseCache for %s should have returned err", test.cache)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseCache for %s returned err: %v", test.cache, err)
+		}
+
+		if name != test.name || target != test.target {
+			t.Errorf("ParseCache for %s is %s:%s, want %s:%s", test.cache, name, target, test.name, test.target)
+		}
+	}
+}
+
+func TestInternal_CacheVolume(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		org  string
+		repo string
+		name string
+		want string
+	}{
+		{
+			org:  "github",
+			repo: "octocat",
+			name: "gomod",
+			want: "vela-cache_github_octocat_gomod",
+		},
+		{
+			org:  "github",
+			repo: "octo/cat",
+			name: "maven.repo",
+			want: "vela-cache_github_octo.2fcat_maven.2erepo",
+		},
+		{
+			org:  "a_b",
+			repo: "c",
+			name: "gomod",
+			want: "vela-cache_a.5fb_c_gomod",
+		},
+		{
+			org:  "a",
+			repo: "b_c",
+			name: "gomod",
+			want: "vela-cache_a_b.5fc_gomod",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := CacheVolume(test.org, test.repo, test.name)
+
+		if got != test.want {
+			t.Errorf("CacheVolume for %s/%s is %s, want %s", test.org, test.repo, got, test.want)
+		}
+	}
+}
+
+func TestInternal_CreateCache(t *testing.T) {
+	// setup types
+	calls := testDocker(t, map[string]string{
+		"volume create":  "vela-cache_github_octocat_gomod",
+		"volume inspect": testVolumes,
+	})
+
+	// run test
+	got, err := CreateCache(context.Background(), "github", "octocat", "gomod")
+	if err != nil {
+		t.Errorf("CreateCache returned err: %v", err)
+	}
+
+	want := []string{
+		"volume", "create",
+		"--label", "io.vela.cache.name=gomod",
+		"--label", "io.vela.cache.org=github",
+		"--label", "io.vela.cache.repo=octocat",
+		"vela-cache_github_octocat_gomod",
+	}
+
+	if !reflect.DeepEqual((*calls)[0], want) {
+		t.Errorf("CreateCache ran docker %v, want %v", (*calls)[0], want)
+	}
+
+	if got.Name != "gomod" || got.Mountpoint != "/var/lib/docker/volumes/vela-cache_github_octocat_gomod/_data" {
+		t.Errorf("CreateCache is %+v", got)
+	}
+}
+
+func TestInternal_ListCaches(t *testing.T) {
+	// setup types
+	calls := testDocker(t, map[string]string{
+		"volume ls":      "vela-cache_github_octocat_gomod\nvela-cache_github_hello-world_npm",
+		"volume inspect": testVolumes,
+	})
+
+	// run test
+	got, err := ListCaches(context.Background(), "github", "")
+	if err != nil {
+		t.Errorf("ListCaches returned err: %v", err)
+	}
+
+	want := []string{"volume", "ls", "--quiet", "--filter", "label=io.vela.cache.name", "--filter", "label=io.vela.cache.org=github"}
+
+	if !reflect.DeepEqual((*calls)[0], want) {
+		t.Errorf("ListCaches ran docker %v, want %v", (*calls)[0], want)
+	}
+
+	if len(got) != 2 || got[0].Repo != "hello-world" || got[1].Repo != "octocat" {
+		t.Errorf("ListCaches is %+v", got)
+	}
+}
+
+func TestInternal_ListCaches_Empty(t *testing.T) {
+	// setup types
+	calls := testDocker(t, map[string]string{
+		"volume ls": "",
+	})
+
+	// run test
+	got, err := ListCaches(context.Background(), "github", "octocat")
+	if err != nil {
+		t.Errorf("ListCaches returned err: %v", err)
+	}
+
+	if len(got) != 0 || len(*calls) != 1 {
+		t.Errorf("ListCaches is %+v", got)
+	}
+}
+
+func TestInternal_RemoveCache(t *testing.T) {
+	// setup types
+	calls := testDocker(t, map[string]string{
+		"volume rm": "vela-cache_github_octocat_gomod",
+	})
+
+	// run test
+	err := RemoveCache(context.Background(), "github", "octocat", "gomod")
+	if err != nil {
+		t.Errorf("RemoveCache returned err: %v", err)
+	}
+
+	want := []string{"volume", "rm", "vela-cache_github_octocat_gomod"}
+
+	if !reflect.DeepEqual((*calls)[0], want) {
+		t.Errorf("RemoveCache ran docker %v, want %v", (*calls)[0], want)
+	}
+
+	Docker = func(_ context.Context, _ ...string) (string, error) {
+		return "", errors.New("no such volume")
+	}
+
+	err = RemoveCache(context.Background(), "github", "octocat", "gomod")
+	if err == nil {
+		t.Errorf("RemoveCache should have returned err")
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines
+// surrounding the changes in a unified diff.
+const diffContext = 3
+
+// diffLine represents a line of a unified diff
+// and its position in the provided contents.
+type diffLine struct {
+	kind byte
+	text string
+	from int
+	to   int
+}
+
+// Diff returns the unified diff for the lines of the provided
+// contents, or an empty string when the contents are equal.
+func Diff(from, to, fromName, toName string) string {
+	if from == to {
+		return ""
+	}
+
+	a := diffSplit(from)
+	b := diffSplit(to)
+
+	// compute the longest common subsequence of the lines
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := []diffLine{}
+
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i], i, j})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for k := 0; k < len(lines); {
+		if lines[k].kind == ' ' {
+			k++
+
+			continue
+		}
+
+		// capture the changes that are close enough to share a hunk
+		start := max(k-diffContext, 0)
+		end := k
+
+		for l := k; l < len(lines); l++ {
+			if lines[l].kind != ' ' {
+				end = l
+			} else if l-end > 2*diffContext {
+				break
+			}
+		}
+
+		stop := min(end+diffContext+1, len(lines))
+
+		diffHunk(&sb, lines[start:stop])
+
+		k = stop
+	}
+
+	return sb.String()
+}
+
+// diffHunk writes a hunk of a unified diff for the provided lines.
+func diffHunk(sb *strings.Builder, lines []diffLine) {
+	fromCount, toCount := 0, 0
+
+	for _, line := range lines {
+		if line.kind != '+' {
+			fromCount++
+		}
+
+		if line.kind != '-' {
+			toCount++
+		}
+	}
+
+	fromStart, toStart := lines[0].from, lines[0].to
+
+	if fromCount > 0 {
+		fromStart++
+	}
+
+	if toCount > 0 {
+		toStart++
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", fromStart, fromCount, toStart, toCount)
+
+	for _, line := range lines {
+		fmt.Fprintf(sb, "%c%s\n", line.kind, line.text)
+	}
+}
+
+// diffSplit splits the provided contents into lines.
+func diffSplit(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DockerBinary is the path to the docker CLI used for operations the
+// Vela runtime does not support. It is resolved when the package is
+// loaded so it remains available after the environment is cleared
+// for executing a pipeline locally.
+var DockerBinary = lookupDocker()
+
+// lookupDocker returns the full path to the docker
+// CLI, falling back to the binary name if not found.
+func lookupDocker() string {
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return "docker"
+	}
+
+	return path
+}
+
+// Docker runs the docker CLI with the provided arguments and returns
+// the trimmed standard output. It is a variable to enable testing.
+var Docker = func(ctx context.Context, args ...string) (string, error) {
+	logrus.Tracef("running docker %s", strings.Join(args, " "))
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, DockerBinary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) == 0 {
+			return "", fmt.Errorf("docker %s: %w", args[0], err)
+		}
+
+		return "", fmt.Errorf("docker %s: %s", args[0], msg)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Shell attaches interactive commands to running containers.
+type Shell interface {
+	// Exec runs the command in the container with the provided
+	// ID attached to the provided input and outputs.
+	Exec(ctx context.Context, id string, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+// DockerShell is the Shell using the docker CLI,
+// since the Vela runtime does not support exec.
+type DockerShell struct{}
+
+// Exec runs the command in the container with `docker exec -it`.
+func (DockerShell) Exec(ctx context.Context, id string, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	args := append([]string{"exec", "-it", id}, cmd...)
+
+	logrus.Tracef("running docker %s", strings.Join(args, " "))
+
+	c := exec.CommandContext(ctx, DockerBinary, args...)
+	c.Stdin = stdin
+	c.Stdout = stdout
+	c.Stderr = stderr
+
+	return c.Run()
+}
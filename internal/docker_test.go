@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestInternal_Docker(t *testing.T) {
+	// setup types
+	binary := DockerBinary
+
+	t.Cleanup(func() { DockerBinary = binary })
+
+	// use echo to capture the arguments passed to the binary
+	DockerBinary = "echo"
+
+	// run test
+	got, err := Docker(context.Background(), "volume", "ls")
+	if err != nil {
+		t.Errorf("Docker returned err: %v", err)
+	}
+
+	if got != "volume ls" {
+		t.Errorf("Docker is %q, want %q", got, "volume ls")
+	}
+
+	DockerBinary = "false"
+
+	_, err = Docker(context.Background(), "volume", "ls")
+	if err == nil {
+		t.Errorf("Docker should have returned err")
+	}
+}
+
+func TestInternal_DockerShell_Exec(t *testing.T) {
+	// setup types
+	binary := DockerBinary
+
+	t.Cleanup(func() { DockerBinary = binary })
+
+	// use echo to capture the arguments passed to the binary
+	DockerBinary = "echo"
+
+	var stdout, stderr bytes.Buffer
+
+	// run test
+	err := DockerShell{}.Exec(context.Background(), "step_github_octocat_1_test", []string{"/bin/sh"}, strings.NewReader(""), &stdout, &stderr)
+	if err != nil {
+		t.Errorf("Exec returned err: %v", err)
+	}
+
+	want := "exec -it step_github_octocat_1_test /bin/sh\n"
+
+	if stdout.String() != want {
+		t.Errorf("Exec is %q, want %q", stdout.String(), want)
+	}
+
+	DockerBinary = "false"
+
+	err = DockerShell{}.Exec(context.Background(), "step_github_octocat_1_test", []string{"/bin/sh"}, strings.NewReader(""), &stdout, &stderr)
+	if err == nil {
+		t.Errorf("Exec should have returned err")
+	}
+}
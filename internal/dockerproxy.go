@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultDockerHost defines the address of the Docker
+// daemon used when DOCKER_HOST is not set.
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+// dockerCreatePattern matches the path of the Docker API request creating a container.
+var dockerCreatePattern = regexp.MustCompile(`^(/v[0-9.]+)?/containers/create$`)
+
+// DockerCreateOptions represents the options the Vela runtime
+// does not support that are added to every container created
+// through a DockerProxy.
+type DockerCreateOptions struct {
+	// Volumes are the named volumes mounted into the
+	// container in the form <volume>:<container-path>.
+	Volumes []string
+	// NanoCPUs is the CPU quota of the container in units of 1e-9 CPUs.
+	NanoCPUs int64
+	// Memory is the memory limit of the container in bytes.
+	Memory int64
+}
+
+// DockerProxy represents a proxy to the Docker daemon
+// that adds options to the containers created through it.
+type DockerProxy struct {
+	// Host is the address of the proxy in the format of DOCKER_HOST.
+	Host string
+
+	dir    string
+	server *http.Server
+}
+
+// NewDockerProxy starts a proxy to the Docker daemon from DOCKER_HOST
+// that adds the provided options to every container created through it.
+func NewDockerProxy(opts *DockerCreateOptions) (*DockerProxy, error) {
+	network, address, err := dockerDaemon()
+	if err != nil {
+		return nil, err
+	}
+
+	// create the socket of the proxy in a directory only the user can access
+	dir, err := os.MkdirTemp("", "vela-docker-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create docker proxy: %w", err)
+	}
+
+	socket := filepath.Join(dir, "docker.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+
+		return nil, fmt.Errorf("unable to create docker proxy: %w", err)
+	}
+
+	dialer := new(net.Dialer)
+
+	// https://pkg.go.dev/net/http/httputil#ReverseProxy
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.URL.Scheme = "http"
+			r.Out.URL.Host = "docker"
+		},
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+		},
+		// stream logs from the daemon as they are written
+		FlushInterval: -1,
+	}
+
+	p := &DockerProxy{
+		Host: "unix://" + socket,
+		dir:  dir,
+		server: &http.Server{
+			ReadHeaderTimeout: 30 * time.Second,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost && dockerCreatePattern.MatchString(r.URL.Path) {
+					err := opts.apply(r)
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+
+						return
+					}
+				}
+
+				proxy.ServeHTTP(w, r)
+			}),
+		},
+	}
+
+	logrus.Debugf("starting docker proxy on %s to %s", p.Host, address)
+
+	go func() {
+		err := p.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("docker proxy stopped: %v", err)
+		}
+	}()
+
+	return p, nil
+}
+
+// Close stops the proxy and removes its socket.
+func (p *DockerProxy) Close() error {
+	err := p.server.Close()
+
+	return errors.Join(err, os.RemoveAll(p.dir))
+}
+
+// apply adds the options to the body of the
+// request creating a container.
+func (o *DockerCreateOptions) apply(r *http.Request) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read container: %w", err)
+	}
+
+	config := make(map[string]json.RawMessage)
+
+	err = json.Unmarshal(body, &config)
+	if err != nil {
+		return fmt.Errorf("unable to parse container: %w", err)
+	}
+
+	host := make(map[string]any)
+
+	if raw, ok := config["HostConfig"]; ok {
+		// keep numbers as is to avoid losing precision
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+
+		err = decoder.Decode(&host)
+		if err != nil {
+			return fmt.Errorf("unable to parse container: %w", err)
+		}
+	}
+
+	mounts, _ := host["Mounts"].([]any)
+
+	for _, volume := range o.Volumes {
+		source, target, _ := strings.Cut(volume, ":")
+
+		mounts = append(mounts, map[string]any{
+			"Type":   "volume",
+			"Source": source,
+			"Target": target,
+		})
+	}
+
+	host["Mounts"] = mounts
+
+	if o.NanoCPUs > 0 {
+		host["NanoCpus"] = o.NanoCPUs
+	}
+
+	// limit the memory including swap to the same amount
+	if o.Memory > 0 {
+		host["Memory"] = o.Memory
+		host["MemorySwap"] = o.Memory
+	}
+
+	config["HostConfig"], err = json.Marshal(host)
+	if err != nil {
+		return fmt.Errorf("unable to create container: %w", err)
+	}
+
+	body, err = json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("unable to create container: %w", err)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return nil
+}
+
+// dockerDaemon returns the network and address of the Docker daemon from DOCKER_HOST.
+func dockerDaemon() (string, string, error) {
+	host := os.Getenv("DOCKER_HOST")
+	if len(host) == 0 {
+		host = defaultDockerHost
+	}
+
+	proto, address, _ := strings.Cut(host, "://")
+
+	switch proto {
+	case "unix":
+		return "unix", address, nil
+	case "tcp":
+		// the proxy does not support connecting to the daemon with TLS
+		if len(os.Getenv("DOCKER_TLS_VERIFY")) == 0 && len(os.Getenv("DOCKER_CERT_PATH")) == 0 {
+			return "tcp", address, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unsupported docker host %s for caches and resource limits (supported: unix:// or tcp:// without TLS)", host)
+}
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testDockerRequest represents a POST request received by the fake Docker daemon.
+type testDockerRequest struct {
+	path string
+	body map[string]any
+}
+
+// testDockerDaemon starts a fake Docker daemon on a unix socket set as
+// DOCKER_HOST, returning the POST requests it receives. Requests to
+// attach to a container are hijacked and echo the written input.
+func testDockerDaemon(t *testing.T) *[]testDockerRequest {
+	t.Helper()
+
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "docker.sock"))
+	if err != nil {
+		t.Fatalf("unable to create docker daemon: %v", err)
+	}
+
+	received := []testDockerRequest{}
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/attach") {
+			conn, rw, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				return
+			}
+
+			defer conn.Close()
+
+			_, _ = rw.WriteString("HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+			_ = rw.Flush()
+
+			_, _ = io.Copy(conn, rw)
+
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			body := make(map[string]any)
+
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			received = append(received, testDockerRequest{path: r.URL.Path, body: body})
+		}
+
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+
+	s.Listener = listener
+	s.Start()
+
+	t.Cleanup(s.Close)
+	t.Setenv("DOCKER_HOST", "unix://"+listener.Addr().String())
+
+	return &received
+}
+
+// testDockerClient creates a client sending requests to the provided proxy.
+func testDockerClient(p *DockerProxy) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return new(net.Dialer).DialContext(ctx, "unix", strings.TrimPrefix(p.Host, "unix://"))
+			},
+		},
+	}
+}
+
+func TestInternal_DockerProxy(t *testing.T) {
+	// setup types
+	received := testDockerDaemon(t)
+
+	p, err := NewDockerProxy(&DockerCreateOptions{
+		Volumes:  []string{"vela-cache_github_octocat_gomod:/root/go/pkg/mod"},
+		NanoCPUs: 1500000000,
+		Memory:   536870912,
+	})
+	if err != nil {
+		t.Fatalf("NewDockerProxy returned err: %v", err)
+	}
+
+	defer p.Close()
+
+	client := testDockerClient(p)
+
+	// run test
+	resp, err := client.Get("http://docker/v1.44/containers/json")
+	if err != nil {
+		t.Fatalf("unable to list containers through proxy: %v", err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != "/v1.44/containers/json" {
+		t.Errorf("proxy forwarded %s, want /v1.44/containers/json", body)
+	}
+
+	resp, err = client.Post(
+		"http://docker/v1.44/containers/create?name=step_github_octocat_1_test",
+		"application/json",
+		strings.NewReader(`{"Image":"golang:latest","HostConfig":{"Mounts":[{"Type":"bind","Source":"/tmp","Target":"/vela"}],"Privileged":false}}`),
+	)
+	if err != nil {
+		t.Fatalf("unable to create container through proxy: %v", err)
+	}
+
+	resp.Body.Close()
+
+	if len(*received) != 1 {
+		t.Fatalf("proxy created %d containers, want 1", len(*received))
+	}
+
+	got := (*received)[0].body
+
+	want := map[string]any{
+		"Image": "golang:latest",
+		"HostConfig": map[string]any{
+			"Mounts": []any{
+				map[string]any{"Type": "bind", "Source": "/tmp", "Target": "/vela"},
+				map[string]any{"Type": "volume", "Source": "vela-cache_github_octocat_gomod", "Target": "/root/go/pkg/mod"},
+			},
+			"Privileged": false,
+			"NanoCpus":   float64(1500000000),
+			"Memory":     float64(536870912),
+			"MemorySwap": float64(536870912),
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("proxy created container %v, want %v", got, want)
+	}
+
+	err = p.Close()
+	if err != nil {
+		t.Errorf("Close returned err: %v", err)
+	}
+
+	_, err = os.Stat(strings.TrimPrefix(p.Host, "unix://"))
+	if err == nil {
+		t.Errorf("Close should have removed the proxy socket")
+	}
+}
+
+func TestInternal_DockerProxy_Paths(t *testing.T) {
+	// setup types
+	received := testDockerDaemon(t)
+
+	p, err := NewDockerProxy(&DockerCreateOptions{Memory: 536870912})
+	if err != nil {
+		t.Fatalf("NewDockerProxy returned err: %v", err)
+	}
+
+	defer p.Close()
+
+	client := testDockerClient(p)
+
+	// setup tests
+	tests := []struct {
+		path    string
+		options bool
+	}{
+		{path: "/containers/create", options: true},
+		{path: "/v1.44/containers/create", options: true},
+		{path: "/v1.51/containers/create", options: true},
+		{path: "/v1.44/containers/step_github_octocat_1_test/start", options: false},
+		{path: "/v1.44/containers/create/extra", options: false},
+		{path: "/v1.44/networks/create", options: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		*received = nil
+
+		resp, err := client.Post("http://docker"+test.path, "application/json", strings.NewReader(`{"HostConfig":{}}`))
+		if err != nil {
+			t.Fatalf("unable to post %s through proxy: %v", test.path, err)
+		}
+
+		resp.Body.Close()
+
+		if len(*received) != 1 || (*received)[0].path != test.path {
+			t.Fatalf("proxy forwarded %v, want %s", *received, test.path)
+		}
+
+		host, _ := (*received)[0].body["HostConfig"].(map[string]any)
+
+		_, got := host["Memory"]
+
+		if got != test.options {
+			t.Errorf("proxy added options to %s is %v, want %v", test.path, got, test.options)
+		}
+	}
+}
+
+func TestInternal_DockerProxy_Hijack(t *testing.T) {
+	// setup types
+	testDockerDaemon(t)
+
+	p, err := NewDockerProxy(new(DockerCreateOptions))
+	if err != nil {
+		t.Fatalf("NewDockerProxy returned err: %v", err)
+	}
+
+	defer p.Close()
+
+	conn, err := net.Dial("unix", strings.TrimPrefix(p.Host, "unix://"))
+	if err != nil {
+		t.Fatalf("unable to connect to proxy: %v", err)
+	}
+
+	defer conn.Close()
+
+	// run test
+	_, err = fmt.Fprint(conn, "POST /v1.44/containers/step_github_octocat_1_test/attach?stream=1 HTTP/1.1\r\nHost: docker\r\nConnection: Upgrade\r\nUpgrade: tcp\r\nContent-Length: 0\r\n\r\n")
+	if err != nil {
+		t.Fatalf("unable to attach through proxy: %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("unable to read attach response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("proxy attach status is %d, want %d", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+
+	_, err = io.WriteString(conn, "ping\n")
+	if err != nil {
+		t.Fatalf("unable to write to hijacked connection: %v", err)
+	}
+
+	got, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("unable to read from hijacked connection: %v", err)
+	}
+
+	if got != "ping\n" {
+		t.Errorf("hijacked connection is %q, want %q", got, "ping\n")
+	}
+}
+
+func TestInternal_dockerDaemon(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		host    string
+		tls     string
+		network string
+		address string
+	}{
+		{
+			failure: false,
+			host:    "",
+			network: "unix",
+			address: "/var/run/docker.sock",
+		},
+		{
+			failure: false,
+			host:    "unix:///run/user/1000/docker.sock",
+			network: "unix",
+			address: "/run/user/1000/docker.sock",
+		},
+		{
+			failure: false,
+			host:    "tcp://127.0.0.1:2375",
+			network: "tcp",
+			address: "127.0.0.1:2375",
+		},
+		{
+			failure: true,
+			host:    "tcp://127.0.0.1:2376",
+			tls:     "1",
+		},
+		{
+			failure: true,
+			host:    "npipe:////./pipe/docker_engine",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Setenv("DOCKER_HOST", test.host)
+		t.Setenv("DOCKER_TLS_VERIFY", test.tls)
+
+		network, address, err := dockerDaemon()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("dockerDaemon for %s should have returned err", test.host)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("dockerDaemon for %s returned err: %v", test.host, err)
+		}
+
+		if network != test.network || address != test.address {
+			t.Errorf("dockerDaemon for %s is %s %s, want %s %s", test.host, network, address, test.network, test.address)
+		}
+	}
+}
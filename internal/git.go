@@ -3,15 +3,34 @@
 package internal
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	giturls "github.com/chainguard-dev/git-urls"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
 )
 
+// GitHead represents the metadata of the commit
+// checked out in a local git repository.
+type GitHead struct {
+	Commit  string
+	Author  string
+	Email   string
+	Message string
+	Branch  string
+	Ref     string
+}
+
 // SetGitConfigContext attempts to set the org and repo
 // based on the .git/ directory, provided the user has
 // the config flag of no-git set to true.
@@ -119,3 +138,274 @@ func GetGitConfigRepo(path string) string {
 
 	return repoName
 }
+
+// GetGitHead opens the git repository containing the path
+// and captures the metadata for the commit at HEAD.
+func GetGitHead(path string) (*GitHead, error) {
+	// open repository searching parent directories
+	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// capture the reference for HEAD
+	ref, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	// capture the commit for HEAD
+	commit, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	head := &GitHead{
+		Commit:  commit.Hash.String(),
+		Author:  commit.Author.Name,
+		Email:   commit.Author.Email,
+		Message: strings.TrimSpace(commit.Message),
+	}
+
+	// a detached HEAD has no branch to report
+	if ref.Name().IsBranch() {
+		head.Branch = ref.Name().Short()
+		head.Ref = ref.Name().String()
+	}
+
+	return head, nil
+}
+
+// GetGitTargetBranch opens the git repository containing the path
+// and returns the branch a pull request would target. The provided
+// base revision is used when it names a local or remote branch,
+// otherwise the default branch of the origin remote is used.
+func GetGitTargetBranch(path, base string) (string, error) {
+	// open repository searching parent directories
+	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", err
+	}
+
+	if len(base) > 0 {
+		// check if the base revision is a local branch
+		_, err = r.Reference(plumbing.NewBranchReferenceName(base), false)
+		if err == nil {
+			return base, nil
+		}
+
+		// check if the base revision is a remote branch, i.e. origin/main
+		_, err = r.Reference(plumbing.ReferenceName("refs/remotes/"+base), false)
+		if err == nil {
+			_, branch, found := strings.Cut(base, "/")
+			if found {
+				return branch, nil
+			}
+		}
+	}
+
+	// capture the default branch the origin remote points to
+	ref, err := r.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false)
+	if err != nil {
+		return "", fmt.Errorf("unable to capture default branch of origin: %w", err)
+	}
+
+	if ref.Type() != plumbing.SymbolicReference {
+		return "", fmt.Errorf("default branch of origin is not a branch")
+	}
+
+	return strings.TrimPrefix(ref.Target().String(), "refs/remotes/origin/"), nil
+}
+
+// GetGitChangeset opens the git repository containing the path
+// and returns the files changed between the merge base of the
+// provided base revision and HEAD, including uncommitted
+// changes to tracked files in the working tree.
+func GetGitChangeset(path, base string) ([]string, error) {
+	// open repository searching parent directories
+	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// capture the reference for HEAD
+	ref, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the provided base revision
+	hash, err := r.ResolveRevision(plumbing.Revision(base))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve git revision %s: %w", base, err)
+	}
+
+	other, err := r.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// compare against the merge base to match the
+	// files a pull request against base would contain
+	bases, err := head.MergeBase(other)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("no common ancestor found between HEAD and %s", base)
+	}
+
+	fromTree, err := bases[0].Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	toTree, err := head.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]struct{})
+
+	for _, change := range changes {
+		if len(change.From.Name) > 0 {
+			files[change.From.Name] = struct{}{}
+		}
+
+		if len(change.To.Name) > 0 {
+			files[change.To.Name] = struct{}{}
+		}
+	}
+
+	// capture uncommitted changes from the working tree
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	for file, s := range status {
+		if s.Worktree == git.Untracked {
+			continue
+		}
+
+		if s.Staging != git.Unmodified || s.Worktree != git.Unmodified {
+			files[file] = struct{}{}
+		}
+	}
+
+	changeset := make([]string, 0, len(files))
+	for file := range files {
+		changeset = append(changeset, file)
+	}
+
+	slices.Sort(changeset)
+
+	return changeset, nil
+}
+
+// GetGitRoot opens the git repository containing
+// the path and returns the root of the working tree.
+func GetGitRoot(path string) (string, error) {
+	// open repository searching parent directories
+	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	return w.Filesystem.Root(), nil
+}
+
+// ExportGitTree opens the git repository containing the path
+// and writes the files committed at the provided revision
+// into the destination directory. The hash of the exported
+// commit is returned.
+func ExportGitTree(path, revision, dst string) (string, error) {
+	// open repository searching parent directories
+	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", err
+	}
+
+	// resolve the provided revision
+	hash, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve git revision %s: %w", revision, err)
+	}
+
+	commit, err := r.CommitObject(*hash)
+	if err != nil {
+		return "", err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", err
+	}
+
+	// write every file in the tree to the destination
+	err = tree.Files().ForEach(func(f *object.File) error {
+		target := filepath.Join(dst, filepath.FromSlash(f.Name))
+
+		err := os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			return err
+		}
+
+		// symlinks store the link target as the file contents
+		if f.Mode == filemode.Symlink {
+			link, err := f.Contents()
+			if err != nil {
+				return err
+			}
+
+			return os.Symlink(link, target)
+		}
+
+		perm := os.FileMode(0644)
+		if f.Mode == filemode.Executable {
+			perm = 0755
+		}
+
+		reader, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(file, reader)
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return commit.Hash.String(), nil
+}
@@ -4,10 +4,15 @@ package internal
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 func TestGit_GetGitConfigOrg(t *testing.T) {
@@ -82,6 +87,310 @@ func TestGit_GetGitConfigRepo(t *testing.T) {
 	testTearDown()
 }
 
+func TestGit_GetGitHead(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	hash := testCommit(t, r, dir, "README.md", "initial commit\n\nwith body")
+
+	err = os.MkdirAll(filepath.Join(dir, "nested"), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	// run test
+	got, err := GetGitHead(filepath.Join(dir, "nested"))
+	if err != nil {
+		t.Fatalf("GetGitHead returned err: %v", err)
+	}
+
+	want := &GitHead{
+		Commit:  hash.String(),
+		Author:  "Octocat",
+		Email:   "octocat@github.com",
+		Message: "initial commit\n\nwith body",
+		Branch:  "master",
+		Ref:     "refs/heads/master",
+	}
+
+	if *got != *want {
+		t.Errorf("GetGitHead is %+v, want %+v", got, want)
+	}
+
+	_, err = GetGitHead(t.TempDir())
+	if err == nil {
+		t.Errorf("GetGitHead should have returned err for non-git directory")
+	}
+}
+
+func TestGit_GetGitChangeset(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	testCommit(t, r, dir, "README.md", "initial commit")
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("unable to open worktree: %v", err)
+	}
+
+	err = w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true})
+	if err != nil {
+		t.Fatalf("unable to checkout branch: %v", err)
+	}
+
+	testCommit(t, r, dir, "src/main.go", "add main")
+
+	// modify a tracked file without committing
+	err = os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed"), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	// create an untracked file which should be ignored
+	err = os.WriteFile(filepath.Join(dir, "untracked.txt"), []byte("untracked"), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	// run test
+	got, err := GetGitChangeset(dir, "master")
+	if err != nil {
+		t.Fatalf("GetGitChangeset returned err: %v", err)
+	}
+
+	want := []string{"README.md", "src/main.go"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("GetGitChangeset is %v, want %v", got, want)
+	}
+
+	_, err = GetGitChangeset(dir, "notfound")
+	if err == nil {
+		t.Errorf("GetGitChangeset should have returned err for unknown revision")
+	}
+}
+
+func TestGit_GetGitTargetBranch(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	hash := testCommit(t, r, dir, "README.md", "initial commit")
+
+	// a repository without a remote has no default branch
+	_, err = GetGitTargetBranch(dir, "")
+	if err == nil {
+		t.Errorf("GetGitTargetBranch should have returned err without a remote")
+	}
+
+	for _, ref := range []*plumbing.Reference{
+		plumbing.NewHashReference(plumbing.NewBranchReferenceName("develop"), hash),
+		plumbing.NewHashReference("refs/remotes/origin/main", hash),
+		plumbing.NewHashReference("refs/remotes/origin/release", hash),
+		plumbing.NewSymbolicReference(plumbing.NewRemoteHEADReferenceName("origin"), "refs/remotes/origin/main"),
+	} {
+		err = r.Storer.SetReference(ref)
+		if err != nil {
+			t.Fatalf("unable to set reference: %v", err)
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		name string
+		base string
+		want string
+	}{
+		{
+			name: "default branch",
+			base: "",
+			want: "main",
+		},
+		{
+			name: "local branch",
+			base: "develop",
+			want: "develop",
+		},
+		{
+			name: "remote branch",
+			base: "origin/release",
+			want: "release",
+		},
+		{
+			name: "commit",
+			base: hash.String(),
+			want: "main",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetGitTargetBranch(dir, test.base)
+			if err != nil {
+				t.Fatalf("GetGitTargetBranch returned err: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("GetGitTargetBranch is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGit_GetGitRoot(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	_, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Join(dir, "nested"), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	// run test
+	got, err := GetGitRoot(filepath.Join(dir, "nested"))
+	if err != nil {
+		t.Fatalf("GetGitRoot returned err: %v", err)
+	}
+
+	if got != dir {
+		t.Errorf("GetGitRoot is %s, want %s", got, dir)
+	}
+}
+
+func TestGit_ExportGitTree(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unable to init repo: %v", err)
+	}
+
+	first := testCommit(t, r, dir, "README.md", "initial commit")
+
+	testCommit(t, r, dir, "src/main.go", "add main")
+
+	// create an untracked file which should not be exported
+	err = os.WriteFile(filepath.Join(dir, "untracked.txt"), []byte("untracked"), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		revision string
+		commit   plumbing.Hash
+		files    []string
+		missing  []string
+	}{
+		{
+			revision: "HEAD",
+			files:    []string{"README.md", "src/main.go"},
+			missing:  []string{"untracked.txt"},
+		},
+		{
+			revision: first.String(),
+			commit:   first,
+			files:    []string{"README.md"},
+			missing:  []string{"src/main.go", "untracked.txt"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		dst := t.TempDir()
+
+		got, err := ExportGitTree(dir, test.revision, dst)
+		if err != nil {
+			t.Errorf("ExportGitTree for %s returned err: %v", test.revision, err)
+		}
+
+		if !test.commit.IsZero() && got != test.commit.String() {
+			t.Errorf("ExportGitTree for %s is %s, want %s", test.revision, got, test.commit)
+		}
+
+		for _, file := range test.files {
+			_, err := os.Stat(filepath.Join(dst, file))
+			if err != nil {
+				t.Errorf("ExportGitTree for %s did not export %s", test.revision, file)
+			}
+		}
+
+		for _, file := range test.missing {
+			_, err := os.Stat(filepath.Join(dst, file))
+			if err == nil {
+				t.Errorf("ExportGitTree for %s should not export %s", test.revision, file)
+			}
+		}
+	}
+
+	_, err = ExportGitTree(dir, "notfound", t.TempDir())
+	if err == nil {
+		t.Errorf("ExportGitTree should have returned err for unknown revision")
+	}
+}
+
+// testCommit writes and commits a file to the provided repository.
+func testCommit(t *testing.T, r *git.Repository, dir, file, message string) plumbing.Hash {
+	t.Helper()
+
+	path := filepath.Join(dir, file)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("unable to create directory: %v", err)
+	}
+
+	err = os.WriteFile(path, []byte(message), 0644)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("unable to open worktree: %v", err)
+	}
+
+	_, err = w.Add(file)
+	if err != nil {
+		t.Fatalf("unable to add file: %v", err)
+	}
+
+	hash, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Octocat",
+			Email: "octocat@github.com",
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+
+	return hash
+}
+
 func testSetup(t *testing.T) {
 	// setup configs
 	r1, err := git.PlainInit("./testdata/project1/", false)
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// DefaultGitHubURL defines the GitHub instance used
+// when no compiler GitHub url is provided.
+const DefaultGitHubURL = "https://github.com"
+
+// GitHubHost returns the host of the provided compiler GitHub url.
+func GitHubHost(address string) string {
+	if len(address) == 0 {
+		address = DefaultGitHubURL
+	}
+
+	u, err := url.Parse(address)
+	if err != nil || len(u.Host) == 0 {
+		return address
+	}
+
+	return u.Host
+}
+
+// NewGitHubClient creates a client for fetching remote
+// templates with the GitHub settings of the compiler.
+func NewGitHubClient(address, token string) (*github.Client, error) {
+	client := github.NewClient(nil)
+
+	if len(token) > 0 {
+		client = client.WithAuthToken(token)
+	}
+
+	if strings.EqualFold(GitHubHost(address), GitHubHost(DefaultGitHubURL)) {
+		return client, nil
+	}
+
+	// use the API of the GitHub Enterprise instance
+	return client.WithEnterpriseURLs(address, address)
+}
+
+// GetGitHubTemplate fetches the contents of a remote template at the ref of its source.
+func GetGitHubTemplate(ctx context.Context, client *github.Client, src *TemplateSource) ([]byte, error) {
+	opts := new(github.RepositoryContentGetOptions)
+
+	// templates without a ref use the default branch
+	if len(src.Ref) > 0 {
+		opts.Ref = src.Ref
+	}
+
+	file, _, _, err := client.Repositories.GetContents(ctx, src.Org, src.Repo, src.Path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch template %s/%s/%s: %w", src.Org, src.Repo, src.Path, err)
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("unable to fetch template %s/%s/%s: path is a directory", src.Org, src.Repo, src.Path)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode template %s/%s/%s: %w", src.Org, src.Repo, src.Path, err)
+	}
+
+	return []byte(content), nil
+}
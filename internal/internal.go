@@ -43,6 +43,13 @@ const (
 	FlagBuild = "build"
 )
 
+// cache flag keys.
+const (
+	// FlagCache defines the key for the
+	// flag when setting the caches.
+	FlagCache = "cache"
+)
+
 // compiler flag keys.
 const (
 	// FlagCompilerGitHubToken defines the key for the
@@ -212,24 +219,45 @@ const (
 	// ActionCompile defines the action for compiling a resource.
 	ActionCompile = "compile"
 
+	// ActionConvert defines the action for converting a resource.
+	ActionConvert = "convert"
+
+	// ActionDiff defines the action for comparing resources.
+	ActionDiff = "diff"
+
 	// ActionExec defines the action for executing a resource.
 	ActionExec = "exec"
 
 	// ActionExpand defines the action for expanding a resource.
 	ActionExpand = "expand"
 
+	// ActionFormat defines the action for formatting a resource.
+	ActionFormat = "fmt"
+
 	// ActionGenerate defines the action for producing a resource.
 	ActionGenerate = "generate"
 
 	// ActionGet defines the action for getting a list of resources.
 	ActionGet = "get"
 
+	// ActionInit defines the action for initializing a resource.
+	ActionInit = "init"
+
+	// ActionLint defines the action for linting a resource.
+	ActionLint = "lint"
+
 	// ActionLoad defines the action for loading a resource.
 	ActionLoad = "load"
 
+	// ActionLock defines the action for locking a resource.
+	ActionLock = "lock"
+
 	// ActionRemove defines the action for deleting a resource.
 	ActionRemove = "remove"
 
+	// ActionRender defines the action for rendering a resource.
+	ActionRender = "render"
+
 	// ActionRepair defines the action for repairing a resource.
 	ActionRepair = "repair"
 
@@ -242,6 +270,9 @@ const (
 	// ActionSyncAll defines the action for syncing all org resources with SCM.
 	ActionSyncAll = "syncAll"
 
+	// ActionTest defines the action for testing a resource.
+	ActionTest = "test"
+
 	// ActionUpdate defines the action for modifying a resource.
 	ActionUpdate = "update"
 
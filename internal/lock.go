@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.yaml.in/yaml/v3"
+)
+
+// commitPattern matches a ref that is a full commit SHA.
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// LockFile defines the name of the file pinning the
+// remote templates of a pipeline to commits.
+const LockFile = ".vela.lock.yml"
+
+// Lock represents the remote templates of the pipelines in a
+// directory pinned to commits in a lockfile, keyed by the file
+// name of each pipeline.
+type Lock struct {
+	Version   string                   `yaml:"version"`
+	Pipelines map[string]*PipelineLock `yaml:"pipelines"`
+}
+
+// PipelineLock represents the remote templates
+// of a pipeline pinned to commits.
+type PipelineLock struct {
+	Templates []*TemplateLock `yaml:"templates"`
+}
+
+// TemplateLock represents a remote template pinned to a commit.
+type TemplateLock struct {
+	Name   string `yaml:"name"`
+	Source string `yaml:"source"`
+	Commit string `yaml:"commit"`
+}
+
+// RemoteTemplate represents a template of a pipeline sourced from GitHub.
+type RemoteTemplate struct {
+	Name   string `yaml:"name"`
+	Source string `yaml:"source"`
+	Type   string `yaml:"type"`
+}
+
+// TemplateSource represents the parts of the source of a remote
+// template in the format <host>/<org>/<repo>/<path>[@<ref>].
+type TemplateSource struct {
+	Host string
+	Org  string
+	Repo string
+	Path string
+	Ref  string
+}
+
+// Pipeline returns the pinned templates of the
+// pipeline with the provided file name.
+func (l *Lock) Pipeline(file string) *PipelineLock {
+	return l.Pipelines[file]
+}
+
+// Template returns the pinned template with the provided name.
+func (p *PipelineLock) Template(name string) *TemplateLock {
+	for _, tmpl := range p.Templates {
+		if strings.EqualFold(tmpl.Name, name) {
+			return tmpl
+		}
+	}
+
+	return nil
+}
+
+// Pinned returns true if the ref of the source is a full commit SHA.
+func (s *TemplateSource) Pinned() bool {
+	return commitPattern.MatchString(s.Ref)
+}
+
+// ParseTemplateSource parses the source of a remote template into its parts.
+func ParseTemplateSource(source string) (*TemplateSource, error) {
+	src := new(TemplateSource)
+
+	// capture the ref of the template from the source
+	if i := strings.LastIndex(source, "@"); i >= 0 {
+		source, src.Ref = source[:i], source[i+1:]
+	}
+
+	parts := strings.SplitN(source, "/", 4)
+	if len(parts) != 4 || slices.Contains(parts, "") {
+		return nil, fmt.Errorf("invalid template source: %s (valid format: <host>/<org>/<repo>/<path>[@<ref>])", source)
+	}
+
+	src.Host, src.Org, src.Repo, src.Path = parts[0], parts[1], parts[2], parts[3]
+
+	return src, nil
+}
+
+// RemoteTemplates returns the templates of the pipeline that are
+// sourced from GitHub, skipping templates sourced from local files.
+func RemoteTemplates(pipeline []byte) ([]*RemoteTemplate, error) {
+	p := struct {
+		Templates []*RemoteTemplate `yaml:"templates"`
+	}{}
+
+	err := yaml.Unmarshal(pipeline, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []*RemoteTemplate{}
+
+	for _, tmpl := range p.Templates {
+		if strings.EqualFold(tmpl.Type, "file") {
+			continue
+		}
+
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// ReadLock reads the lockfile from the provided directory,
+// returning no lock when the lockfile does not exist.
+func ReadLock(dir string) (*Lock, error) {
+	path := filepath.Join(dir, LockFile)
+
+	logrus.Tracef("reading lockfile %s", path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read lockfile %s: %w", path, err)
+	}
+
+	lock := new(Lock)
+
+	err = yaml.Unmarshal(data, lock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse lockfile %s: %w", path, err)
+	}
+
+	return lock, nil
+}
+
+// WriteLock writes the lockfile to the provided directory.
+func WriteLock(dir string, lock *Lock) error {
+	path := filepath.Join(dir, LockFile)
+
+	logrus.Tracef("writing lockfile %s", path)
+
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("unable to create lockfile %s: %w", path, err)
+	}
+
+	header := "# This file is generated by `vela lock templates`. DO NOT EDIT.\n"
+
+	err = os.WriteFile(path, append([]byte(header), data...), 0o644) //nolint:gosec // lockfile is committed with the pipeline
+	if err != nil {
+		return fmt.Errorf("unable to write lockfile %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// PinTemplates rewrites the source of the remote templates of a
+// pipeline to reference the commits they are pinned to in the lock.
+//
+// Templates missing from the lock, or pinned from a different source,
+// are left unchanged.
+func PinTemplates(pipeline []byte, lock *PipelineLock) ([]byte, error) {
+	doc := new(yaml.Node)
+
+	err := yaml.Unmarshal(pipeline, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return pipeline, nil
+	}
+
+	templates := mappingValue(doc.Content[0], "templates")
+	if templates == nil || templates.Kind != yaml.SequenceNode {
+		return pipeline, nil
+	}
+
+	pinned := false
+
+	for _, item := range templates.Content {
+		name, source := mappingValue(item, "name"), mappingValue(item, "source")
+		if name == nil || source == nil {
+			continue
+		}
+
+		// skip templates sourced from local files
+		if kind := mappingValue(item, "type"); kind != nil && strings.EqualFold(kind.Value, "file") {
+			continue
+		}
+
+		locked := lock.Template(name.Value)
+		if locked == nil {
+			logrus.Warnf("template %s is not pinned in %s", name.Value, LockFile)
+
+			continue
+		}
+
+		if locked.Source != source.Value {
+			logrus.Warnf("template %s is pinned from %s in %s, not %s", name.Value, locked.Source, LockFile, source.Value)
+
+			continue
+		}
+
+		base := source.Value
+		if i := strings.LastIndex(base, "@"); i >= 0 {
+			base = base[:i]
+		}
+
+		logrus.Debugf("pinning template %s to commit %s", name.Value, locked.Commit)
+
+		source.Value = fmt.Sprintf("%s@%s", base, locked.Commit)
+		pinned = true
+	}
+
+	if !pinned {
+		return pipeline, nil
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// mappingValue returns the value for the key of a yaml mapping node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"reflect"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestInternal_ParseTemplateSource(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		source  string
+		want    *TemplateSource
+	}{
+		{
+			name:    "with ref",
+			failure: false,
+			source:  "github.com/octocat/templates/go/build.yml@main",
+			want:    &TemplateSource{Host: "github.com", Org: "octocat", Repo: "templates", Path: "go/build.yml", Ref: "main"},
+		},
+		{
+			name:    "without ref",
+			failure: false,
+			source:  "git.example.com/octocat/templates/build.yml",
+			want:    &TemplateSource{Host: "git.example.com", Org: "octocat", Repo: "templates", Path: "build.yml"},
+		},
+		{
+			name:    "missing path",
+			failure: true,
+			source:  "github.com/octocat/templates@main",
+		},
+		{
+			name:    "empty part",
+			failure: true,
+			source:  "github.com//templates/build.yml",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseTemplateSource(test.source)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("ParseTemplateSource should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseTemplateSource returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseTemplateSource is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestInternal_ReadLock_WriteLock(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	want := &Lock{
+		Version: "1",
+		Pipelines: map[string]*PipelineLock{
+			".vela.yml": {
+				Templates: []*TemplateLock{
+					{Name: "go", Source: "github.com/octocat/templates/go.yml@main", Commit: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"},
+				},
+			},
+		},
+	}
+
+	got, err := ReadLock(dir)
+	if err != nil {
+		t.Errorf("ReadLock returned err: %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("ReadLock is %v, want nil", got)
+	}
+
+	err = WriteLock(dir, want)
+	if err != nil {
+		t.Errorf("WriteLock returned err: %v", err)
+	}
+
+	got, err = ReadLock(dir)
+	if err != nil {
+		t.Errorf("ReadLock returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadLock is %v, want %v", got, want)
+	}
+}
+
+func TestInternal_PinTemplates(t *testing.T) {
+	// setup types
+	lock := &PipelineLock{
+		Templates: []*TemplateLock{
+			{Name: "go", Source: "github.com/octocat/templates/go.yml@main", Commit: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"},
+			{Name: "docker", Source: "github.com/octocat/templates/docker.yml@v1", Commit: "2f1e9ad6d6c5f5a6b2d1c3e4f5a6b7c8d9e0f1a2"},
+		},
+	}
+
+	pipeline := `version: "1"
+templates:
+  - name: go
+    source: github.com/octocat/templates/go.yml@main
+    type: github
+  - name: docker
+    source: github.com/octocat/templates/docker.yml@v2
+    type: github
+  - name: lint
+    source: github.com/octocat/templates/lint.yml
+    type: github
+  - name: local
+    source: templates/local.yml
+    type: file
+steps:
+  - name: build
+    template:
+      name: go
+`
+
+	want := []string{
+		"github.com/octocat/templates/go.yml@7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		"github.com/octocat/templates/docker.yml@v2",
+		"github.com/octocat/templates/lint.yml",
+		"templates/local.yml",
+	}
+
+	got, err := PinTemplates([]byte(pipeline), lock)
+	if err != nil {
+		t.Fatalf("PinTemplates returned err: %v", err)
+	}
+
+	parsed := struct {
+		Templates []struct {
+			Source string `yaml:"source"`
+		} `yaml:"templates"`
+	}{}
+
+	err = yaml.Unmarshal(got, &parsed)
+	if err != nil {
+		t.Fatalf("unable to parse pipeline: %v", err)
+	}
+
+	sources := []string{}
+	for _, tmpl := range parsed.Templates {
+		sources = append(sources, tmpl.Source)
+	}
+
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("PinTemplates sources are %v, want %v", sources, want)
+	}
+
+	// pin a pipeline without any locked templates
+	unchanged := "version: \"1\"\nsteps:\n  - name: build\n    image: golang\n"
+
+	got, err = PinTemplates([]byte(unchanged), lock)
+	if err != nil {
+		t.Errorf("PinTemplates returned err: %v", err)
+	}
+
+	if string(got) != unchanged {
+		t.Errorf("PinTemplates is %s, want %s", got, unchanged)
+	}
+}
@@ -0,0 +1,484 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-vela/server/schema"
+)
+
+// schemaLegacyValues defines the values of keys the compiler still accepts
+// for compatibility, even though the schema does not, which are reported
+// as deprecated by the linter instead.
+var schemaLegacyValues = map[string][]string{
+	"pull": {"true", "false"},
+}
+
+// SchemaError represents a location in a pipeline
+// that does not match the schema for pipelines.
+type SchemaError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Error returns the location and message of the error
+// in the format <file>:<line>:<column>: <message>.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// schemaNode represents the parts of a JSON Schema
+// used to verify a pipeline against the schema.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+	Definitions          map[string]*schemaNode `json:"definitions"`
+	Type                 json.RawMessage        `json:"type"`
+	Enum                 []any                  `json:"enum"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	PatternProperties    map[string]*schemaNode `json:"patternProperties"`
+	AdditionalProperties *schemaNode            `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	AllOf                []*schemaNode          `json:"allOf"`
+	AnyOf                []*schemaNode          `json:"anyOf"`
+	OneOf                []*schemaNode          `json:"oneOf"`
+
+	// Bool is set for the boolean schemas that allow or deny any value.
+	Bool *bool `json:"-"`
+	// Types is the list of types parsed from the type of the schema.
+	Types []string `json:"-"`
+}
+
+// UnmarshalJSON decodes the schema, which can be a
+// boolean schema as well as a schema object.
+func (s *schemaNode) UnmarshalJSON(data []byte) error {
+	var b bool
+
+	err := json.Unmarshal(data, &b)
+	if err == nil {
+		s.Bool = &b
+
+		return nil
+	}
+
+	type plain schemaNode
+
+	err = json.Unmarshal(data, (*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	// the type of the schema can be a single type or a list of types
+	if len(s.Type) > 0 {
+		var t string
+
+		if json.Unmarshal(s.Type, &t) == nil {
+			s.Types = []string{t}
+		} else {
+			err = json.Unmarshal(s.Type, &s.Types)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PipelineSchema returns the JSON Schema for the Vela YAML
+// pipeline format, which editors can use for autocompletion.
+func PipelineSchema() ([]byte, error) {
+	// https://pkg.go.dev/github.com/go-vela/server/schema?tab=doc#NewPipelineSchema
+	s, err := schema.NewPipelineSchema()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pipeline schema: %w", err)
+	}
+
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// pipelineSchema parses the schema for pipelines once, since it is
+// shared between the pipelines verified against the schema.
+var pipelineSchema = sync.OnceValues(func() (*schemaNode, error) {
+	data, err := PipelineSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(schemaNode)
+
+	err = json.Unmarshal(data, s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pipeline schema: %w", err)
+	}
+
+	return s, nil
+})
+
+// ValidateSchema verifies the pipeline against the schema for pipelines,
+// returning every unknown key, type mismatch and invalid enum value with
+// its location in the file.
+//
+// Pipelines that can not be parsed, or are rendered as a template,
+// are left for the compiler to report. Like the schema, any top level
+// keys are allowed, since pipelines declare them to hold yaml anchors.
+func ValidateSchema(file string, pipeline []byte) ([]*SchemaError, error) {
+	root, err := pipelineSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := new(yaml.Node)
+
+	err = yaml.Unmarshal(pipeline, doc)
+	if err != nil {
+		logrus.Debugf("unable to parse pipeline %s for schema validation: %v", file, err)
+
+		return nil, nil
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	node := doc.Content[0]
+
+	// check if the pipeline is rendered as a template before it is parsed
+	if inline := mappingValue(mappingValue(node, "metadata"), "render_inline"); inline != nil && inline.Value == "true" {
+		logrus.Debugf("skipping schema validation for inline rendered pipeline %s", file)
+
+		return nil, nil
+	}
+
+	v := &schemaValidator{root: root, file: file}
+
+	errs := v.validate(node, root, "pipeline")
+
+	slices.SortStableFunc(errs, func(a, b *SchemaError) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return a.Column - b.Column
+	})
+
+	return slices.CompactFunc(errs, func(a, b *SchemaError) bool {
+		return *a == *b
+	}), nil
+}
+
+// schemaValidator verifies the nodes of a pipeline against a schema.
+type schemaValidator struct {
+	root *schemaNode
+	file string
+}
+
+// validate returns the locations where the node does not match the schema.
+func (v *schemaValidator) validate(node *yaml.Node, s *schemaNode, path string) []*SchemaError {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	s = v.resolve(s)
+
+	if s == nil {
+		return nil
+	}
+
+	if s.Bool != nil {
+		if *s.Bool {
+			return nil
+		}
+
+		return []*SchemaError{v.error(node, path, "%s is not allowed", path)}
+	}
+
+	errs := []*SchemaError{}
+
+	for _, sub := range s.AllOf {
+		errs = append(errs, v.validate(node, sub, path)...)
+	}
+
+	// check if the node matches any of the alternatives of the schema
+	if alternatives := slices.Concat(s.OneOf, s.AnyOf); len(alternatives) > 0 {
+		var closest []*SchemaError
+
+		matched := false
+
+		for _, alt := range alternatives {
+			// skip the alternatives of a different type to report
+			// the errors of the alternative the node is written as
+			if !v.matches(node, alt) {
+				continue
+			}
+
+			altErrs := v.validate(node, alt, path)
+			if len(altErrs) == 0 {
+				matched = true
+
+				break
+			}
+
+			if closest == nil || len(altErrs) < len(closest) {
+				closest = altErrs
+			}
+		}
+
+		if !matched {
+			if closest == nil {
+				return append(errs, v.typeError(node, path, v.types(&schemaNode{OneOf: alternatives}, 0)))
+			}
+
+			return append(errs, closest...)
+		}
+	}
+
+	if !matchesTypes(node, s.Types) {
+		return append(errs, v.typeError(node, path, s.Types))
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if len(s.Enum) > 0 && node.ShortTag() != "!!null" {
+			values := []string{}
+
+			for _, value := range s.Enum {
+				values = append(values, fmt.Sprint(value))
+			}
+
+			if !slices.Contains(values, node.Value) {
+				errs = append(errs, v.error(node, path, "invalid value %q for %s, must be one of: %s",
+					node.Value, path, strings.Join(values, ", ")))
+			}
+		}
+	case yaml.MappingNode:
+		errs = append(errs, v.validateMapping(node, s, path)...)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range node.Content {
+				errs = append(errs, v.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateMapping returns the locations where the keys
+// and values of the mapping do not match the schema.
+func (v *schemaValidator) validateMapping(node *yaml.Node, s *schemaNode, path string) []*SchemaError {
+	errs := []*SchemaError{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		// verify the keys merged into the mapping from an anchor
+		if key.Tag == "!!merge" {
+			merged := []*yaml.Node{value}
+
+			if value.Kind == yaml.SequenceNode {
+				merged = value.Content
+			}
+
+			for _, m := range merged {
+				errs = append(errs, v.validate(m, s, path)...)
+			}
+
+			continue
+		}
+
+		child := fmt.Sprintf("%s.%s", path, key.Value)
+		if path == "pipeline" {
+			child = key.Value
+		}
+
+		// skip the values the compiler accepts for compatibility
+		if slices.Contains(schemaLegacyValues[key.Value], value.Value) && value.Kind == yaml.ScalarNode {
+			continue
+		}
+
+		if prop, ok := s.Properties[key.Value]; ok {
+			errs = append(errs, v.validate(value, prop, child)...)
+
+			continue
+		}
+
+		matched := false
+
+		for pattern, prop := range s.PatternProperties {
+			re, err := regexp.Compile(pattern)
+			if err != nil || !re.MatchString(key.Value) {
+				continue
+			}
+
+			matched = true
+
+			errs = append(errs, v.validate(value, prop, child)...)
+		}
+
+		if matched || s.AdditionalProperties == nil {
+			continue
+		}
+
+		additional := v.resolve(s.AdditionalProperties)
+		if additional != nil && additional.Bool != nil && !*additional.Bool {
+			errs = append(errs, v.error(key, path, "unknown key %q in %s", key.Value, path))
+
+			continue
+		}
+
+		errs = append(errs, v.validate(value, additional, child)...)
+	}
+
+	return errs
+}
+
+// resolve returns the schema referenced by the schema.
+func (v *schemaValidator) resolve(s *schemaNode) *schemaNode {
+	// limit the references followed to avoid cycles
+	for range 10 {
+		if s == nil || len(s.Ref) == 0 {
+			return s
+		}
+
+		name, found := strings.CutPrefix(s.Ref, "#/$defs/")
+		if !found {
+			name, found = strings.CutPrefix(s.Ref, "#/definitions/")
+		}
+
+		switch {
+		case s.Ref == "#":
+			s = v.root
+		case found && v.root.Defs[name] != nil:
+			s = v.root.Defs[name]
+		case found && v.root.Definitions[name] != nil:
+			s = v.root.Definitions[name]
+		default:
+			logrus.Debugf("unable to resolve schema reference %s", s.Ref)
+
+			return nil
+		}
+	}
+
+	return s
+}
+
+// types returns the types allowed by the schema, including its alternatives.
+func (v *schemaValidator) types(s *schemaNode, depth int) []string {
+	s = v.resolve(s)
+
+	if s == nil || depth > 10 {
+		return nil
+	}
+
+	types := slices.Clone(s.Types)
+
+	for _, alt := range slices.Concat(s.OneOf, s.AnyOf) {
+		types = append(types, v.types(alt, depth+1)...)
+	}
+
+	slices.Sort(types)
+
+	return slices.Compact(types)
+}
+
+// matches returns true if the node is one of the types allowed by the schema.
+func (v *schemaValidator) matches(node *yaml.Node, s *schemaNode) bool {
+	return matchesTypes(node, v.types(s, 0))
+}
+
+// matchesTypes returns true if the node is one of the types.
+//
+// Scalars match strings and empty values match any type,
+// since the compiler decodes them into any field of that type.
+func matchesTypes(node *yaml.Node, types []string) bool {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if len(types) == 0 || (node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null") {
+		return true
+	}
+
+	for _, t := range types {
+		switch t {
+		case "object":
+			if node.Kind == yaml.MappingNode {
+				return true
+			}
+		case "array":
+			if node.Kind == yaml.SequenceNode {
+				return true
+			}
+		case "string":
+			if node.Kind == yaml.ScalarNode {
+				return true
+			}
+		case "integer":
+			if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!int" {
+				return true
+			}
+		case "number":
+			if node.Kind == yaml.ScalarNode && (node.ShortTag() == "!!int" || node.ShortTag() == "!!float") {
+				return true
+			}
+		case "boolean":
+			if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!bool" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// typeError creates the error for a node that is not one of the types.
+func (v *schemaValidator) typeError(node *yaml.Node, path string, types []string) *SchemaError {
+	return v.error(node, path, "%s must be %s, got %s", path, strings.Join(types, " or "), nodeType(node))
+}
+
+// error creates the error for the location of the node.
+func (v *schemaValidator) error(node *yaml.Node, path, format string, args ...any) *SchemaError {
+	return &SchemaError{
+		File:    v.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// nodeType returns the JSON type of the node.
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch node.ShortTag() {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!null":
+		return "null"
+	default:
+		return "string"
+	}
+}
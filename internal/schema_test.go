@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestInternal_PipelineSchema(t *testing.T) {
+	// run test
+	got, err := PipelineSchema()
+	if err != nil {
+		t.Fatalf("PipelineSchema returned err: %v", err)
+	}
+
+	s := new(schemaNode)
+
+	err = json.Unmarshal(got, s)
+	if err != nil {
+		t.Errorf("PipelineSchema returned invalid JSON: %v", err)
+	}
+}
+
+func TestInternal_ValidateSchema(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name     string
+		pipeline string
+		want     []string
+	}{
+		{
+			name: "valid pipeline",
+			pipeline: `version: "1"
+
+steps:
+  - name: test
+    image: golang:latest
+    pull: always
+    commands:
+      - go test ./...
+`,
+			want: []string{},
+		},
+		{
+			name: "unknown key",
+			pipeline: `version: "1"
+
+steps:
+  - name: test
+    image: golang:latest
+    command: go test ./...
+`,
+			want: []string{`.vela.yml:6:5: unknown key "command" in steps[0]`},
+		},
+		{
+			name: "unknown top level key",
+			pipeline: `version: "1"
+
+x-defaults: &defaults
+  pull: always
+
+steps:
+  - <<: *defaults
+    name: test
+    image: golang:latest
+`,
+			want: []string{},
+		},
+		{
+			name: "inline rendered pipeline",
+			pipeline: `version: "1"
+
+metadata:
+  render_inline: true
+
+{{ range .steps }}
+`,
+			want: []string{},
+		},
+		{
+			name:     "unparsable pipeline",
+			pipeline: "steps: [",
+			want:     []string{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs, err := ValidateSchema(".vela.yml", []byte(test.pipeline))
+			if err != nil {
+				t.Fatalf("ValidateSchema returned err: %v", err)
+			}
+
+			got := []string{}
+
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ValidateSchema is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestInternal_schemaValidator(t *testing.T) {
+	// setup types
+	root := new(schemaNode)
+
+	err := json.Unmarshal([]byte(`{
+  "$ref": "#/$defs/Build",
+  "$defs": {
+    "Build": {
+      "type": "object",
+      "properties": {
+        "version": {"type": "string"},
+        "environment": {
+          "oneOf": [
+            {"type": "object", "additionalProperties": {"type": "string"}},
+            {"type": "array", "items": {"type": "string"}}
+          ]
+        },
+        "steps": {"type": "array", "items": {"$ref": "#/$defs/Step"}}
+      },
+      "patternProperties": {"^x-": true},
+      "additionalProperties": false
+    },
+    "Step": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "detach": {"type": "boolean"},
+        "pull": {"type": "string", "enum": ["always", "not_present", "on_start", "never"]},
+        "commands": {"oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]}
+      },
+      "additionalProperties": false
+    }
+  }
+}`), root)
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		pipeline string
+		want     []string
+	}{
+		{
+			name: "valid pipeline",
+			pipeline: `version: 1
+x-defaults: &defaults
+  pull: always
+environment:
+  - FOO=bar
+steps:
+  - <<: *defaults
+    name: test
+    pull: true
+    commands: go test ./...
+`,
+			want: []string{},
+		},
+		{
+			name: "invalid pipeline",
+			pipeline: `version: "1"
+x-defaults: &defaults
+  pul: always
+environment: foo
+steps:
+  - <<: *defaults
+    name: test
+    detach: [true]
+    pull: sometimes
+    commands:
+      - go test ./...
+      - {go: test}
+`,
+			want: []string{
+				`.vela.yml:4:14: environment must be array or object, got string`,
+				`.vela.yml:3:3: unknown key "pul" in steps[0]`,
+				`.vela.yml:8:13: steps[0].detach must be boolean, got array`,
+				`.vela.yml:9:11: invalid value "sometimes" for steps[0].pull, must be one of: always, not_present, on_start, never`,
+				`.vela.yml:12:9: steps[0].commands[1] must be string, got object`,
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := new(yaml.Node)
+
+			err := yaml.Unmarshal([]byte(test.pipeline), doc)
+			if err != nil {
+				t.Fatalf("unable to parse pipeline: %v", err)
+			}
+
+			v := &schemaValidator{root: root, file: ".vela.yml"}
+
+			got := []string{}
+
+			for _, e := range v.validate(doc.Content[0], root, "pipeline") {
+				got = append(got, e.Error())
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("validate is\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// templateCacheIndex defines the file in the template cache
+// mapping the source of a template to its contents.
+const templateCacheIndex = "index.json"
+
+// templateCacheMu serializes updates to the index of the
+// template cache from concurrent pipeline compilations.
+var templateCacheMu sync.Mutex
+
+// DefaultTemplateCache returns the default directory of the template cache.
+func DefaultTemplateCache() string {
+	return filepath.Join(os.Getenv("HOME"), ".vela", "templates")
+}
+
+// CachedTemplate represents a remote template stored in the
+// template cache, keyed by its source and ref.
+type CachedTemplate struct {
+	Source  string `json:"source" yaml:"source"`
+	Digest  string `json:"digest" yaml:"digest"`
+	Size    int    `json:"size" yaml:"size"`
+	Path    string `json:"path" yaml:"path"`
+	Created string `json:"created" yaml:"created"`
+}
+
+// GetCachedTemplate returns the cached template for the
+// provided source, or nil when the template is not cached.
+func GetCachedTemplate(dir, source string) (*CachedTemplate, error) {
+	index, err := readTemplateCache(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, ok := index[source]
+	if !ok {
+		return nil, nil
+	}
+
+	// treat a template with missing contents as not cached
+	_, err = os.Stat(tmpl.Path)
+	if err != nil {
+		logrus.Debugf("contents of cached template %s not found: %v", source, err)
+
+		return nil, nil
+	}
+
+	return tmpl, nil
+}
+
+// CacheTemplate stores the contents of the template for the provided
+// source in the template cache, addressed by the digest of the contents.
+func CacheTemplate(dir, source string, data []byte) (*CachedTemplate, error) {
+	templateCacheMu.Lock()
+	defer templateCacheMu.Unlock()
+
+	index, err := readTemplateCache(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	tmpl := &CachedTemplate{
+		Source:  source,
+		Digest:  fmt.Sprintf("sha256:%s", digest),
+		Size:    len(data),
+		Path:    filepath.Join(dir, "blobs", digest),
+		Created: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	logrus.Tracef("caching template %s in %s", source, tmpl.Path)
+
+	err = os.MkdirAll(filepath.Dir(tmpl.Path), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create template cache %s: %w", dir, err)
+	}
+
+	// only write contents that are not already cached
+	_, err = os.Stat(tmpl.Path)
+	if err != nil {
+		err = os.WriteFile(tmpl.Path, data, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to cache template %s: %w", source, err)
+		}
+	}
+
+	index[source] = tmpl
+
+	err = writeTemplateCache(dir, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// ListCachedTemplates returns the templates in the template cache.
+func ListCachedTemplates(dir string) ([]*CachedTemplate, error) {
+	index, err := readTemplateCache(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []*CachedTemplate{}
+
+	for _, source := range slices.Sorted(maps.Keys(index)) {
+		templates = append(templates, index[source])
+	}
+
+	return templates, nil
+}
+
+// RemoveCachedTemplates removes the template for the provided source from
+// the template cache, or every template when no source is provided, along
+// with any contents no longer referenced by a cached template.
+func RemoveCachedTemplates(dir, source string) ([]*CachedTemplate, error) {
+	templateCacheMu.Lock()
+	defer templateCacheMu.Unlock()
+
+	index, err := readTemplateCache(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []*CachedTemplate{}
+
+	for _, key := range slices.Sorted(maps.Keys(index)) {
+		if len(source) > 0 && key != source {
+			continue
+		}
+
+		removed = append(removed, index[key])
+
+		delete(index, key)
+	}
+
+	if len(source) > 0 && len(removed) == 0 {
+		return nil, fmt.Errorf("template %s not found in template cache", source)
+	}
+
+	err = writeTemplateCache(dir, index)
+	if err != nil {
+		return nil, err
+	}
+
+	// capture the contents still referenced by cached templates
+	referenced := make(map[string]bool)
+	for _, tmpl := range index {
+		referenced[tmpl.Path] = true
+	}
+
+	for _, tmpl := range removed {
+		if referenced[tmpl.Path] {
+			continue
+		}
+
+		logrus.Tracef("removing contents of cached template %s", tmpl.Source)
+
+		err = os.Remove(tmpl.Path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("unable to remove cached template %s: %w", tmpl.Source, err)
+		}
+
+		referenced[tmpl.Path] = true
+	}
+
+	return removed, nil
+}
+
+// readTemplateCache reads the index of the template cache.
+func readTemplateCache(dir string) (map[string]*CachedTemplate, error) {
+	index := make(map[string]*CachedTemplate)
+
+	data, err := os.ReadFile(filepath.Join(dir, templateCacheIndex))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return index, nil
+		}
+
+		return nil, fmt.Errorf("unable to read template cache %s: %w", dir, err)
+	}
+
+	err = json.Unmarshal(data, &index)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template cache %s: %w", dir, err)
+	}
+
+	return index, nil
+}
+
+// writeTemplateCache writes the index of the template cache.
+func writeTemplateCache(dir string, index map[string]*CachedTemplate) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to create template cache %s: %w", dir, err)
+	}
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return fmt.Errorf("unable to create template cache %s: %w", dir, err)
+	}
+
+	// write the index to a temporary file first to avoid partial writes
+	tmp := filepath.Join(dir, fmt.Sprintf(".%s.%d", templateCacheIndex, os.Getpid()))
+
+	err = os.WriteFile(tmp, data, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to write template cache %s: %w", dir, err)
+	}
+
+	err = os.Rename(tmp, filepath.Join(dir, templateCacheIndex))
+	if err != nil {
+		return fmt.Errorf("unable to write template cache %s: %w", dir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"os"
+	"testing"
+)
+
+func TestInternal_CachedTemplates(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	main := "github.com/octocat/templates/go.yml@main"
+	pinned := "github.com/octocat/templates/go.yml@7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"
+	docker := "github.com/octocat/templates/docker.yml@v1"
+
+	// check a template missing from the cache
+	got, err := GetCachedTemplate(dir, main)
+	if err != nil {
+		t.Errorf("GetCachedTemplate returned err: %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("GetCachedTemplate is %v, want nil", got)
+	}
+
+	// cache templates, where two sources share the same contents
+	for source, contents := range map[string]string{main: "steps: []\n", pinned: "steps: []\n", docker: "services: []\n"} {
+		_, err = CacheTemplate(dir, source, []byte(contents))
+		if err != nil {
+			t.Fatalf("CacheTemplate returned err: %v", err)
+		}
+	}
+
+	got, err = GetCachedTemplate(dir, main)
+	if err != nil {
+		t.Errorf("GetCachedTemplate returned err: %v", err)
+	}
+
+	if got == nil || got.Size != 10 || got.Digest != "sha256:315b81de5a786a8106206c4da56557e62ebd1907bf9a7345d7bec96eccdbc104" {
+		t.Fatalf("GetCachedTemplate is %v, want cached template", got)
+	}
+
+	data, err := os.ReadFile(got.Path)
+	if err != nil || string(data) != "steps: []\n" {
+		t.Errorf("cached template contents are %q, want %q", data, "steps: []\n")
+	}
+
+	templates, err := ListCachedTemplates(dir)
+	if err != nil {
+		t.Errorf("ListCachedTemplates returned err: %v", err)
+	}
+
+	if len(templates) != 3 || templates[0].Source != docker {
+		t.Errorf("ListCachedTemplates is %v, want 3 sorted templates", templates)
+	}
+
+	// remove a template sharing its contents with another template
+	_, err = RemoveCachedTemplates(dir, main)
+	if err != nil {
+		t.Errorf("RemoveCachedTemplates returned err: %v", err)
+	}
+
+	got, err = GetCachedTemplate(dir, pinned)
+	if err != nil || got == nil {
+		t.Errorf("GetCachedTemplate is %v, want cached template with shared contents", got)
+	}
+
+	// remove a template missing from the cache
+	_, err = RemoveCachedTemplates(dir, main)
+	if err == nil {
+		t.Errorf("RemoveCachedTemplates should have returned err")
+	}
+
+	// remove all templates
+	removed, err := RemoveCachedTemplates(dir, "")
+	if err != nil {
+		t.Errorf("RemoveCachedTemplates returned err: %v", err)
+	}
+
+	if len(removed) != 2 {
+		t.Errorf("RemoveCachedTemplates removed %d templates, want 2", len(removed))
+	}
+
+	_, err = os.Stat(got.Path)
+	if err == nil {
+		t.Errorf("contents of removed template %s still exist", got.Source)
+	}
+}